@@ -0,0 +1,575 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: onos/ransim/faults/faults.proto
+
+// Package onos.ransim.faults defines the API of the faults injected on the E2 interface of the simulated E2 nodes
+
+package faults
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FaultProfile is the profile of the faults injected on the E2 interface of a node; the rates are the probabilities,
+// between 0 and 1, of injecting the fault in a message
+type FaultProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// indication_delay delays every indication
+	IndicationDelay *durationpb.Duration `protobuf:"bytes,1,opt,name=indication_delay,json=indicationDelay,proto3" json:"indication_delay,omitempty"`
+	DropRate        float64              `protobuf:"fixed64,2,opt,name=drop_rate,json=dropRate,proto3" json:"drop_rate,omitempty"`
+	DuplicateRate   float64              `protobuf:"fixed64,3,opt,name=duplicate_rate,json=duplicateRate,proto3" json:"duplicate_rate,omitempty"`
+	// reorder_rate is the rate of the indications held back and sent after the next indication
+	ReorderRate float64 `protobuf:"fixed64,4,opt,name=reorder_rate,json=reorderRate,proto3" json:"reorder_rate,omitempty"`
+	// corrupt_rate is the rate of the indications whose encoded E2AP message is corrupted
+	CorruptRate float64 `protobuf:"fixed64,5,opt,name=corrupt_rate,json=corruptRate,proto3" json:"corrupt_rate,omitempty"`
+	// subscription_reject_cause is the name of the E2AP cause rejecting every subscription, e.g. CAUSE_MISC_OM_INTERVENTION
+	SubscriptionRejectCause string  `protobuf:"bytes,6,opt,name=subscription_reject_cause,json=subscriptionRejectCause,proto3" json:"subscription_reject_cause,omitempty"`
+	ControlFailureRate      float64 `protobuf:"fixed64,7,opt,name=control_failure_rate,json=controlFailureRate,proto3" json:"control_failure_rate,omitempty"`
+}
+
+func (x *FaultProfile) Reset() {
+	*x = FaultProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_faults_faults_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultProfile) ProtoMessage() {}
+
+func (x *FaultProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_faults_faults_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultProfile.ProtoReflect.Descriptor instead.
+func (*FaultProfile) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_faults_faults_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FaultProfile) GetIndicationDelay() *durationpb.Duration {
+	if x != nil {
+		return x.IndicationDelay
+	}
+	return nil
+}
+
+func (x *FaultProfile) GetDropRate() float64 {
+	if x != nil {
+		return x.DropRate
+	}
+	return 0
+}
+
+func (x *FaultProfile) GetDuplicateRate() float64 {
+	if x != nil {
+		return x.DuplicateRate
+	}
+	return 0
+}
+
+func (x *FaultProfile) GetReorderRate() float64 {
+	if x != nil {
+		return x.ReorderRate
+	}
+	return 0
+}
+
+func (x *FaultProfile) GetCorruptRate() float64 {
+	if x != nil {
+		return x.CorruptRate
+	}
+	return 0
+}
+
+func (x *FaultProfile) GetSubscriptionRejectCause() string {
+	if x != nil {
+		return x.SubscriptionRejectCause
+	}
+	return ""
+}
+
+func (x *FaultProfile) GetControlFailureRate() float64 {
+	if x != nil {
+		return x.ControlFailureRate
+	}
+	return 0
+}
+
+type GetFaultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid uint64 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+}
+
+func (x *GetFaultsRequest) Reset() {
+	*x = GetFaultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_faults_faults_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFaultsRequest) ProtoMessage() {}
+
+func (x *GetFaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_faults_faults_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFaultsRequest.ProtoReflect.Descriptor instead.
+func (*GetFaultsRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_faults_faults_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFaultsRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+type GetFaultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid   uint64        `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	Profile *FaultProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// counters are the numbers of faults of each kind injected so far
+	Counters map[string]uint64 `protobuf:"bytes,3,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *GetFaultsResponse) Reset() {
+	*x = GetFaultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_faults_faults_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFaultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFaultsResponse) ProtoMessage() {}
+
+func (x *GetFaultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_faults_faults_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFaultsResponse.ProtoReflect.Descriptor instead.
+func (*GetFaultsResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_faults_faults_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetFaultsResponse) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *GetFaultsResponse) GetProfile() *FaultProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *GetFaultsResponse) GetCounters() map[string]uint64 {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type SetFaultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid   uint64        `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	Profile *FaultProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *SetFaultsRequest) Reset() {
+	*x = SetFaultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_faults_faults_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFaultsRequest) ProtoMessage() {}
+
+func (x *SetFaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_faults_faults_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFaultsRequest.ProtoReflect.Descriptor instead.
+func (*SetFaultsRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_faults_faults_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetFaultsRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *SetFaultsRequest) GetProfile() *FaultProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type SetFaultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid   uint64        `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	Profile *FaultProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// counters are the numbers of faults of each kind injected so far
+	Counters map[string]uint64 `protobuf:"bytes,3,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *SetFaultsResponse) Reset() {
+	*x = SetFaultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_faults_faults_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFaultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFaultsResponse) ProtoMessage() {}
+
+func (x *SetFaultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_faults_faults_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFaultsResponse.ProtoReflect.Descriptor instead.
+func (*SetFaultsResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_faults_faults_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetFaultsResponse) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *SetFaultsResponse) GetProfile() *FaultProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *SetFaultsResponse) GetCounters() map[string]uint64 {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+var File_onos_ransim_faults_faults_proto protoreflect.FileDescriptor
+
+var file_onos_ransim_faults_faults_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2f, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x2f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x02, 0x0a, 0x0c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x6e, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x1b, 0x0a, 0x09,
+	0x64, 0x72, 0x6f, 0x70, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x64, 0x72, 0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x75, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x75,
+	0x70, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x61,
+	0x75, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x61, 0x75,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x22, 0xf3,
+	0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x6e,
+	0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73,
+	0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x4f, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x64, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x12, 0x3a,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0xf3, 0x01, 0x0a, 0x11, 0x53,
+	0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x4f, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x32, 0xc2, 0x01, 0x0a, 0x0c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x24,
+	0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x09, 0x53,
+	0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x53, 0x65,
+	0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f,
+	0x72, 0x61, 0x6e, 0x2d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2f, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_onos_ransim_faults_faults_proto_rawDescOnce sync.Once
+	file_onos_ransim_faults_faults_proto_rawDescData = file_onos_ransim_faults_faults_proto_rawDesc
+)
+
+func file_onos_ransim_faults_faults_proto_rawDescGZIP() []byte {
+	file_onos_ransim_faults_faults_proto_rawDescOnce.Do(func() {
+		file_onos_ransim_faults_faults_proto_rawDescData = protoimpl.X.CompressGZIP(file_onos_ransim_faults_faults_proto_rawDescData)
+	})
+	return file_onos_ransim_faults_faults_proto_rawDescData
+}
+
+var file_onos_ransim_faults_faults_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_onos_ransim_faults_faults_proto_goTypes = []interface{}{
+	(*FaultProfile)(nil),        // 0: onos.ransim.faults.FaultProfile
+	(*GetFaultsRequest)(nil),    // 1: onos.ransim.faults.GetFaultsRequest
+	(*GetFaultsResponse)(nil),   // 2: onos.ransim.faults.GetFaultsResponse
+	(*SetFaultsRequest)(nil),    // 3: onos.ransim.faults.SetFaultsRequest
+	(*SetFaultsResponse)(nil),   // 4: onos.ransim.faults.SetFaultsResponse
+	nil,                         // 5: onos.ransim.faults.GetFaultsResponse.CountersEntry
+	nil,                         // 6: onos.ransim.faults.SetFaultsResponse.CountersEntry
+	(*durationpb.Duration)(nil), // 7: google.protobuf.Duration
+}
+var file_onos_ransim_faults_faults_proto_depIdxs = []int32{
+	7, // 0: onos.ransim.faults.FaultProfile.indication_delay:type_name -> google.protobuf.Duration
+	0, // 1: onos.ransim.faults.GetFaultsResponse.profile:type_name -> onos.ransim.faults.FaultProfile
+	5, // 2: onos.ransim.faults.GetFaultsResponse.counters:type_name -> onos.ransim.faults.GetFaultsResponse.CountersEntry
+	0, // 3: onos.ransim.faults.SetFaultsRequest.profile:type_name -> onos.ransim.faults.FaultProfile
+	0, // 4: onos.ransim.faults.SetFaultsResponse.profile:type_name -> onos.ransim.faults.FaultProfile
+	6, // 5: onos.ransim.faults.SetFaultsResponse.counters:type_name -> onos.ransim.faults.SetFaultsResponse.CountersEntry
+	1, // 6: onos.ransim.faults.FaultService.GetFaults:input_type -> onos.ransim.faults.GetFaultsRequest
+	3, // 7: onos.ransim.faults.FaultService.SetFaults:input_type -> onos.ransim.faults.SetFaultsRequest
+	2, // 8: onos.ransim.faults.FaultService.GetFaults:output_type -> onos.ransim.faults.GetFaultsResponse
+	4, // 9: onos.ransim.faults.FaultService.SetFaults:output_type -> onos.ransim.faults.SetFaultsResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_onos_ransim_faults_faults_proto_init() }
+func file_onos_ransim_faults_faults_proto_init() {
+	if File_onos_ransim_faults_faults_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_onos_ransim_faults_faults_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FaultProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_faults_faults_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFaultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_faults_faults_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFaultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_faults_faults_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFaultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_faults_faults_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFaultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_onos_ransim_faults_faults_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_onos_ransim_faults_faults_proto_goTypes,
+		DependencyIndexes: file_onos_ransim_faults_faults_proto_depIdxs,
+		MessageInfos:      file_onos_ransim_faults_faults_proto_msgTypes,
+	}.Build()
+	File_onos_ransim_faults_faults_proto = out.File
+	file_onos_ransim_faults_faults_proto_rawDesc = nil
+	file_onos_ransim_faults_faults_proto_goTypes = nil
+	file_onos_ransim_faults_faults_proto_depIdxs = nil
+}
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: onos/ransim/faults/faults.proto
+
+package faults
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FaultServiceClient is the client API for FaultService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FaultServiceClient interface {
+	// GetFaults returns the fault profile of a node and the counters of the faults injected so far
+	GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsResponse, error)
+	// SetFaults replaces the fault profile of a node
+	SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*SetFaultsResponse, error)
+}
+
+type faultServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFaultServiceClient(cc grpc.ClientConnInterface) FaultServiceClient {
+	return &faultServiceClient{cc}
+}
+
+func (c *faultServiceClient) GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsResponse, error) {
+	out := new(GetFaultsResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.faults.FaultService/GetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultServiceClient) SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*SetFaultsResponse, error) {
+	out := new(SetFaultsResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.faults.FaultService/SetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FaultServiceServer is the server API for FaultService service.
+// All implementations must embed UnimplementedFaultServiceServer
+// for forward compatibility
+type FaultServiceServer interface {
+	// GetFaults returns the fault profile of a node and the counters of the faults injected so far
+	GetFaults(context.Context, *GetFaultsRequest) (*GetFaultsResponse, error)
+	// SetFaults replaces the fault profile of a node
+	SetFaults(context.Context, *SetFaultsRequest) (*SetFaultsResponse, error)
+	mustEmbedUnimplementedFaultServiceServer()
+}
+
+// UnimplementedFaultServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFaultServiceServer struct {
+}
+
+func (UnimplementedFaultServiceServer) GetFaults(context.Context, *GetFaultsRequest) (*GetFaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFaults not implemented")
+}
+func (UnimplementedFaultServiceServer) SetFaults(context.Context, *SetFaultsRequest) (*SetFaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFaults not implemented")
+}
+func (UnimplementedFaultServiceServer) mustEmbedUnimplementedFaultServiceServer() {}
+
+// UnsafeFaultServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FaultServiceServer will
+// result in compilation errors.
+type UnsafeFaultServiceServer interface {
+	mustEmbedUnimplementedFaultServiceServer()
+}
+
+func RegisterFaultServiceServer(s grpc.ServiceRegistrar, srv FaultServiceServer) {
+	s.RegisterService(&FaultService_ServiceDesc, srv)
+}
+
+func _FaultService_GetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).GetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.faults.FaultService/GetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).GetFaults(ctx, req.(*GetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultService_SetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).SetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.faults.FaultService/SetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).SetFaults(ctx, req.(*SetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FaultService_ServiceDesc is the grpc.ServiceDesc for FaultService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FaultService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.ransim.faults.FaultService",
+	HandlerType: (*FaultServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFaults",
+			Handler:    _FaultService_GetFaults_Handler,
+		},
+		{
+			MethodName: "SetFaults",
+			Handler:    _FaultService_SetFaults_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "onos/ransim/faults/faults.proto",
+}
@@ -0,0 +1,479 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: onos/ransim/nodes/nodes.proto
+
+// Package onos.ransim.nodes defines the API of the E2 interface of the simulated E2 nodes
+
+package nodes
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateServiceModelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid uint64 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	// service_models are the names of the service models of the node once updated
+	ServiceModels []string `protobuf:"bytes,2,rep,name=service_models,json=serviceModels,proto3" json:"service_models,omitempty"`
+}
+
+func (x *UpdateServiceModelsRequest) Reset() {
+	*x = UpdateServiceModelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateServiceModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceModelsRequest) ProtoMessage() {}
+
+func (x *UpdateServiceModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceModelsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateServiceModelsRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_nodes_nodes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateServiceModelsRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *UpdateServiceModelsRequest) GetServiceModels() []string {
+	if x != nil {
+		return x.ServiceModels
+	}
+	return nil
+}
+
+type UpdateServiceModelsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateServiceModelsResponse) Reset() {
+	*x = UpdateServiceModelsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateServiceModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceModelsResponse) ProtoMessage() {}
+
+func (x *UpdateServiceModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceModelsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateServiceModelsResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_nodes_nodes_proto_rawDescGZIP(), []int{1}
+}
+
+type GetE2InterfacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid uint64 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+}
+
+func (x *GetE2InterfacesRequest) Reset() {
+	*x = GetE2InterfacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetE2InterfacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetE2InterfacesRequest) ProtoMessage() {}
+
+func (x *GetE2InterfacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetE2InterfacesRequest.ProtoReflect.Descriptor instead.
+func (*GetE2InterfacesRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_nodes_nodes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetE2InterfacesRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+// E2Interface is the E2 interface instance of a node towards one of its controllers
+type E2Interface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	// error_indications_sent is the number of error indications sent by the node to the controller
+	ErrorIndicationsSent uint64 `protobuf:"varint,2,opt,name=error_indications_sent,json=errorIndicationsSent,proto3" json:"error_indications_sent,omitempty"`
+	// error_indications_received is the number of error indications received by the node from the controller
+	ErrorIndicationsReceived uint64 `protobuf:"varint,3,opt,name=error_indications_received,json=errorIndicationsReceived,proto3" json:"error_indications_received,omitempty"`
+	// connection_phase is the phase of the last connection attempt of the node to the controller
+	ConnectionPhase string `protobuf:"bytes,4,opt,name=connection_phase,json=connectionPhase,proto3" json:"connection_phase,omitempty"`
+	// setup_failure_cause is the cause of the last setup failure of the node with the controller
+	SetupFailureCause string `protobuf:"bytes,5,opt,name=setup_failure_cause,json=setupFailureCause,proto3" json:"setup_failure_cause,omitempty"`
+}
+
+func (x *E2Interface) Reset() {
+	*x = E2Interface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2Interface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2Interface) ProtoMessage() {}
+
+func (x *E2Interface) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2Interface.ProtoReflect.Descriptor instead.
+func (*E2Interface) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_nodes_nodes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *E2Interface) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+func (x *E2Interface) GetErrorIndicationsSent() uint64 {
+	if x != nil {
+		return x.ErrorIndicationsSent
+	}
+	return 0
+}
+
+func (x *E2Interface) GetErrorIndicationsReceived() uint64 {
+	if x != nil {
+		return x.ErrorIndicationsReceived
+	}
+	return 0
+}
+
+func (x *E2Interface) GetConnectionPhase() string {
+	if x != nil {
+		return x.ConnectionPhase
+	}
+	return ""
+}
+
+func (x *E2Interface) GetSetupFailureCause() string {
+	if x != nil {
+		return x.SetupFailureCause
+	}
+	return ""
+}
+
+type GetE2InterfacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interfaces []*E2Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *GetE2InterfacesResponse) Reset() {
+	*x = GetE2InterfacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetE2InterfacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetE2InterfacesResponse) ProtoMessage() {}
+
+func (x *GetE2InterfacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_nodes_nodes_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetE2InterfacesResponse.ProtoReflect.Descriptor instead.
+func (*GetE2InterfacesResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_nodes_nodes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetE2InterfacesResponse) GetInterfaces() []*E2Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+var File_onos_ransim_nodes_nodes_proto protoreflect.FileDescriptor
+
+var file_onos_ransim_nodes_nodes_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x11, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x22, 0x59, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0x1d, 0x0a,
+	0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x22, 0xfc, 0x01, 0x0a,
+	0x0b, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x16,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x65,
+	0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x18, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73,
+	0x65, 0x74, 0x75, 0x70, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x61, 0x75,
+	0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x74, 0x75, 0x70, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x61, 0x75, 0x73, 0x65, 0x22, 0x59, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x6e, 0x6f,
+	0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x45,
+	0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x32, 0xef, 0x01, 0x0a, 0x0d, 0x45, 0x32, 0x4e, 0x6f, 0x64,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x74, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x12,
+	0x2d, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e,
+	0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0x29, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6f,
+	0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x32, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x2f, 0x72, 0x61, 0x6e, 0x2d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d,
+	0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_onos_ransim_nodes_nodes_proto_rawDescOnce sync.Once
+	file_onos_ransim_nodes_nodes_proto_rawDescData = file_onos_ransim_nodes_nodes_proto_rawDesc
+)
+
+func file_onos_ransim_nodes_nodes_proto_rawDescGZIP() []byte {
+	file_onos_ransim_nodes_nodes_proto_rawDescOnce.Do(func() {
+		file_onos_ransim_nodes_nodes_proto_rawDescData = protoimpl.X.CompressGZIP(file_onos_ransim_nodes_nodes_proto_rawDescData)
+	})
+	return file_onos_ransim_nodes_nodes_proto_rawDescData
+}
+
+var file_onos_ransim_nodes_nodes_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_onos_ransim_nodes_nodes_proto_goTypes = []interface{}{
+	(*UpdateServiceModelsRequest)(nil),  // 0: onos.ransim.nodes.UpdateServiceModelsRequest
+	(*UpdateServiceModelsResponse)(nil), // 1: onos.ransim.nodes.UpdateServiceModelsResponse
+	(*GetE2InterfacesRequest)(nil),      // 2: onos.ransim.nodes.GetE2InterfacesRequest
+	(*E2Interface)(nil),                 // 3: onos.ransim.nodes.E2Interface
+	(*GetE2InterfacesResponse)(nil),     // 4: onos.ransim.nodes.GetE2InterfacesResponse
+}
+var file_onos_ransim_nodes_nodes_proto_depIdxs = []int32{
+	3, // 0: onos.ransim.nodes.GetE2InterfacesResponse.interfaces:type_name -> onos.ransim.nodes.E2Interface
+	0, // 1: onos.ransim.nodes.E2NodeService.UpdateServiceModels:input_type -> onos.ransim.nodes.UpdateServiceModelsRequest
+	2, // 2: onos.ransim.nodes.E2NodeService.GetE2Interfaces:input_type -> onos.ransim.nodes.GetE2InterfacesRequest
+	1, // 3: onos.ransim.nodes.E2NodeService.UpdateServiceModels:output_type -> onos.ransim.nodes.UpdateServiceModelsResponse
+	4, // 4: onos.ransim.nodes.E2NodeService.GetE2Interfaces:output_type -> onos.ransim.nodes.GetE2InterfacesResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_onos_ransim_nodes_nodes_proto_init() }
+func file_onos_ransim_nodes_nodes_proto_init() {
+	if File_onos_ransim_nodes_nodes_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_onos_ransim_nodes_nodes_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateServiceModelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_nodes_nodes_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateServiceModelsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_nodes_nodes_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetE2InterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_nodes_nodes_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2Interface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_nodes_nodes_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetE2InterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_onos_ransim_nodes_nodes_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_onos_ransim_nodes_nodes_proto_goTypes,
+		DependencyIndexes: file_onos_ransim_nodes_nodes_proto_depIdxs,
+		MessageInfos:      file_onos_ransim_nodes_nodes_proto_msgTypes,
+	}.Build()
+	File_onos_ransim_nodes_nodes_proto = out.File
+	file_onos_ransim_nodes_nodes_proto_rawDesc = nil
+	file_onos_ransim_nodes_nodes_proto_goTypes = nil
+	file_onos_ransim_nodes_nodes_proto_depIdxs = nil
+}
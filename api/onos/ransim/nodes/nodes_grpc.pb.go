@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: onos/ransim/nodes/nodes.proto
+
+package nodes
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// E2NodeServiceClient is the client API for E2NodeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type E2NodeServiceClient interface {
+	// UpdateServiceModels adds and removes service models of a running node, which announces the changes of its
+	// RAN functions to its controllers with a RIC service update
+	UpdateServiceModels(ctx context.Context, in *UpdateServiceModelsRequest, opts ...grpc.CallOption) (*UpdateServiceModelsResponse, error)
+	// GetE2Interfaces returns the E2 interface instances of a node towards each of its controllers
+	GetE2Interfaces(ctx context.Context, in *GetE2InterfacesRequest, opts ...grpc.CallOption) (*GetE2InterfacesResponse, error)
+}
+
+type e2NodeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewE2NodeServiceClient(cc grpc.ClientConnInterface) E2NodeServiceClient {
+	return &e2NodeServiceClient{cc}
+}
+
+func (c *e2NodeServiceClient) UpdateServiceModels(ctx context.Context, in *UpdateServiceModelsRequest, opts ...grpc.CallOption) (*UpdateServiceModelsResponse, error) {
+	out := new(UpdateServiceModelsResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.nodes.E2NodeService/UpdateServiceModels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *e2NodeServiceClient) GetE2Interfaces(ctx context.Context, in *GetE2InterfacesRequest, opts ...grpc.CallOption) (*GetE2InterfacesResponse, error) {
+	out := new(GetE2InterfacesResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.nodes.E2NodeService/GetE2Interfaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// E2NodeServiceServer is the server API for E2NodeService service.
+// All implementations must embed UnimplementedE2NodeServiceServer
+// for forward compatibility
+type E2NodeServiceServer interface {
+	// UpdateServiceModels adds and removes service models of a running node, which announces the changes of its
+	// RAN functions to its controllers with a RIC service update
+	UpdateServiceModels(context.Context, *UpdateServiceModelsRequest) (*UpdateServiceModelsResponse, error)
+	// GetE2Interfaces returns the E2 interface instances of a node towards each of its controllers
+	GetE2Interfaces(context.Context, *GetE2InterfacesRequest) (*GetE2InterfacesResponse, error)
+	mustEmbedUnimplementedE2NodeServiceServer()
+}
+
+// UnimplementedE2NodeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedE2NodeServiceServer struct {
+}
+
+func (UnimplementedE2NodeServiceServer) UpdateServiceModels(context.Context, *UpdateServiceModelsRequest) (*UpdateServiceModelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceModels not implemented")
+}
+func (UnimplementedE2NodeServiceServer) GetE2Interfaces(context.Context, *GetE2InterfacesRequest) (*GetE2InterfacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetE2Interfaces not implemented")
+}
+func (UnimplementedE2NodeServiceServer) mustEmbedUnimplementedE2NodeServiceServer() {}
+
+// UnsafeE2NodeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to E2NodeServiceServer will
+// result in compilation errors.
+type UnsafeE2NodeServiceServer interface {
+	mustEmbedUnimplementedE2NodeServiceServer()
+}
+
+func RegisterE2NodeServiceServer(s grpc.ServiceRegistrar, srv E2NodeServiceServer) {
+	s.RegisterService(&E2NodeService_ServiceDesc, srv)
+}
+
+func _E2NodeService_UpdateServiceModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(E2NodeServiceServer).UpdateServiceModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.nodes.E2NodeService/UpdateServiceModels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(E2NodeServiceServer).UpdateServiceModels(ctx, req.(*UpdateServiceModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _E2NodeService_GetE2Interfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetE2InterfacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(E2NodeServiceServer).GetE2Interfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.nodes.E2NodeService/GetE2Interfaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(E2NodeServiceServer).GetE2Interfaces(ctx, req.(*GetE2InterfacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// E2NodeService_ServiceDesc is the grpc.ServiceDesc for E2NodeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var E2NodeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.ransim.nodes.E2NodeService",
+	HandlerType: (*E2NodeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpdateServiceModels",
+			Handler:    _E2NodeService_UpdateServiceModels_Handler,
+		},
+		{
+			MethodName: "GetE2Interfaces",
+			Handler:    _E2NodeService_GetE2Interfaces_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "onos/ransim/nodes/nodes.proto",
+}
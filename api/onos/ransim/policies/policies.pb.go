@@ -0,0 +1,979 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: onos/ransim/policies/policies.proto
+
+// Package onos.ransim.policies defines the API of the ledger of the RC policies applied by the nodes
+
+package policies
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Parameter is the kind of RAN parameter changed by a policy
+type Parameter int32
+
+const (
+	// CELL_INDIVIDUAL_OFFSET is the Ocn of a neighbor in a cell; the target is the serving cell NCGI and the key
+	// the neighbor NCGI
+	Parameter_CELL_INDIVIDUAL_OFFSET Parameter = 0
+	// FIVE_QI is the 5QI of a UE; the target is the UE IMSI
+	Parameter_FIVE_QI Parameter = 1
+	// RRM_POLICY_RATIO is the RRM policy ratio of a slice in a cell; the target is the cell NCGI and the key the slice
+	Parameter_RRM_POLICY_RATIO Parameter = 2
+)
+
+// Enum value maps for Parameter.
+var (
+	Parameter_name = map[int32]string{
+		0: "CELL_INDIVIDUAL_OFFSET",
+		1: "FIVE_QI",
+		2: "RRM_POLICY_RATIO",
+	}
+	Parameter_value = map[string]int32{
+		"CELL_INDIVIDUAL_OFFSET": 0,
+		"FIVE_QI":                1,
+		"RRM_POLICY_RATIO":       2,
+	}
+)
+
+func (x Parameter) Enum() *Parameter {
+	p := new(Parameter)
+	*p = x
+	return p
+}
+
+func (x Parameter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Parameter) Descriptor() protoreflect.EnumDescriptor {
+	return file_onos_ransim_policies_policies_proto_enumTypes[0].Descriptor()
+}
+
+func (Parameter) Type() protoreflect.EnumType {
+	return &file_onos_ransim_policies_policies_proto_enumTypes[0]
+}
+
+func (x Parameter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Parameter.Descriptor instead.
+func (Parameter) EnumDescriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{0}
+}
+
+// Action is a policy action installed by a subscription
+type Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StyleType int32 `protobuf:"varint,1,opt,name=style_type,json=styleType,proto3" json:"style_type,omitempty"`
+	ActionId  int32 `protobuf:"varint,2,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"`
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Action) GetStyleType() int32 {
+	if x != nil {
+		return x.StyleType
+	}
+	return 0
+}
+
+func (x *Action) GetActionId() int32 {
+	if x != nil {
+		return x.ActionId
+	}
+	return 0
+}
+
+// RrmPolicyRatio is the PRB quota of a slice in a cell
+type RrmPolicyRatio struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sst               uint32 `protobuf:"varint,1,opt,name=sst,proto3" json:"sst,omitempty"`
+	Sd                uint32 `protobuf:"varint,2,opt,name=sd,proto3" json:"sd,omitempty"`
+	MinPrbRatio       int32  `protobuf:"varint,3,opt,name=min_prb_ratio,json=minPrbRatio,proto3" json:"min_prb_ratio,omitempty"`
+	MaxPrbRatio       int32  `protobuf:"varint,4,opt,name=max_prb_ratio,json=maxPrbRatio,proto3" json:"max_prb_ratio,omitempty"`
+	DedicatedPrbRatio int32  `protobuf:"varint,5,opt,name=dedicated_prb_ratio,json=dedicatedPrbRatio,proto3" json:"dedicated_prb_ratio,omitempty"`
+}
+
+func (x *RrmPolicyRatio) Reset() {
+	*x = RrmPolicyRatio{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RrmPolicyRatio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RrmPolicyRatio) ProtoMessage() {}
+
+func (x *RrmPolicyRatio) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RrmPolicyRatio.ProtoReflect.Descriptor instead.
+func (*RrmPolicyRatio) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RrmPolicyRatio) GetSst() uint32 {
+	if x != nil {
+		return x.Sst
+	}
+	return 0
+}
+
+func (x *RrmPolicyRatio) GetSd() uint32 {
+	if x != nil {
+		return x.Sd
+	}
+	return 0
+}
+
+func (x *RrmPolicyRatio) GetMinPrbRatio() int32 {
+	if x != nil {
+		return x.MinPrbRatio
+	}
+	return 0
+}
+
+func (x *RrmPolicyRatio) GetMaxPrbRatio() int32 {
+	if x != nil {
+		return x.MaxPrbRatio
+	}
+	return 0
+}
+
+func (x *RrmPolicyRatio) GetDedicatedPrbRatio() int32 {
+	if x != nil {
+		return x.DedicatedPrbRatio
+	}
+	return 0
+}
+
+// ParameterValue is a value of a RAN parameter changed by a policy
+type ParameterValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*ParameterValue_IntValue
+	//	*ParameterValue_RrmPolicyRatio
+	Value isParameterValue_Value `protobuf_oneof:"value"`
+}
+
+func (x *ParameterValue) Reset() {
+	*x = ParameterValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParameterValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterValue) ProtoMessage() {}
+
+func (x *ParameterValue) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterValue.ProtoReflect.Descriptor instead.
+func (*ParameterValue) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *ParameterValue) GetValue() isParameterValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *ParameterValue) GetIntValue() int64 {
+	if x, ok := x.GetValue().(*ParameterValue_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (x *ParameterValue) GetRrmPolicyRatio() *RrmPolicyRatio {
+	if x, ok := x.GetValue().(*ParameterValue_RrmPolicyRatio); ok {
+		return x.RrmPolicyRatio
+	}
+	return nil
+}
+
+type isParameterValue_Value interface {
+	isParameterValue_Value()
+}
+
+type ParameterValue_IntValue struct {
+	// int_value is the value of the Ocn and 5QI parameters
+	IntValue int64 `protobuf:"varint,1,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type ParameterValue_RrmPolicyRatio struct {
+	RrmPolicyRatio *RrmPolicyRatio `protobuf:"bytes,2,opt,name=rrm_policy_ratio,json=rrmPolicyRatio,proto3,oneof"`
+}
+
+func (*ParameterValue_IntValue) isParameterValue_Value() {}
+
+func (*ParameterValue_RrmPolicyRatio) isParameterValue_Value() {}
+
+// Change is a single RAN parameter change applied by a policy
+type Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action    *Action   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Parameter Parameter `protobuf:"varint,2,opt,name=parameter,proto3,enum=onos.ransim.policies.Parameter" json:"parameter,omitempty"`
+	Target    uint64    `protobuf:"varint,3,opt,name=target,proto3" json:"target,omitempty"`
+	Key       uint64    `protobuf:"varint,4,opt,name=key,proto3" json:"key,omitempty"`
+	// original is the value before the policy was first applied; not set if the parameter did not exist
+	Original *ParameterValue `protobuf:"bytes,5,opt,name=original,proto3" json:"original,omitempty"`
+	// applied is the value most recently set by the policy
+	Applied *ParameterValue `protobuf:"bytes,6,opt,name=applied,proto3" json:"applied,omitempty"`
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Change) GetAction() *Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *Change) GetParameter() Parameter {
+	if x != nil {
+		return x.Parameter
+	}
+	return Parameter_CELL_INDIVIDUAL_OFFSET
+}
+
+func (x *Change) GetTarget() uint64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *Change) GetKey() uint64 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *Change) GetOriginal() *ParameterValue {
+	if x != nil {
+		return x.Original
+	}
+	return nil
+}
+
+func (x *Change) GetApplied() *ParameterValue {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+// Policy is the ledger entry of a subscription of a node; the changes are in the order they were first applied
+type Policy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid          uint64                 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Actions        []*Action              `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	Changes        []*Change              `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Policy) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *Policy) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *Policy) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Policy) GetChanges() []*Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *Policy) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Policy) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListPoliciesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// gnbid selects the policies of a single node if set
+	Gnbid uint64 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+}
+
+func (x *ListPoliciesRequest) Reset() {
+	*x = ListPoliciesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesRequest) ProtoMessage() {}
+
+func (x *ListPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPoliciesRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+type ListPoliciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policies []*Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+}
+
+func (x *ListPoliciesResponse) Reset() {
+	*x = ListPoliciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesResponse) ProtoMessage() {}
+
+func (x *ListPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPoliciesResponse) GetPolicies() []*Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type GetPolicyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gnbid          uint64 `protobuf:"varint,1,opt,name=gnbid,proto3" json:"gnbid,omitempty"`
+	SubscriptionId string `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+}
+
+func (x *GetPolicyRequest) Reset() {
+	*x = GetPolicyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyRequest) ProtoMessage() {}
+
+func (x *GetPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPolicyRequest) GetGnbid() uint64 {
+	if x != nil {
+		return x.Gnbid
+	}
+	return 0
+}
+
+func (x *GetPolicyRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type GetPolicyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy *Policy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+}
+
+func (x *GetPolicyResponse) Reset() {
+	*x = GetPolicyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_onos_ransim_policies_policies_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyResponse) ProtoMessage() {}
+
+func (x *GetPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onos_ransim_policies_policies_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_onos_ransim_policies_policies_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPolicyResponse) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+var File_onos_ransim_policies_policies_proto protoreflect.FileDescriptor
+
+var file_onos_ransim_policies_policies_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2f, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x74, 0x79, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0xaa, 0x01, 0x0a, 0x0e, 0x52, 0x72, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x73, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x73, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x02, 0x73, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x70,
+	0x72, 0x62, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x6d, 0x69, 0x6e, 0x50, 0x72, 0x62, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x62, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x62, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12,
+	0x2e, 0x0a, 0x13, 0x64, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x62,
+	0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x64, 0x65,
+	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x62, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x22,
+	0x8a, 0x01, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x50, 0x0a, 0x10, 0x72, 0x72, 0x6d, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x6e,
+	0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x2e, 0x52, 0x72, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x72, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x61,
+	0x74, 0x69, 0x6f, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa9, 0x02, 0x0a,
+	0x06, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a,
+	0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x52, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x3e, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x6e, 0x6f, 0x73,
+	0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73,
+	0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x22, 0xad, 0x02, 0x0a, 0x06, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x36, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x6e,
+	0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a,
+	0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x2b, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x67, 0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x67, 0x6e, 0x62, 0x69, 0x64, 0x22, 0x50, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a,
+	0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67,
+	0x6e, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x67, 0x6e, 0x62, 0x69,
+	0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x49, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x34, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2a, 0x4a, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x49, 0x4e, 0x44, 0x49, 0x56,
+	0x49, 0x44, 0x55, 0x41, 0x4c, 0x5f, 0x4f, 0x46, 0x46, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x46, 0x49, 0x56, 0x45, 0x5f, 0x51, 0x49, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x52,
+	0x52, 0x4d, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x10,
+	0x02, 0x32, 0xd4, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x65, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x12, 0x29, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
+	0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x26, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x2f, 0x72, 0x61, 0x6e, 0x2d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x6d,
+	0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_onos_ransim_policies_policies_proto_rawDescOnce sync.Once
+	file_onos_ransim_policies_policies_proto_rawDescData = file_onos_ransim_policies_policies_proto_rawDesc
+)
+
+func file_onos_ransim_policies_policies_proto_rawDescGZIP() []byte {
+	file_onos_ransim_policies_policies_proto_rawDescOnce.Do(func() {
+		file_onos_ransim_policies_policies_proto_rawDescData = protoimpl.X.CompressGZIP(file_onos_ransim_policies_policies_proto_rawDescData)
+	})
+	return file_onos_ransim_policies_policies_proto_rawDescData
+}
+
+var file_onos_ransim_policies_policies_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_onos_ransim_policies_policies_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_onos_ransim_policies_policies_proto_goTypes = []interface{}{
+	(Parameter)(0),                // 0: onos.ransim.policies.Parameter
+	(*Action)(nil),                // 1: onos.ransim.policies.Action
+	(*RrmPolicyRatio)(nil),        // 2: onos.ransim.policies.RrmPolicyRatio
+	(*ParameterValue)(nil),        // 3: onos.ransim.policies.ParameterValue
+	(*Change)(nil),                // 4: onos.ransim.policies.Change
+	(*Policy)(nil),                // 5: onos.ransim.policies.Policy
+	(*ListPoliciesRequest)(nil),   // 6: onos.ransim.policies.ListPoliciesRequest
+	(*ListPoliciesResponse)(nil),  // 7: onos.ransim.policies.ListPoliciesResponse
+	(*GetPolicyRequest)(nil),      // 8: onos.ransim.policies.GetPolicyRequest
+	(*GetPolicyResponse)(nil),     // 9: onos.ransim.policies.GetPolicyResponse
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_onos_ransim_policies_policies_proto_depIdxs = []int32{
+	2,  // 0: onos.ransim.policies.ParameterValue.rrm_policy_ratio:type_name -> onos.ransim.policies.RrmPolicyRatio
+	1,  // 1: onos.ransim.policies.Change.action:type_name -> onos.ransim.policies.Action
+	0,  // 2: onos.ransim.policies.Change.parameter:type_name -> onos.ransim.policies.Parameter
+	3,  // 3: onos.ransim.policies.Change.original:type_name -> onos.ransim.policies.ParameterValue
+	3,  // 4: onos.ransim.policies.Change.applied:type_name -> onos.ransim.policies.ParameterValue
+	1,  // 5: onos.ransim.policies.Policy.actions:type_name -> onos.ransim.policies.Action
+	4,  // 6: onos.ransim.policies.Policy.changes:type_name -> onos.ransim.policies.Change
+	10, // 7: onos.ransim.policies.Policy.created_at:type_name -> google.protobuf.Timestamp
+	10, // 8: onos.ransim.policies.Policy.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 9: onos.ransim.policies.ListPoliciesResponse.policies:type_name -> onos.ransim.policies.Policy
+	5,  // 10: onos.ransim.policies.GetPolicyResponse.policy:type_name -> onos.ransim.policies.Policy
+	6,  // 11: onos.ransim.policies.PolicyService.ListPolicies:input_type -> onos.ransim.policies.ListPoliciesRequest
+	8,  // 12: onos.ransim.policies.PolicyService.GetPolicy:input_type -> onos.ransim.policies.GetPolicyRequest
+	7,  // 13: onos.ransim.policies.PolicyService.ListPolicies:output_type -> onos.ransim.policies.ListPoliciesResponse
+	9,  // 14: onos.ransim.policies.PolicyService.GetPolicy:output_type -> onos.ransim.policies.GetPolicyResponse
+	13, // [13:15] is the sub-list for method output_type
+	11, // [11:13] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_onos_ransim_policies_policies_proto_init() }
+func file_onos_ransim_policies_policies_proto_init() {
+	if File_onos_ransim_policies_policies_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_onos_ransim_policies_policies_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Action); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RrmPolicyRatio); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParameterValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Policy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPoliciesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPoliciesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_onos_ransim_policies_policies_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPolicyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_onos_ransim_policies_policies_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*ParameterValue_IntValue)(nil),
+		(*ParameterValue_RrmPolicyRatio)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_onos_ransim_policies_policies_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_onos_ransim_policies_policies_proto_goTypes,
+		DependencyIndexes: file_onos_ransim_policies_policies_proto_depIdxs,
+		EnumInfos:         file_onos_ransim_policies_policies_proto_enumTypes,
+		MessageInfos:      file_onos_ransim_policies_policies_proto_msgTypes,
+	}.Build()
+	File_onos_ransim_policies_policies_proto = out.File
+	file_onos_ransim_policies_policies_proto_rawDesc = nil
+	file_onos_ransim_policies_policies_proto_goTypes = nil
+	file_onos_ransim_policies_policies_proto_depIdxs = nil
+}
@@ -0,0 +1,15 @@
+#!/bin/sh
+
+# SPDX-FileCopyrightText: 2022-present Intel Corporation
+#
+# SPDX-License-Identifier: Apache-2.0
+
+# Compiles the protos of the APIs of the simulator which are not part of onos-api
+proto_path="./api:${GOPATH}/src/github.com/google/protobuf/src"
+
+for proto in api/onos/ransim/*/*.proto; do
+    protoc --proto_path="$proto_path" \
+        --go_out=./api --go_opt=paths=source_relative \
+        --go-grpc_out=./api --go-grpc_opt=paths=source_relative \
+        "${proto#api/}"
+done
@@ -0,0 +1,396 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package amf
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = logging.GetLogger()
+
+const (
+	// firstAmfUeNgapID is the AMF UE NGAP ID assigned to the first UE registered with the AMF
+	firstAmfUeNgapID = 1000
+	// defaultLatency is the time taken by each NGAP message if the model does not set one
+	defaultLatency = time.Millisecond
+	// countersPeriod is the period of publication of the counters of the cells
+	countersPeriod = time.Second
+	// pagingPeriod is the period of the pagings of the IDLE UEs
+	pagingPeriod = time.Second
+)
+
+// Names of the per-cell metrics published by the AMF
+const (
+	// ConnEstabAttemptsMetric is the number of attempts of IDLE UEs to connect through the AMF
+	ConnEstabAttemptsMetric = "ng_conn_estab_attempts"
+	// ConnEstabSuccessesMetric is the number of IDLE UEs connected through the AMF
+	ConnEstabSuccessesMetric = "ng_conn_estab_successes"
+	// ConnEstabLatencyMetric is the mean time, in milliseconds, taken by the IDLE UEs to connect
+	ConnEstabLatencyMetric = "ng_conn_estab_latency_ms"
+	// PagingReceivedMetric is the number of pagings received from the AMF
+	PagingReceivedMetric = "ng_paging_received"
+)
+
+// defaultGuami is the GUAMI of the AMF if the model does not set one
+var defaultGuami = model.Guami{
+	AmfRegionID: 0xdd,
+	AmfSetID:    0x333,
+	AmfPointer:  0x3f,
+}
+
+// AMF is a lightweight stand-in of the AMF serving the nodes. It registers the UEs, assigning them their AMF UE
+// NGAP ID and its GUAMI, pages the IDLE UEs and runs the NG procedures of the UEs going from IDLE to CONNECTED and
+// back. The NGAP messages of the procedures are recorded in the message log without payload since NGAP is not
+// encoded by the simulator.
+type AMF interface {
+	// Start registers the UEs and keeps registering the UEs created afterwards until the context is done or the
+	// AMF is stopped
+	Start(ctx context.Context) error
+
+	// Stop stops registering and paging the UEs
+	Stop()
+
+	// Paged returns whether the IDLE UE is paged by the AMF and waits to connect
+	Paged(imsi types.IMSI) bool
+
+	// Connect runs the service request of the IDLE UE, answering its paging if the AMF paged it; it fails if the
+	// AMF rejects the UE
+	Connect(ctx context.Context, imsi types.IMSI) error
+
+	// Release releases the context of the UE going IDLE
+	Release(ctx context.Context, imsi types.IMSI) error
+
+	// Counters returns the counters of the procedures run through the cell
+	Counters(ncgi types.NCGI) Counters
+
+	// Clear forgets the registered and paged UEs and resets the counters of all cells; the AMF UE NGAP IDs of the
+	// UEs registered afterwards follow the ones assigned before since the UEs keep them
+	Clear(ctx context.Context)
+}
+
+// Counters are the counters of the NG procedures run through a cell
+type Counters struct {
+	ConnEstabAttempts  uint64
+	ConnEstabSuccesses uint64
+	// ConnEstabLatency is the total time taken by the successful connections
+	ConnEstabLatency time.Duration
+	PagingReceived   uint64
+}
+
+// meanConnEstabLatency returns the mean time taken by the successful connections
+func (c Counters) meanConnEstabLatency() time.Duration {
+	if c.ConnEstabSuccesses == 0 {
+		return 0
+	}
+	return c.ConnEstabLatency / time.Duration(c.ConnEstabSuccesses)
+}
+
+type amf struct {
+	ueStore      ues.Store
+	messageStore messages.Store
+	metricStore  metrics.Store
+	guami        model.Guami
+	latency      time.Duration
+	pagingRate   float64
+	failureRate  float64
+
+	mu              sync.Mutex
+	cancel          context.CancelFunc
+	nextAmfUeNgapID types.AmfUENgapID
+	registered      map[types.IMSI]types.AmfUENgapID
+	paged           map[types.IMSI]bool
+	counters        map[types.NCGI]*Counters
+	published       map[types.NCGI]Counters
+}
+
+// NewAMF returns the AMF simulated for the nodes of the model; the message and metric stores are optional
+func NewAMF(m *model.Model, ueStore ues.Store, messageStore messages.Store, metricStore metrics.Store) AMF {
+	guami := m.Guami
+	if guami == (model.Guami{}) {
+		guami = defaultGuami
+	}
+	latency := m.AMF.Latency
+	if latency == 0 {
+		latency = defaultLatency
+	}
+	return &amf{
+		ueStore:         ueStore,
+		messageStore:    messageStore,
+		metricStore:     metricStore,
+		guami:           guami,
+		latency:         latency,
+		pagingRate:      m.AMF.PagingRate,
+		failureRate:     m.AMF.FailureRate,
+		nextAmfUeNgapID: firstAmfUeNgapID,
+		registered:      make(map[types.IMSI]types.AmfUENgapID),
+		paged:           make(map[types.IMSI]bool),
+		counters:        make(map[types.NCGI]*Counters),
+		published:       make(map[types.NCGI]Counters),
+	}
+}
+
+func (a *amf) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	ch := make(chan event.Event)
+	if err := a.ueStore.Watch(ctx, ch); err != nil {
+		cancel()
+		return err
+	}
+	a.mu.Lock()
+	a.cancel = cancel
+	a.mu.Unlock()
+	for _, ue := range a.ueStore.ListAllUEs(ctx) {
+		a.register(ctx, ue.IMSI)
+	}
+	go a.processUEEvents(ctx, ch)
+	if a.pagingRate > 0 {
+		go a.pageUEs(ctx)
+	}
+	if a.metricStore != nil {
+		go a.publishCounters(ctx)
+	}
+	return nil
+}
+
+// processUEEvents registers the UEs as they are created and forgets them as they are deleted
+func (a *amf) processUEEvents(ctx context.Context, ch <-chan event.Event) {
+	for ueEvent := range ch {
+		ue := ueEvent.Value.(*model.UE)
+		switch ueEvent.Type {
+		case ues.Created:
+			a.register(ctx, ue.IMSI)
+		case ues.Deleted:
+			a.mu.Lock()
+			delete(a.registered, ue.IMSI)
+			delete(a.paged, ue.IMSI)
+			a.mu.Unlock()
+		}
+	}
+}
+
+// register assigns the next AMF UE NGAP ID and the GUAMI to the UE unless it is already registered; an ID is
+// never assigned twice, even if the UE fails to register
+func (a *amf) register(ctx context.Context, imsi types.IMSI) {
+	a.mu.Lock()
+	if _, ok := a.registered[imsi]; ok {
+		a.mu.Unlock()
+		return
+	}
+	amfUeNgapID := a.nextAmfUeNgapID
+	a.nextAmfUeNgapID++
+	a.registered[imsi] = amfUeNgapID
+	a.mu.Unlock()
+
+	// The UE store is updated without the lock since it notifies its watchers, among which the AMF
+	if err := a.ueStore.UpdateRegistration(ctx, imsi, amfUeNgapID, a.guami); err != nil {
+		log.Warnf("Unable to register UE %d: %v", imsi, err)
+		a.mu.Lock()
+		if a.registered[imsi] == amfUeNgapID {
+			delete(a.registered, imsi)
+		}
+		a.mu.Unlock()
+	}
+}
+
+// pageUEs periodically pages the IDLE UEs, each with the paging rate of the AMF
+func (a *amf) pageUEs(ctx context.Context) {
+	ticker := time.NewTicker(pagingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		for _, ue := range a.ueStore.ListAllUEs(ctx) {
+			if ue.RrcState != mho.Rrcstatus_RRCSTATUS_IDLE || ue.Cell == nil || rand.Float64() >= a.pagingRate {
+				continue
+			}
+			if err := a.page(ctx, ue); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// page pages the IDLE UE unless it is already paged
+func (a *amf) page(ctx context.Context, ue *model.UE) error {
+	a.mu.Lock()
+	if a.paged[ue.IMSI] {
+		a.mu.Unlock()
+		return nil
+	}
+	a.paged[ue.IMSI] = true
+	a.mu.Unlock()
+	a.updateCounters(ue.Cell.NCGI, func(c *Counters) {
+		c.PagingReceived++
+	})
+	return a.exchange(ctx, ue, messages.Incoming, messages.Paging)
+}
+
+func (a *amf) Paged(imsi types.IMSI) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.paged[imsi]
+}
+
+func (a *amf) Connect(ctx context.Context, imsi types.IMSI) error {
+	a.register(ctx, imsi)
+	ue, err := a.ueStore.Get(ctx, imsi)
+	if err != nil {
+		return err
+	}
+	ncgi := ue.Cell.NCGI
+	a.mu.Lock()
+	delete(a.paged, imsi)
+	a.mu.Unlock()
+	a.updateCounters(ncgi, func(c *Counters) {
+		c.ConnEstabAttempts++
+	})
+
+	// The connection takes three NGAP messages, hence three times the latency of the AMF
+	start := time.Now()
+	if err := a.exchange(ctx, ue, messages.Outgoing, messages.InitialUEMessage); err != nil {
+		return err
+	}
+	if rand.Float64() < a.failureRate {
+		return errors.NewUnavailable("the service request of UE %d is rejected by the AMF", imsi)
+	}
+	if err := a.exchange(ctx, ue, messages.Incoming, messages.InitialContextSetupRequest); err != nil {
+		return err
+	}
+	if err := a.exchange(ctx, ue, messages.Outgoing, messages.InitialContextSetupResponse); err != nil {
+		return err
+	}
+	latency := time.Since(start)
+	a.updateCounters(ncgi, func(c *Counters) {
+		c.ConnEstabSuccesses++
+		c.ConnEstabLatency += latency
+	})
+	return nil
+}
+
+func (a *amf) Release(ctx context.Context, imsi types.IMSI) error {
+	ue, err := a.ueStore.Get(ctx, imsi)
+	if err != nil {
+		return err
+	}
+	if err := a.exchange(ctx, ue, messages.Outgoing, messages.UEContextReleaseRequest); err != nil {
+		return err
+	}
+	if err := a.exchange(ctx, ue, messages.Incoming, messages.UEContextReleaseCommand); err != nil {
+		return err
+	}
+	return a.exchange(ctx, ue, messages.Outgoing, messages.UEContextReleaseComplete)
+}
+
+// exchange records the NGAP message exchanged with the node serving the UE and waits for it to reach the other end
+func (a *amf) exchange(ctx context.Context, ue *model.UE, direction messages.Direction, procedure string) error {
+	if a.messageStore != nil {
+		a.messageStore.Add(ctx, &messages.Message{
+			GnbID:     types.GetGnbID(uint64(ue.Cell.NCGI)),
+			Interface: messages.NG,
+			Direction: direction,
+			Procedure: procedure,
+			IMSI:      ue.IMSI,
+			NCGI:      ue.Cell.NCGI,
+		})
+	}
+	timer := time.NewTimer(a.latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *amf) updateCounters(ncgi types.NCGI, update func(c *Counters)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	c, ok := a.counters[ncgi]
+	if !ok {
+		c = &Counters{}
+		a.counters[ncgi] = c
+	}
+	update(c)
+}
+
+func (a *amf) Counters(ncgi types.NCGI) Counters {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c, ok := a.counters[ncgi]; ok {
+		return *c
+	}
+	return Counters{}
+}
+
+func (a *amf) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+}
+
+func (a *amf) Clear(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.registered = make(map[types.IMSI]types.AmfUENgapID)
+	a.paged = make(map[types.IMSI]bool)
+	a.counters = make(map[types.NCGI]*Counters)
+	a.published = make(map[types.NCGI]Counters)
+}
+
+// publishCounters periodically publishes the counters of the cells that changed as metrics of the cells
+func (a *amf) publishCounters(ctx context.Context) {
+	ticker := time.NewTicker(countersPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		changed := make(map[types.NCGI]Counters)
+		a.mu.Lock()
+		for ncgi, c := range a.counters {
+			if a.published[ncgi] != *c {
+				changed[ncgi] = *c
+				a.published[ncgi] = *c
+			}
+		}
+		a.mu.Unlock()
+
+		for ncgi, c := range changed {
+			values := map[string]interface{}{
+				ConnEstabAttemptsMetric:  c.ConnEstabAttempts,
+				ConnEstabSuccessesMetric: c.ConnEstabSuccesses,
+				ConnEstabLatencyMetric:   uint64(c.meanConnEstabLatency().Milliseconds()),
+				PagingReceivedMetric:     c.PagingReceived,
+			}
+			for name, value := range values {
+				if err := a.metricStore.Set(ctx, uint64(ncgi), name, value); err != nil {
+					log.Warn(err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package amf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUEStore(t *testing.T, m *model.Model, count uint) ues.Store {
+	err := model.LoadConfig(m, "../model/test")
+	assert.NoError(t, err)
+	return ues.NewUERegistry(count, cells.NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes)), "idle")
+}
+
+func TestRegistration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := &model.Model{}
+	ueStore := newUEStore(t, m, 5)
+	a := NewAMF(m, ueStore, nil, nil)
+	assert.NoError(t, a.Start(ctx))
+
+	amfUeNgapIDs := make(map[types.AmfUENgapID]bool)
+	for _, ue := range ueStore.ListAllUEs(ctx) {
+		assert.GreaterOrEqual(t, ue.AmfUeNgapID, types.AmfUENgapID(firstAmfUeNgapID))
+		assert.Equal(t, defaultGuami, ue.Guami)
+		amfUeNgapIDs[ue.AmfUeNgapID] = true
+	}
+	assert.Len(t, amfUeNgapIDs, 5)
+
+	// The UEs created afterwards are registered as well
+	ueStore.SetUECount(ctx, 8)
+	assert.Eventually(t, func() bool {
+		for _, ue := range ueStore.ListAllUEs(ctx) {
+			if _, err := ueStore.GetWithAmfUeNgapID(ctx, ue.AmfUeNgapID); err != nil || ue.AmfUeNgapID == 0 {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnectAndRelease(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	ueStore := newUEStore(t, m, 1)
+	m.AMF = model.AMF{Latency: time.Millisecond, PagingRate: 1}
+	messageStore := messages.NewMessageLog(messages.DefaultCapacity)
+	a := NewAMF(m, ueStore, messageStore, nil)
+	pagingCtx, stopPaging := context.WithCancel(ctx)
+	assert.NoError(t, a.Start(pagingCtx))
+
+	// The IDLE UE is paged and connects in response
+	ue := ueStore.ListAllUEs(ctx)[0]
+	assert.Eventually(t, func() bool {
+		return a.Paged(ue.IMSI)
+	}, 2*pagingPeriod, 10*time.Millisecond)
+	stopPaging()
+	assert.NoError(t, a.Connect(ctx, ue.IMSI))
+	assert.False(t, a.Paged(ue.IMSI))
+	assert.NoError(t, a.Release(ctx, ue.IMSI))
+
+	counters := a.Counters(ue.Cell.NCGI)
+	assert.Equal(t, uint64(1), counters.ConnEstabAttempts)
+	assert.Equal(t, uint64(1), counters.ConnEstabSuccesses)
+	assert.Equal(t, uint64(1), counters.PagingReceived)
+	assert.GreaterOrEqual(t, counters.ConnEstabLatency, 3*time.Millisecond)
+
+	procedures := make([]string, 0)
+	for _, message := range messageStore.List(ctx) {
+		assert.Equal(t, messages.NG, message.Interface)
+		assert.Equal(t, types.GetGnbID(uint64(ue.Cell.NCGI)), message.GnbID)
+		assert.Equal(t, ue.IMSI, message.IMSI)
+		procedures = append(procedures, message.Procedure)
+	}
+	assert.Equal(t, []string{messages.Paging, messages.InitialUEMessage, messages.InitialContextSetupRequest,
+		messages.InitialContextSetupResponse, messages.UEContextReleaseRequest, messages.UEContextReleaseCommand,
+		messages.UEContextReleaseComplete}, procedures)
+
+	// The AMF rejects every service request
+	a = NewAMF(&model.Model{AMF: model.AMF{FailureRate: 1}}, ueStore, nil, nil)
+	err := a.Connect(ctx, ue.IMSI)
+	assert.True(t, errors.IsUnavailable(err))
+	counters = a.Counters(ue.Cell.NCGI)
+	assert.Equal(t, uint64(1), counters.ConnEstabAttempts)
+	assert.Equal(t, uint64(0), counters.ConnEstabSuccesses)
+
+	// The registrations are forgotten with the counters
+	a.Clear(ctx)
+	assert.Equal(t, Counters{}, a.Counters(ue.Cell.NCGI))
+	assert.Len(t, a.(*amf).registered, 0)
+}
+
+func TestClear(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	ueStore := newUEStore(t, m, 2)
+	a := NewAMF(m, ueStore, nil, nil)
+	assert.NoError(t, a.Start(ctx))
+	a.Stop()
+
+	// The UEs registered again after a clear keep distinct AMF UE NGAP IDs whatever their order
+	a.Clear(ctx)
+	assert.Len(t, a.(*amf).registered, 0)
+	list := ueStore.ListAllUEs(ctx)
+	for i := len(list) - 1; i >= 0; i-- {
+		a.(*amf).register(ctx, list[i].IMSI)
+	}
+	assert.Len(t, a.(*amf).registered, 2)
+	for _, ue := range ueStore.ListAllUEs(ctx) {
+		registered, err := ueStore.GetWithAmfUeNgapID(ctx, ue.AmfUeNgapID)
+		assert.NoError(t, err)
+		assert.Equal(t, ue.IMSI, registered.IMSI)
+		assert.GreaterOrEqual(t, ue.AmfUeNgapID, types.AmfUENgapID(firstAmfUeNgapID+2))
+	}
+}
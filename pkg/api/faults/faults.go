@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	faultsapi "github.com/onosproject/ran-simulator/api/onos/ransim/faults"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var log = liblog.GetLogger()
+
+// NewService returns a new fault injection Service
+func NewService(faultStore faults.Store) service.Service {
+	return &Service{
+		faultStore: faultStore,
+	}
+}
+
+// Service is a Service implementation for the fault injection.
+type Service struct {
+	service.Service
+	faultStore faults.Store
+}
+
+// Register registers the fault injection Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		faultStore: s.faultStore,
+	}
+	faultsapi.RegisterFaultServiceServer(r, server)
+}
+
+var _ service.Service = &Service{}
+
+// Server implements the fault injection gRPC service
+type Server struct {
+	faultsapi.UnimplementedFaultServiceServer
+	faultStore faults.Store
+}
+
+// GetFaults returns the fault profile of a node and the counters of its injected faults
+func (s *Server) GetFaults(ctx context.Context, request *faultsapi.GetFaultsRequest) (*faultsapi.GetFaultsResponse, error) {
+	log.Debugf("Received get faults request: %+v", request)
+	gnbID := types.GnbID(request.Gnbid)
+	return &faultsapi.GetFaultsResponse{
+		Gnbid:    request.Gnbid,
+		Profile:  profileToAPI(s.faultStore.Get(ctx, gnbID)),
+		Counters: s.counters(ctx, gnbID),
+	}, nil
+}
+
+// SetFaults replaces the fault profile of a node
+func (s *Server) SetFaults(ctx context.Context, request *faultsapi.SetFaultsRequest) (*faultsapi.SetFaultsResponse, error) {
+	log.Debugf("Received set faults request: %+v", request)
+	gnbID := types.GnbID(request.Gnbid)
+	s.faultStore.Set(ctx, gnbID, profileFromAPI(request.Profile))
+	return &faultsapi.SetFaultsResponse{
+		Gnbid:    request.Gnbid,
+		Profile:  profileToAPI(s.faultStore.Get(ctx, gnbID)),
+		Counters: s.counters(ctx, gnbID),
+	}, nil
+}
+
+func (s *Server) counters(ctx context.Context, gnbID types.GnbID) map[string]uint64 {
+	counters := make(map[string]uint64)
+	for fault, count := range s.faultStore.Counters(ctx, gnbID) {
+		counters[string(fault)] = count
+	}
+	return counters
+}
+
+func profileToAPI(profile model.FaultProfile) *faultsapi.FaultProfile {
+	return &faultsapi.FaultProfile{
+		IndicationDelay:         durationpb.New(profile.IndicationDelay),
+		DropRate:                profile.DropRate,
+		DuplicateRate:           profile.DuplicateRate,
+		ReorderRate:             profile.ReorderRate,
+		CorruptRate:             profile.CorruptRate,
+		SubscriptionRejectCause: profile.SubscriptionRejectCause,
+		ControlFailureRate:      profile.ControlFailureRate,
+	}
+}
+
+func profileFromAPI(profile *faultsapi.FaultProfile) model.FaultProfile {
+	return model.FaultProfile{
+		IndicationDelay:         profile.GetIndicationDelay().AsDuration(),
+		DropRate:                profile.GetDropRate(),
+		DuplicateRate:           profile.GetDuplicateRate(),
+		ReorderRate:             profile.GetReorderRate(),
+		CorruptRate:             profile.GetCorruptRate(),
+		SubscriptionRejectCause: profile.GetSubscriptionRejectCause(),
+		ControlFailureRate:      profile.GetControlFailureRate(),
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messages
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	messagesapi "github.com/onosproject/ran-simulator/api/onos/ransim/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var log = liblog.GetLogger()
+
+// NewService returns a new message log Service
+func NewService(messageStore messages.Store) service.Service {
+	return &Service{
+		messageStore: messageStore,
+	}
+}
+
+// Service is a Service implementation for the message log.
+type Service struct {
+	service.Service
+	messageStore messages.Store
+}
+
+// Register registers the message log Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		messageStore: s.messageStore,
+	}
+	messagesapi.RegisterMessageServiceServer(r, server)
+}
+
+var _ service.Service = &Service{}
+
+// Server implements the message log gRPC service
+type Server struct {
+	messagesapi.UnimplementedMessageServiceServer
+	messageStore messages.Store
+}
+
+// ListMessages lists the messages of the log matching the filter of the request
+func (s *Server) ListMessages(ctx context.Context, request *messagesapi.ListMessagesRequest) (*messagesapi.ListMessagesResponse, error) {
+	log.Debugf("Received listing messages request: %v", request)
+	response := &messagesapi.ListMessagesResponse{}
+	for _, message := range s.messageStore.List(ctx) {
+		if matches(request.Filter, message) {
+			response.Messages = append(response.Messages, messageToAPI(message))
+		}
+	}
+	return response, nil
+}
+
+// WatchMessages streams the messages added to the log matching the filter of the request
+func (s *Server) WatchMessages(request *messagesapi.WatchMessagesRequest, server messagesapi.MessageService_WatchMessagesServer) error {
+	log.Debugf("Received watching messages request: %v", request)
+	ch := make(chan event.Event)
+	if err := s.messageStore.Watch(server.Context(), ch); err != nil {
+		return err
+	}
+
+	// The messages added while replaying are both listed and watched; they are only sent once
+	var replayed uint64
+	if request.Replay {
+		for _, message := range s.messageStore.List(server.Context()) {
+			replayed = message.ID
+			if !matches(request.Filter, message) {
+				continue
+			}
+			if err := server.Send(&messagesapi.WatchMessagesResponse{Message: messageToAPI(message)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for messageEvent := range ch {
+		message := messageEvent.Value.(*messages.Message)
+		if message.ID <= replayed || !matches(request.Filter, message) {
+			continue
+		}
+		if err := server.Send(&messagesapi.WatchMessagesResponse{Message: messageToAPI(message)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matches returns whether the message is selected by the filter
+func matches(filter *messagesapi.MessageFilter, message *messages.Message) bool {
+	if filter.GetGnbid() != 0 && message.GnbID != types.GnbID(filter.GetGnbid()) {
+		return false
+	}
+	if filter.GetInterface() != "" && message.Interface.String() != filter.GetInterface() {
+		return false
+	}
+	return filter.GetProcedure() == "" || message.Procedure == filter.GetProcedure()
+}
+
+func messageToAPI(message *messages.Message) *messagesapi.Message {
+	return &messagesapi.Message{
+		Id:        message.ID,
+		Gnbid:     uint64(message.GnbID),
+		PeerGnbid: uint64(message.PeerGnbID),
+		Interface: message.Interface.String(),
+		Direction: message.Direction.String(),
+		Procedure: message.Procedure,
+		Imsi:      uint64(message.IMSI),
+		Ncgi:      uint64(message.NCGI),
+		Payload:   message.Payload,
+		Timestamp: timestamppb.New(message.Timestamp),
+	}
+}
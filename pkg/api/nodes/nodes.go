@@ -8,6 +8,8 @@ package nodes
 import (
 	"context"
 
+	nodesapi "github.com/onosproject/ran-simulator/api/onos/ransim/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/agents"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
@@ -21,34 +23,56 @@ import (
 
 var log = liblog.GetLogger()
 
+// Agent control commands
+const (
+	// ResetCommand is the agent control command resetting the E2 node
+	ResetCommand = "reset"
+	// StopCommand is the agent control command stopping the agent
+	StopCommand = "stop"
+	// StartCommand is the agent control command starting the agent
+	StartCommand = "start"
+	// DisconnectCommand is the agent control command dropping the SCTP associations of the agent
+	DisconnectCommand = "disconnect"
+	// ReconnectCommand is the agent control command re-establishing the E2 connections of the agent
+	ReconnectCommand = "reconnect"
+	// BlackholeCommand is the agent control command making the agent accept the requests without responding
+	BlackholeCommand = "blackhole"
+)
+
 // NewService returns a new model Service
-func NewService(nodeStore nodes.Store, plmnID types.PlmnID) service.Service {
+func NewService(nodeStore nodes.Store, agentStore agents.Store, plmnID types.PlmnID) service.Service {
 	return &Service{
-		plmnID:    plmnID,
-		nodeStore: nodeStore,
+		plmnID:     plmnID,
+		nodeStore:  nodeStore,
+		agentStore: agentStore,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	plmnID    types.PlmnID
-	nodeStore nodes.Store
+	plmnID     types.PlmnID
+	nodeStore  nodes.Store
+	agentStore agents.Store
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		plmnID:    s.plmnID,
-		nodeStore: s.nodeStore,
+		plmnID:     s.plmnID,
+		nodeStore:  s.nodeStore,
+		agentStore: s.agentStore,
 	}
 	modelapi.RegisterNodeModelServer(r, server)
+	nodesapi.RegisterE2NodeServiceServer(r, server)
 }
 
 // Server implements the TrafficSim gRPC service for administrative facilities.
 type Server struct {
-	plmnID    types.PlmnID
-	nodeStore nodes.Store
+	nodesapi.UnimplementedE2NodeServiceServer
+	plmnID     types.PlmnID
+	nodeStore  nodes.Store
+	agentStore agents.Store
 }
 
 func nodeToAPI(node *model.Node) *types.Node {
@@ -176,11 +200,103 @@ func (s *Server) AgentControl(ctx context.Context, request *modelapi.AgentContro
 		return nil, err
 	}
 	log.Infof("Requested '%s' of agent %d", request.Command, node.GnbID)
-	// TODO: implement agent stop|start, implement connection drop|reconnect, etc.
-	// For now, just put the command into the status
-	err = s.nodeStore.SetStatus(ctx, node.GnbID, request.Command)
+	agent, err := s.agentStore.Get(node.GnbID)
+	if err != nil {
+		return nil, err
+	}
+	switch request.Command {
+	case ResetCommand:
+		// Deletes all the subscriptions of the node and re-establishes its E2 connection
+		err = agent.Reset(ctx)
+	case StopCommand:
+		err = agent.Stop()
+	case StartCommand:
+		// The agent keeps trying to connect to its controllers, hence it is started in the background and the
+		// status of the node is updated once it is started
+		go func() {
+			if err := agent.Start(); err != nil {
+				log.Warnf("Failed to start agent %d: %v", node.GnbID, err)
+			}
+			ctx := context.Background()
+			if err := s.nodeStore.SetStatus(ctx, node.GnbID, agent.Status(ctx)); err != nil {
+				log.Warn(err)
+			}
+		}()
+	case DisconnectCommand:
+		err = agent.Disconnect(ctx)
+	case ReconnectCommand:
+		err = agent.Reconnect(ctx)
+	case BlackholeCommand:
+		err = agent.Blackhole(ctx)
+	default:
+		// The other commands are only recorded in the status of the node
+		err = s.nodeStore.SetStatus(ctx, node.GnbID, request.Command)
+		if err != nil {
+			return nil, err
+		}
+		return &modelapi.AgentControlResponse{Node: nodeToAPI(node)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.nodeStore.SetStatus(ctx, node.GnbID, agent.Status(ctx))
+	if err != nil {
+		return nil, err
+	}
+	node, err = s.nodeStore.Get(ctx, node.GnbID)
 	if err != nil {
 		return nil, err
 	}
 	return &modelapi.AgentControlResponse{Node: nodeToAPI(node)}, nil
 }
+
+// UpdateServiceModels adds and removes service models of a running node, which announces the changes of its RAN
+// functions to its controllers with a RIC service update
+func (s *Server) UpdateServiceModels(ctx context.Context, request *nodesapi.UpdateServiceModelsRequest) (*nodesapi.UpdateServiceModelsResponse, error) {
+	log.Debugf("Received update service models request: %+v", request)
+	node, err := s.nodeStore.Get(ctx, types.GnbID(request.Gnbid))
+	if err != nil {
+		return nil, err
+	}
+	agent, err := s.agentStore.Get(node.GnbID)
+	if err != nil {
+		return nil, err
+	}
+	err = agent.UpdateServiceModels(ctx, request.ServiceModels)
+	if err != nil {
+		return nil, err
+	}
+	updated := *node
+	updated.ServiceModels = request.ServiceModels
+	err = s.nodeStore.Update(ctx, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &nodesapi.UpdateServiceModelsResponse{}, nil
+}
+
+// GetE2Interfaces returns the E2 interface instances of a node towards each of its controllers
+func (s *Server) GetE2Interfaces(ctx context.Context, request *nodesapi.GetE2InterfacesRequest) (*nodesapi.GetE2InterfacesResponse, error) {
+	log.Debugf("Received get E2 interfaces request: %+v", request)
+	agent, err := s.agentStore.Get(types.GnbID(request.Gnbid))
+	if err != nil {
+		return nil, err
+	}
+	node, err := s.nodeStore.Get(ctx, types.GnbID(request.Gnbid))
+	if err != nil {
+		return nil, err
+	}
+	response := &nodesapi.GetE2InterfacesResponse{}
+	for _, iface := range agent.Interfaces(ctx) {
+		state := node.ConnectionStates[iface.Controller]
+		response.Interfaces = append(response.Interfaces, &nodesapi.E2Interface{
+			Controller:               iface.Controller,
+			ErrorIndicationsSent:     iface.ErrorIndicationsSent,
+			ErrorIndicationsReceived: iface.ErrorIndicationsReceived,
+			ConnectionPhase:          state.Phase,
+			SetupFailureCause:        state.SetupFailureCause,
+		})
+	}
+	return response, nil
+}
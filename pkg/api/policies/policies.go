@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	policiesapi "github.com/onosproject/ran-simulator/api/onos/ransim/policies"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var log = liblog.GetLogger()
+
+// NewService returns a new policy Service
+func NewService(policyStore policies.Store) service.Service {
+	return &Service{
+		policyStore: policyStore,
+	}
+}
+
+// Service is a Service implementation for the policy ledger.
+type Service struct {
+	service.Service
+	policyStore policies.Store
+}
+
+// Register registers the policy Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		policyStore: s.policyStore,
+	}
+	policiesapi.RegisterPolicyServiceServer(r, server)
+}
+
+var _ service.Service = &Service{}
+
+// Server implements the policy gRPC service
+type Server struct {
+	policiesapi.UnimplementedPolicyServiceServer
+	policyStore policies.Store
+}
+
+// ListPolicies lists the policies applied by all nodes or by the node of the request
+func (s *Server) ListPolicies(ctx context.Context, request *policiesapi.ListPoliciesRequest) (*policiesapi.ListPoliciesResponse, error) {
+	log.Debugf("Received listing policies request: %v", request)
+	var list []*policies.Policy
+	if request.Gnbid != 0 {
+		list = s.policyStore.ListNode(ctx, types.GnbID(request.Gnbid))
+	} else {
+		list = s.policyStore.List(ctx)
+	}
+	response := &policiesapi.ListPoliciesResponse{
+		Policies: make([]*policiesapi.Policy, 0, len(list)),
+	}
+	for _, policy := range list {
+		response.Policies = append(response.Policies, policyToAPI(policy))
+	}
+	return response, nil
+}
+
+// GetPolicy returns the policy applied by the subscription of the node of the request
+func (s *Server) GetPolicy(ctx context.Context, request *policiesapi.GetPolicyRequest) (*policiesapi.GetPolicyResponse, error) {
+	key := policies.Key{
+		GnbID:          types.GnbID(request.Gnbid),
+		SubscriptionID: request.SubscriptionId,
+	}
+	policy, err := s.policyStore.Get(ctx, key)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &policiesapi.GetPolicyResponse{Policy: policyToAPI(policy)}, nil
+}
+
+func policyToAPI(policy *policies.Policy) *policiesapi.Policy {
+	actions := make([]*policiesapi.Action, 0, len(policy.Actions))
+	for _, action := range policy.Actions {
+		actions = append(actions, actionToAPI(action))
+	}
+	changes := make([]*policiesapi.Change, 0, len(policy.Changes))
+	for _, change := range policy.Changes {
+		changes = append(changes, &policiesapi.Change{
+			Action:    actionToAPI(change.Action),
+			Parameter: policiesapi.Parameter(change.Parameter),
+			Target:    change.Target,
+			Key:       change.Key,
+			Original:  parameterValueToAPI(change.Original),
+			Applied:   parameterValueToAPI(change.Applied),
+		})
+	}
+	return &policiesapi.Policy{
+		Gnbid:          uint64(policy.Key.GnbID),
+		SubscriptionId: policy.Key.SubscriptionID,
+		Actions:        actions,
+		Changes:        changes,
+		CreatedAt:      timestamppb.New(policy.CreatedAt),
+		UpdatedAt:      timestamppb.New(policy.UpdatedAt),
+	}
+}
+
+func actionToAPI(action policies.Action) *policiesapi.Action {
+	return &policiesapi.Action{
+		StyleType: action.StyleType,
+		ActionId:  action.ActionID,
+	}
+}
+
+// parameterValueToAPI converts a value recorded in the ledger; nil if the parameter did not exist
+func parameterValueToAPI(value interface{}) *policiesapi.ParameterValue {
+	switch v := value.(type) {
+	case int:
+		return &policiesapi.ParameterValue{Value: &policiesapi.ParameterValue_IntValue{IntValue: int64(v)}}
+	case int32:
+		return &policiesapi.ParameterValue{Value: &policiesapi.ParameterValue_IntValue{IntValue: int64(v)}}
+	case model.RrmPolicyRatio:
+		return &policiesapi.ParameterValue{Value: &policiesapi.ParameterValue_RrmPolicyRatio{
+			RrmPolicyRatio: &policiesapi.RrmPolicyRatio{
+				Sst:               v.Sst,
+				Sd:                v.Sd,
+				MinPrbRatio:       v.MinPrbRatio,
+				MaxPrbRatio:       v.MaxPrbRatio,
+				DedicatedPrbRatio: v.DedicatedPrbRatio,
+			},
+		}}
+	}
+	return nil
+}
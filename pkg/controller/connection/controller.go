@@ -7,8 +7,11 @@ package connection
 import (
 	"context"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"net"
 	"sync/atomic"
 
+	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"fmt"
@@ -24,6 +27,7 @@ import (
 
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
 	e2connection "github.com/onosproject/ran-simulator/pkg/e2agent/connection"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transport"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 
@@ -38,8 +42,9 @@ const defaultTimeout = 30 * time.Second
 const queueSize = 100
 
 // NewController returns a new connection controller. This controller is responsible to open and close
-// E2 connections that are the result of the E2 Connection Update procedure or E2 Configuration update procedure
-func NewController(connections connections.Store, node model.Node, model *model.Model,
+// E2 connections that are the result of the E2 Connection Update procedure or E2 Configuration update procedure;
+// the connections are opened over the transport of the controller of the node
+func NewController(connections connections.Store, node model.Node, model *model.Model, controllerName string,
 	registry *registry.ServiceModelRegistry, subStore *subscriptions.Subscriptions, cellStore cells.Store) *controller.Controller {
 	c := controller.NewController("E2Connections")
 	c.Watch(&Watcher{
@@ -50,6 +55,7 @@ func NewController(connections connections.Store, node model.Node, model *model.
 		connections: connections,
 		node:        node,
 		model:       model,
+		controller:  controllerName,
 		registry:    registry,
 		subStore:    subStore,
 		cellStore:   cellStore,
@@ -62,6 +68,7 @@ type Reconciler struct {
 	connections   connections.Store
 	node          model.Node
 	model         *model.Model
+	controller    string
 	registry      *registry.ServiceModelRegistry
 	subStore      *subscriptions.Subscriptions
 	transactionID uint64
@@ -164,15 +171,16 @@ func (r *Reconciler) reconcileOpenConnection(connection *connections.Connection)
 			e2connection.WithModel(r.model),
 			e2connection.WithSMRegistry(r.registry),
 			e2connection.WithSubStore(r.subStore),
+			e2connection.WithRICAddress(addressing.RICAddress{
+				IPAddress: net.ParseIP(connection.ID.GetRICIPAddress()),
+				Port:      connection.ID.GetRICPort(),
+			}),
 			e2connection.WithConnectionStore(r.connections),
 			e2connection.WithCellStore(r.cellStore))
 
-		client, err := e2.Connect(ctx, addr, func(channel e2.ClientConn) e2.ClientInterface {
-			return e2Connection
-		})
-
+		client, err := r.dial(ctx, connection, e2Connection)
 		if err != nil {
-			log.Warnf("Failed to reconcile opening connection %+v: %s", connection, err)
+			log.Warnf("Failed to reconcile opening connection %+v at %s: %s", connection, addr, err)
 			return controller.Result{}, err
 		}
 
@@ -204,6 +212,26 @@ func (r *Reconciler) reconcileOpenConnection(connection *connections.Connection)
 
 }
 
+// dial opens the connection to the RIC over the transport of the controller
+func (r *Reconciler) dial(ctx context.Context, connection *connections.Connection, e2Connection e2connection.E2Connection) (e2.ClientConn, error) {
+	ricController, err := r.model.GetController(r.controller)
+	if err != nil {
+		return nil, err
+	}
+	t, err := transport.NewTransport(ricController)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := t.Dial(ctx, addressing.RICAddress{
+		IPAddress: net.ParseIP(connection.ID.GetRICIPAddress()),
+		Port:      connection.ID.GetRICPort(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e2Connection.NewClientConn(conn), nil
+}
+
 func (r *Reconciler) reconcileClosedConnection(connection *connections.Connection) (controller.Result, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
@@ -6,8 +6,14 @@
 package e2agent
 
 import (
+	"bytes"
 	"context"
 	"net"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
 
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm2"
 
@@ -16,31 +22,48 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/e2agent/connection"
 
 	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/mho"
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
-	"github.com/onosproject/rrm-son-lib/pkg/handover"
 
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
 
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ccc"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/llc"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ni"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/rc"
 	rcv1 "github.com/onosproject/ran-simulator/pkg/servicemodel/rc/v1"
 
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 
+	"github.com/onosproject/onos-lib-go/pkg/controller"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	connectionController "github.com/onosproject/ran-simulator/pkg/controller/connection"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
 )
 
 var log = logging.GetLogger()
 
+const (
+	indicationsSentMetric      = "e2_indications_sent"
+	indicationsDroppedMetric   = "e2_indications_dropped"
+	indicationsCoalescedMetric = "e2_indications_coalesced"
+	// indicationCountersPeriod is the period of publication of the indication counters of the node
+	indicationCountersPeriod = time.Second
+)
+
 // E2Agent is an E2 agent
 type E2Agent interface {
 	// Start starts the agent
@@ -48,107 +71,288 @@ type E2Agent interface {
 
 	// Stop stops the agent
 	Stop() error
+
+	// Reset resets the E2 node, deleting all its subscriptions
+	Reset(ctx context.Context) error
+
+	// Disconnect drops the E2 connections of the agent without reconnecting
+	Disconnect(ctx context.Context) error
+
+	// Reconnect re-establishes the E2 connections of the agent
+	Reconnect(ctx context.Context) error
+
+	// Blackhole makes the agent accept the requests of the RICs without responding
+	Blackhole(ctx context.Context) error
+
+	// Status returns the status of the agent derived from the state of its E2 connections
+	Status(ctx context.Context) string
+
+	// UpdateServiceModels adds and removes service models of the node, announcing the changes to the RICs
+	UpdateServiceModels(ctx context.Context, serviceModels []string) error
+
+	// Interfaces returns the E2 interface instances of the node towards each of its controllers
+	Interfaces(ctx context.Context) []Interface
 }
 
+// Interface is an E2 interface instance of the node towards one of its controllers
+type Interface struct {
+	Controller string
+	// ErrorIndicationsSent and ErrorIndicationsReceived count the error indications exchanged with the controller
+	ErrorIndicationsSent     uint64
+	ErrorIndicationsReceived uint64
+}
+
+const (
+	// RunningStatus is the status of an agent connected to a RIC
+	RunningStatus = "Running"
+	// StoppedStatus is the status of a stopped agent
+	StoppedStatus = "Stopped"
+	// DisconnectedStatus is the status of a started agent connected to no RIC
+	DisconnectedStatus = "Disconnected"
+	// BlackholedStatus is the status of an agent which does not respond to the requests of a RIC
+	BlackholedStatus = "Blackholed"
+)
+
 // e2Agent is an E2 agent
 type e2Agent struct {
-	node            model.Node
-	model           *model.Model
+	node           model.Node
+	model          *model.Model
+	instances      []*e2Instance
+	nodeStore      nodes.Store
+	ueStore        ues.Store
+	cellStore      cells.Store
+	messageStore   messages.Store
+	metricStore    metrics.Store
+	policyStore    policies.Store
+	faultStore     faults.Store
+	budget         *connection.IndicationBudget
+	mobilityDriver mobility.Driver
+	scheduler      scheduler.Scheduler
+	cancel         context.CancelFunc
+	mu             sync.RWMutex
+	// start sets up an instance of the node
+	start func(ctx context.Context, instance *e2Instance) error
+	// serviceModelsMu serializes the updates of the service models
+	serviceModelsMu sync.Mutex
+	// serviceModels are the service models the instances were last updated with
+	serviceModels []string
+}
+
+// e2Instance is an E2 interface instance of the node towards one of its controllers; each instance has its
+// own service models, subscriptions, E2 setup and reconnect state
+type e2Instance struct {
+	controller      string
 	registry        *registry.ServiceModelRegistry
 	subStore        *subscriptions.Subscriptions
-	nodeStore       nodes.Store
-	ueStore         ues.Store
-	cellStore       cells.Store
 	connectionStore connections.Store
+	e2Connection    connection.E2Connection
+	controllerLoop  *controller.Controller
 }
 
 // NewE2Agent creates a new E2 agent
 func NewE2Agent(node model.Node, model *model.Model,
-	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store,
-	a3Chan chan handover.A3HandoverDecision, mobilityDriver mobility.Driver) (E2Agent, error) {
+	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store, policyStore policies.Store, messageStore messages.Store,
+	faultStore faults.Store, mobilityDriver mobility.Driver, scheduler scheduler.Scheduler) (E2Agent, error) {
 	log.Info("Creating New E2 Agent for node with e2 Node ID:", node.GnbID)
-	reg := registry.NewServiceModelRegistry()
+	agent := &e2Agent{
+		node:           node,
+		serviceModels:  node.ServiceModels,
+		model:          model,
+		nodeStore:      nodeStore,
+		ueStore:        ueStore,
+		cellStore:      cellStore,
+		messageStore:   messageStore,
+		metricStore:    metricStore,
+		policyStore:    policyStore,
+		faultStore:     faultStore,
+		budget:         connection.NewIndicationBudget(node.IndicationBudget),
+		mobilityDriver: mobilityDriver,
+		scheduler:      scheduler,
+	}
+	agent.start = agent.startInstance
+	// Each controller of the node has its own E2 interface instance
+	for _, controller := range node.Controllers {
+		instance, err := agent.newInstance(controller)
+		if err != nil {
+			return nil, err
+		}
+		agent.instances = append(agent.instances, instance)
+	}
+	return agent, nil
+}
 
-	// Each new e2 agent has its own subscription store
-	subStore := subscriptions.NewStore()
-	sms := node.ServiceModels
-	for _, smID := range sms {
-		serviceModel, err := model.GetServiceModel(smID)
+// newInstance creates the E2 interface instance of the node towards the specified controller
+func (a *e2Agent) newInstance(controller string) (*e2Instance, error) {
+	// Each new instance has its own subscription store
+	instance := &e2Instance{
+		controller: controller,
+		registry:   registry.NewServiceModelRegistry(),
+		subStore:   subscriptions.NewStore(),
+	}
+	for _, smID := range a.node.ServiceModels {
+		sm, err := a.newServiceModel(a.node, smID, instance.subStore)
+		if errors.IsNotSupported(err) {
+			log.Warn(err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		err = instance.registry.RegisterServiceModel(sm)
 		if err != nil {
+			log.Errorf("Failure registering %s service model for e2 Node ID: %v, %s", smID, a.node.GnbID, err.Error())
 			return nil, err
 		}
-		switch registry.RanFunctionID(serviceModel.ID) {
-		case registry.Rcpre2:
-			log.Infof("Registering RC PRE service model for node with e2 Node ID: %v", node.GnbID)
-			rcSm, err := rc.NewServiceModel(node, model,
-				subStore, nodeStore, ueStore, cellStore, metricStore)
-			if err != nil {
-				log.Errorf("Failure creating RC PRE service model for e2 node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-			err = reg.RegisterServiceModel(rcSm)
-			if err != nil {
-				log.Errorf("Failure registering RC PRE service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-		case registry.Kpm2:
-			log.Infof("Registering KPM2 service model for node with e2 Node ID: %v", node.GnbID)
-			kpm2Sm, err := kpm2.NewServiceModel(node, model,
-				subStore, nodeStore, ueStore)
-			if err != nil {
-				log.Errorf("Failure creating KPM2 service model for e2 node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-			err = reg.RegisterServiceModel(kpm2Sm)
-			if err != nil {
-				log.Errorf("Failure registering KPM2 service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-		case registry.Mho:
-			log.Infof("Registering MHO service model for node with e2 Node ID: %v", node.GnbID)
-			mhoSm, err := mho.NewServiceModel(node, model, subStore, nodeStore, ueStore, cellStore,
-				metricStore, a3Chan, mobilityDriver)
-			if err != nil {
-				log.Errorf("Failure creating MHO service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-			err = reg.RegisterServiceModel(mhoSm)
-			if err != nil {
-				log.Errorf("Failure registering MHO service model for e2 Node ID: %s, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-		case registry.Rc:
-			log.Infof("Registering RC service model for e2 node ID:%v", node.GnbID)
-			rcv1Sm, err := rcv1.NewServiceModel(node, model, subStore, nodeStore, ueStore, cellStore, metricStore,
-				a3Chan, mobilityDriver)
-			if err != nil {
-				log.Errorf("Failure creating RC service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
-			err = reg.RegisterServiceModel(rcv1Sm)
-			if err != nil {
-				log.Errorf("Failure registering RC service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
-				return nil, err
-			}
+	}
+	return instance, nil
+}
 
+// newServiceModel creates the service model of the node with the specified name
+func (a *e2Agent) newServiceModel(node model.Node, smID string, subStore *subscriptions.Subscriptions) (registry.ServiceModel, error) {
+	serviceModel, err := a.model.GetServiceModel(smID)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	switch registry.RanFunctionID(serviceModel.ID) {
+	case registry.Rcpre2:
+		log.Infof("Registering RC PRE service model for node with e2 Node ID: %v", node.GnbID)
+		rcSm, err := rc.NewServiceModel(node, a.model,
+			subStore, a.nodeStore, a.ueStore, a.cellStore, a.metricStore)
+		if err != nil {
+			log.Errorf("Failure creating RC PRE service model for e2 node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return rcSm, nil
+	case registry.Kpm2:
+		log.Infof("Registering KPM2 service model for node with e2 Node ID: %v", node.GnbID)
+		kpm2Sm, err := kpm2.NewServiceModel(node, a.model,
+			subStore, a.nodeStore, a.ueStore, a.metricStore)
+		if err != nil {
+			log.Errorf("Failure creating KPM2 service model for e2 node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return kpm2Sm, nil
+	case registry.Mho:
+		log.Infof("Registering MHO service model for node with e2 Node ID: %v", node.GnbID)
+		mhoSm, err := mho.NewServiceModel(node, a.model, subStore, a.nodeStore, a.ueStore, a.cellStore,
+			a.metricStore, a.mobilityDriver)
+		if err != nil {
+			log.Errorf("Failure creating MHO service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return mhoSm, nil
+	case registry.Rc:
+		log.Infof("Registering RC service model for e2 node ID:%v", node.GnbID)
+		rcv1Sm, err := rcv1.NewServiceModel(node, a.model, subStore, a.nodeStore, a.ueStore, a.cellStore, a.metricStore,
+			a.policyStore, a.mobilityDriver)
+		if err != nil {
+			log.Errorf("Failure creating RC service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return rcv1Sm, nil
+	case registry.Ccc:
+		log.Infof("Registering CCC service model for e2 node ID:%v", node.GnbID)
+		cccSm, err := ccc.NewServiceModel(node, a.model, subStore, a.nodeStore, a.ueStore, a.cellStore, a.metricStore)
+		if err != nil {
+			log.Errorf("Failure creating CCC service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return cccSm, nil
+	case registry.Ni:
+		log.Infof("Registering NI service model for e2 node ID:%v", node.GnbID)
+		niSm, err := ni.NewServiceModel(node, a.model, subStore, a.nodeStore, a.ueStore, a.cellStore, a.messageStore)
+		if err != nil {
+			log.Errorf("Failure creating NI service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
+		}
+		return niSm, nil
+	case registry.Llc:
+		log.Infof("Registering LLC service model for e2 node ID:%v", node.GnbID)
+		llcSm, err := llc.NewServiceModel(node, a.model, subStore, a.nodeStore, a.ueStore, a.cellStore, a.scheduler)
+		if err != nil {
+			log.Errorf("Failure creating LLC service model for e2 Node ID: %v, %s", node.GnbID, err.Error())
+			return registry.ServiceModel{}, err
 		}
+		return llcSm, nil
 	}
-	return &e2Agent{
-		node:      node,
-		registry:  reg,
-		model:     model,
-		subStore:  subStore,
-		nodeStore: nodeStore,
-		ueStore:   ueStore,
-		cellStore: cellStore,
-	}, nil
+	return registry.ServiceModel{}, errors.NewNotSupported("service model %s is not supported", smID)
 }
 
 func (a *e2Agent) Start() error {
-	if len(a.node.Controllers) == 0 {
+	a.mu.Lock()
+	if a.cancel != nil {
+		a.mu.Unlock()
+		return errors.NewConflict("e2 agent %d is already started", a.node.GnbID)
+	}
+	if len(a.instances) == 0 {
+		a.mu.Unlock()
 		return errors.NewInvalid("no controller is associated with this node")
 	}
-	controller, err := a.model.GetController(a.node.Controllers[0])
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.mu.Unlock()
+
+	// The instances are set up independently so that an unreachable controller does not hold up the others; the
+	// agent is started once one of them is set up and the others keep retrying in the background
+	results := make(chan error, len(a.instances))
+	for _, instance := range a.instances {
+		go a.runInstance(ctx, instance, results)
+	}
+	var err error
+	for range a.instances {
+		instanceErr := <-results
+		if instanceErr == nil {
+			go a.processTopologyEvents(ctx)
+			go a.publishIndicationCounters(ctx)
+			return nil
+		}
+		if err == nil {
+			err = instanceErr
+		}
+	}
+
+	// None of the controllers could be reached
+	cancel()
+	a.mu.Lock()
+	a.cancel = nil
+	a.mu.Unlock()
+	return err
+}
+
+// runInstance sets up the instance, reporting the result of the first attempt; a failed instance is retried until
+// it is set up or the agent is stopped, unless the retry policy of the node bounds its attempts
+func (a *e2Agent) runInstance(ctx context.Context, instance *e2Instance, results chan<- error) {
+	err := a.start(ctx, instance)
+	results <- err
+	if err == nil {
+		return
+	}
+	log.Warnf("E2 node %d failed to connect to controller %s: %v", a.node.GnbID, instance.controller, err)
+	if a.node.SetupRetry.MaxAttempts > 0 {
+		// The node gave up after the attempts allowed by its retry policy
+		return
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+	count := 0
+	notify := func(err error, t time.Duration) {
+		count++
+		log.Warnf("E2 node %d failed to connect to controller %s; retry after %v; attempt %d: %v", a.node.GnbID, instance.controller, t, count, err)
+	}
+	err = backoff.RetryNotify(func() error {
+		if ctx.Err() != nil {
+			return backoff.Permanent(ctx.Err())
+		}
+		return a.start(ctx, instance)
+	}, backoff.WithContext(b, ctx), notify)
+	if err != nil {
+		return
+	}
+	log.Infof("E2 node %d is connected to controller %s", a.node.GnbID, instance.controller)
+}
+
+// startInstance connects the instance to its controller and runs the E2 setup procedure
+func (a *e2Agent) startInstance(ctx context.Context, instance *e2Instance) error {
+	controller, err := a.model.GetController(instance.controller)
 	if err != nil {
 		return err
 	}
@@ -162,9 +366,7 @@ func (a *e2Agent) Start() error {
 		Port:      uint64(controller.Port),
 	}
 	connectionStore := connections.NewStore()
-	a.connectionStore = connectionStore
-
-	c := connectionController.NewController(connectionStore, a.node, a.model, a.registry, a.subStore, a.cellStore)
+	c := connectionController.NewController(connectionStore, a.node, a.model, instance.controller, instance.registry, instance.subStore, a.cellStore)
 	err = c.Start()
 	if err != nil {
 		return err
@@ -172,40 +374,447 @@ func (a *e2Agent) Start() error {
 
 	e2Connection := connection.NewE2Connection(connection.WithNode(a.node),
 		connection.WithModel(a.model),
-		connection.WithSMRegistry(a.registry),
-		connection.WithSubStore(a.subStore),
+		connection.WithController(instance.controller),
+		connection.WithSMRegistry(instance.registry),
+		connection.WithSubStore(instance.subStore),
 		connection.WithRICAddress(ricAddress),
 		connection.WithConnectionStore(connectionStore),
-		connection.WithCellStore(a.cellStore))
+		connection.WithCellStore(a.cellStore),
+		connection.WithMessageStore(a.messageStore),
+		connection.WithNodeStore(a.nodeStore),
+		connection.WithMetricStore(a.metricStore),
+		connection.WithFaultStore(a.faultStore),
+		connection.WithIndicationBudget(a.budget))
 
-	err = e2Connection.Setup()
+	err = e2Connection.Setup(ctx)
 	if err != nil {
+		c.Stop()
 		return err
 	}
+	a.mu.Lock()
+	if ctx.Err() != nil {
+		// The agent is stopped while the instance is set up
+		a.mu.Unlock()
+		c.Stop()
+		if err := e2Connection.Disconnect(context.Background()); err != nil {
+			log.Warn(err)
+		}
+		return ctx.Err()
+	}
+	instance.connectionStore = connectionStore
+	instance.controllerLoop = c
+	instance.e2Connection = e2Connection
+	a.mu.Unlock()
+	return nil
+}
+
+// connectedInstances returns a snapshot of the instances of the node which completed their E2 setup
+func (a *e2Agent) connectedInstances() []*e2Instance {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	instances := make([]*e2Instance, 0, len(a.instances))
+	for _, instance := range a.instances {
+		if instance.e2Connection != nil {
+			snapshot := *instance
+			instances = append(instances, &snapshot)
+		}
+	}
+	return instances
+}
+
+// processTopologyEvents tells the RIC about the changes of the cells of the node: the node sends an E2 node
+// configuration update and requires the deletion of the subscriptions which can no longer be served once a
+// cell of the node is deleted. The Xn peers of the node are told about the changes of the neighbours of its cells.
+func (a *e2Agent) processTopologyEvents(ctx context.Context) {
+	cellCh := make(chan event.Event)
+	err := a.cellStore.Watch(ctx, cellCh)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	nodeCh := make(chan event.Event)
+	err = a.nodeStore.Watch(ctx, nodeCh)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	// Changes arriving while a configuration update is in progress are sent in a single configuration update
+	updateCh := make(chan struct{}, 1)
+	go a.sendConfigurationUpdates(ctx, updateCh)
+	configurationChanged := func() {
+		select {
+		case updateCh <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		select {
+		case cellEvent, ok := <-cellCh:
+			if !ok {
+				return
+			}
+			ncgi := cellEvent.Key.(types.NCGI)
+			if !a.isNodeCell(ctx, ncgi) {
+				continue
+			}
+			switch cellEvent.Type {
+			case cells.Created:
+				a.ranFunctionsChanged(ctx)
+				configurationChanged()
+			case cells.Updated:
+				configurationChanged()
+			case cells.UpdatedNeighbors:
+				a.neighborsChanged(ctx, ncgi)
+				configurationChanged()
+			case cells.Deleted:
+				for _, instance := range a.connectedInstances() {
+					err = instance.e2Connection.RICSubscriptionDeleteRequired(ctx, ncgi)
+					if err != nil {
+						log.Warn(err)
+					}
+				}
+				a.ranFunctionsChanged(ctx)
+				configurationChanged()
+			}
+		case nodeEvent, ok := <-nodeCh:
+			if !ok {
+				return
+			}
+			if nodeEvent.Type != nodes.Updated || nodeEvent.Key.(types.GnbID) != a.node.GnbID {
+				continue
+			}
+			// Only the changes of the service models of the node change its RAN functions
+			if serviceModels := nodeEvent.Value.(*model.Node).ServiceModels; a.serviceModelsChanged(serviceModels) {
+				if err := a.UpdateServiceModels(ctx, serviceModels); err != nil {
+					log.Warn(err)
+				}
+				configurationChanged()
+			}
+		}
+	}
+}
+
+// serviceModelsChanged returns whether the service models differ from the ones the instances were last updated with
+func (a *e2Agent) serviceModelsChanged(serviceModels []string) bool {
+	a.serviceModelsMu.Lock()
+	defer a.serviceModelsMu.Unlock()
+	if len(serviceModels) != len(a.serviceModels) {
+		return true
+	}
+	for i, smID := range serviceModels {
+		if a.serviceModels[i] != smID {
+			return true
+		}
+	}
+	return false
+}
+
+// ranFunctionsChanged regenerates the RAN function definitions of the service models of the node, which describe
+// its cells, once a cell of the node is created or deleted
+func (a *e2Agent) ranFunctionsChanged(ctx context.Context) {
+	serviceModels := a.node.ServiceModels
+	if node, err := a.nodeStore.Get(ctx, a.node.GnbID); err == nil {
+		serviceModels = node.ServiceModels
+	}
+	if err := a.UpdateServiceModels(ctx, serviceModels); err != nil {
+		log.Warn(err)
+	}
+}
+
+// neighborsChanged sends an Xn NG-RAN node configuration update with the new neighbours of the cell of the node.
+// The update does not depend on the E2 interface instance, so it is sent through the first connected one.
+func (a *e2Agent) neighborsChanged(ctx context.Context, ncgi types.NCGI) {
+	instances := a.connectedInstances()
+	if len(instances) == 0 {
+		return
+	}
+	if err := instances[0].e2Connection.NGRANNodeConfigurationUpdate(ctx, ncgi); err != nil {
+		log.Warnf("E2 node %d failed to send NG-RAN node configuration update of cell %v: %v", a.node.GnbID, ncgi, err)
+	}
+}
+
+// UpdateServiceModels adds and removes service models of the node and regenerates the RAN function definitions of
+// the others from the current cells of the node. The connected instances announce the changes of their RAN functions
+// to their controllers with a RIC service update; the other instances announce them with their next E2 setup.
+func (a *e2Agent) UpdateServiceModels(ctx context.Context, serviceModels []string) error {
+	for _, smID := range serviceModels {
+		if _, err := a.model.GetServiceModel(smID); err != nil {
+			return err
+		}
+	}
+	a.serviceModelsMu.Lock()
+	defer a.serviceModelsMu.Unlock()
+	node := a.node
+	node.ServiceModels = serviceModels
+	if current, err := a.nodeStore.Get(ctx, a.node.GnbID); err == nil {
+		node.Cells = current.Cells
+	}
+
+	a.mu.RLock()
+	instances := make([]e2Instance, 0, len(a.instances))
+	for _, instance := range a.instances {
+		instances = append(instances, *instance)
+	}
+	a.mu.RUnlock()
+	var err error
+	for i := range instances {
+		if instanceErr := a.updateServiceModels(ctx, &instances[i], node); instanceErr != nil {
+			log.Warnf("E2 node %d failed to update its service models with controller %s: %v", a.node.GnbID, instances[i].controller, instanceErr)
+			err = instanceErr
+		}
+	}
+	if err == nil {
+		a.serviceModels = serviceModels
+	}
+	return err
+}
+
+// updateServiceModels updates the service models registered by the instance with the ones of the node. Only the
+// added service models are created; the RAN function definitions of the registered ones describing the cells of the
+// node are regenerated and compared with their current ones.
+func (a *e2Agent) updateServiceModels(ctx context.Context, instance *e2Instance, node model.Node) error {
+	registered := instance.registry.GetServiceModels()
+	added := make([]registry.ServiceModel, 0)
+	modified := make([]registry.ServiceModel, 0)
+	for _, smID := range node.ServiceModels {
+		serviceModel, err := a.model.GetServiceModel(smID)
+		if err != nil {
+			return err
+		}
+		current, ok := registered[registry.RanFunctionID(serviceModel.ID)]
+		if !ok {
+			sm, err := a.newServiceModel(node, smID, instance.subStore)
+			if errors.IsNotSupported(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			added = append(added, sm)
+			continue
+		}
+		delete(registered, current.RanFunctionID)
+		describer, ok := current.Client.(servicemodel.CellDescriber)
+		if !ok {
+			continue
+		}
+		description, err := describer.RanFunctionDescription(ctx, node)
+		if err != nil {
+			return err
+		}
+		// The registered service model keeps serving its subscriptions with its new RAN function definition
+		if !bytes.Equal(current.Description, description) {
+			current.Description = description
+			modified = append(modified, current)
+		}
+	}
+	removed := make([]registry.RanFunctionID, 0, len(registered))
+	for ranFunctionID := range registered {
+		removed = append(removed, ranFunctionID)
+	}
+	if len(added) == 0 && len(modified) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if instance.e2Connection != nil {
+		return instance.e2Connection.RICServiceUpdate(ctx, added, modified, removed)
+	}
+	for _, ranFunctionID := range removed {
+		if err := instance.registry.UnregisterServiceModel(ranFunctionID); err != nil {
+			return err
+		}
+	}
+	for _, sm := range added {
+		if err := instance.registry.RegisterServiceModel(sm); err != nil {
+			return err
+		}
+	}
+	for _, sm := range modified {
+		if _, err := instance.registry.ModifyServiceModel(sm.RanFunctionID, sm.Description); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// sendConfigurationUpdates sends an E2 node configuration update whenever the configuration of the node changes
+func (a *e2Agent) sendConfigurationUpdates(ctx context.Context, updateCh <-chan struct{}) {
+	for {
+		select {
+		case <-updateCh:
+			for _, instance := range a.connectedInstances() {
+				err := instance.e2Connection.ConfigurationUpdate(ctx)
+				if err != nil {
+					log.Warnf("E2 node %d failed to send configuration update to controller %s: %v", a.node.GnbID, instance.controller, err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publishIndicationCounters periodically publishes the counters of the indications sent, dropped and coalesced
+// by the node within its indication budget as metrics of the node
+func (a *e2Agent) publishIndicationCounters(ctx context.Context) {
+	ticker := time.NewTicker(indicationCountersPeriod)
+	defer ticker.Stop()
+	var published [3]uint64
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		sent, dropped, coalesced := a.budget.Counters()
+		counters := [3]uint64{sent, dropped, coalesced}
+		if counters == published {
+			continue
+		}
+		for i, name := range []string{indicationsSentMetric, indicationsDroppedMetric, indicationsCoalescedMetric} {
+			if err := a.metricStore.Set(ctx, uint64(a.node.GnbID), name, counters[i]); err != nil {
+				log.Warn(err)
+			}
+		}
+		published = counters
+	}
+}
+
+// isNodeCell returns whether the cell belongs to the node, either currently or when the agent was created
+func (a *e2Agent) isNodeCell(ctx context.Context, ncgi types.NCGI) bool {
+	nodeCells := a.node.Cells
+	if node, err := a.nodeStore.Get(ctx, a.node.GnbID); err == nil {
+		nodeCells = append(nodeCells[:len(nodeCells):len(nodeCells)], node.Cells...)
+	}
+	for _, cell := range nodeCells {
+		if cell == ncgi {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *e2Agent) Stop() error {
 	log.Debugf("Stopping e2 agent with ID %d:", a.node.GnbID)
+	instances := a.connectedInstances()
+	a.mu.Lock()
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+	loops := make([]*controller.Controller, 0, len(a.instances))
+	for _, instance := range a.instances {
+		if instance.controllerLoop != nil {
+			loops = append(loops, instance.controllerLoop)
+		}
+		instance.e2Connection = nil
+		instance.controllerLoop = nil
+	}
+	a.mu.Unlock()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	conns := a.connectionStore.List(context.Background())
-	log.Debugf("List of Connections: %+v", conns)
-	for _, conn := range conns {
-		if conn.Client != nil {
-			log.Debugf("Closing connection: %+v", conn.ID)
-			err := conn.Client.Close()
-			if err != nil {
-				return err
-			}
-			err = a.connectionStore.Remove(ctx, conn.ID)
-			if err != nil {
-				return err
-			}
+	for _, instance := range instances {
+		// The subscriptions do not survive the agent, their reports are stopped
+		instance.e2Connection.DeleteSubscriptions(ctx)
+		err := instance.e2Connection.Disconnect(ctx)
+		if err != nil {
+			return err
 		}
+	}
+	for _, loop := range loops {
+		loop.Stop()
+	}
+	return nil
+}
 
+func (a *e2Agent) Reset(ctx context.Context) error {
+	log.Debugf("Resetting e2 agent with ID %d:", a.node.GnbID)
+	instances := a.connectedInstances()
+	if len(instances) == 0 {
+		return errors.NewUnavailable("e2 agent %d is not started", a.node.GnbID)
+	}
+	for _, instance := range instances {
+		if err := instance.e2Connection.Reset(ctx); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func (a *e2Agent) Disconnect(ctx context.Context) error {
+	log.Debugf("Disconnecting e2 agent with ID %d:", a.node.GnbID)
+	instances := a.connectedInstances()
+	if len(instances) == 0 {
+		return errors.NewUnavailable("e2 agent %d is not started", a.node.GnbID)
+	}
+	for _, instance := range instances {
+		if err := instance.e2Connection.Disconnect(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *e2Agent) Reconnect(ctx context.Context) error {
+	log.Debugf("Reconnecting e2 agent with ID %d:", a.node.GnbID)
+	instances := a.connectedInstances()
+	if len(instances) == 0 {
+		return errors.NewUnavailable("e2 agent %d is not started", a.node.GnbID)
+	}
+	for _, instance := range instances {
+		if err := instance.e2Connection.Reconnect(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *e2Agent) Blackhole(ctx context.Context) error {
+	log.Debugf("Blackholing e2 agent with ID %d:", a.node.GnbID)
+	instances := a.connectedInstances()
+	if len(instances) == 0 {
+		return errors.NewUnavailable("e2 agent %d is not started", a.node.GnbID)
+	}
+	for _, instance := range instances {
+		instance.e2Connection.Blackhole()
+	}
+	return nil
+}
+
+func (a *e2Agent) Status(ctx context.Context) string {
+	a.mu.RLock()
+	started := a.cancel != nil
+	a.mu.RUnlock()
+	if !started {
+		return StoppedStatus
+	}
+	status := DisconnectedStatus
+	for _, instance := range a.connectedInstances() {
+		if instance.e2Connection.Blackholed() {
+			return BlackholedStatus
+		}
+		for _, conn := range instance.connectionStore.List(ctx) {
+			if conn.Status.Phase == connections.Open && conn.Status.State == connections.Configured {
+				status = RunningStatus
+			}
+		}
+	}
+	return status
+}
+
+func (a *e2Agent) Interfaces(ctx context.Context) []Interface {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	interfaces := make([]Interface, 0, len(a.instances))
+	for _, instance := range a.instances {
+		iface := Interface{Controller: instance.controller}
+		if instance.e2Connection != nil {
+			iface.ErrorIndicationsSent, iface.ErrorIndicationsReceived = instance.e2Connection.ErrorIndications()
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
 var _ E2Agent = &e2Agent{}
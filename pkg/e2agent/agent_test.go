@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package e2agent
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/connection"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAgent creates an agent whose instances are set up by the start function
+func newTestAgent(start func(ctx context.Context, instance *e2Instance) error, controllers ...string) *e2Agent {
+	nodeStore := nodes.NewNodeRegistry(map[string]model.Node{})
+	a := &e2Agent{
+		node:        model.Node{GnbID: 144, Controllers: controllers},
+		nodeStore:   nodeStore,
+		cellStore:   cells.NewCellRegistry(map[string]model.Cell{}, nodeStore),
+		metricStore: metrics.NewMetricsStore(),
+		budget:      connection.NewIndicationBudget(model.IndicationBudget{}),
+		start:       start,
+	}
+	for _, controller := range controllers {
+		a.instances = append(a.instances, &e2Instance{controller: controller})
+	}
+	return a
+}
+
+func TestStartWithDeadController(t *testing.T) {
+	var attempts int32
+	a := newTestAgent(func(ctx context.Context, instance *e2Instance) error {
+		if instance.controller == "dead" {
+			atomic.AddInt32(&attempts, 1)
+			return errors.NewUnavailable("controller %s is unreachable", instance.controller)
+		}
+		return nil
+	}, "dead", "live")
+
+	// the agent is started by the live controller and keeps retrying the dead one in the background
+	assert.NoError(t, a.Start())
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&attempts) > 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the retries stop with the agent
+	assert.NoError(t, a.Stop())
+	stopped := atomic.LoadInt32(&attempts)
+	time.Sleep(2 * time.Second)
+	assert.Equal(t, stopped, atomic.LoadInt32(&attempts))
+	assert.Equal(t, StoppedStatus, a.Status(context.Background()))
+}
+
+func TestStartWithoutController(t *testing.T) {
+	a := newTestAgent(func(ctx context.Context, instance *e2Instance) error {
+		return errors.NewUnavailable("controller %s is unreachable", instance.controller)
+	}, "dead1", "dead2")
+
+	// the agent is not started if none of its controllers can be reached
+	assert.True(t, errors.IsUnavailable(a.Start()))
+	assert.Equal(t, StoppedStatus, a.Status(context.Background()))
+}
+
+func TestUpdateServiceModels(t *testing.T) {
+	ctx := context.Background()
+	ncgi1 := types.ToNCGI(0x138426, 0x1)
+	ncgi2 := types.ToNCGI(0x138426, 0x2)
+	a := newTestAgent(nil)
+	a.model = &model.Model{
+		PlmnID: 0x138426,
+		ServiceModels: map[string]model.ServiceModel{
+			"kpm2": {ID: int(registry.Kpm2)},
+			"mho":  {ID: int(registry.Mho)},
+		},
+	}
+	a.node.Cells = []types.NCGI{ncgi1}
+	a.node.ServiceModels = []string{"kpm2", "mho"}
+	a.serviceModels = a.node.ServiceModels
+	a.nodeStore.Load(ctx, map[string]model.Node{"node1": a.node})
+	instance, err := a.newInstance("controller1")
+	assert.NoError(t, err)
+	a.instances = []*e2Instance{instance}
+
+	// the RAN functions are left alone while the service models and the cells of the node are unchanged
+	assert.False(t, a.serviceModelsChanged([]string{"kpm2", "mho"}))
+	assert.NoError(t, a.UpdateServiceModels(ctx, []string{"kpm2", "mho"}))
+	kpm2Sm, err := instance.registry.GetServiceModel(registry.Kpm2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, kpm2Sm.Revision)
+
+	// the KPM RAN function describes the new cell of the node, the MHO one does not describe the cells
+	node := a.node
+	node.Cells = []types.NCGI{ncgi1, ncgi2}
+	assert.NoError(t, a.nodeStore.Update(ctx, &node))
+	assert.NoError(t, a.UpdateServiceModels(ctx, []string{"kpm2", "mho"}))
+	modified, err := instance.registry.GetServiceModel(registry.Kpm2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, modified.Revision)
+	assert.NotEqual(t, kpm2Sm.Description, modified.Description)
+	mhoSm, err := instance.registry.GetServiceModel(registry.Mho)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mhoSm.Revision)
+
+	// the removed service model is unregistered
+	assert.True(t, a.serviceModelsChanged([]string{"kpm2"}))
+	assert.NoError(t, a.UpdateServiceModels(ctx, []string{"kpm2"}))
+	_, err = instance.registry.GetServiceModel(registry.Mho)
+	assert.Error(t, err)
+	assert.False(t, a.serviceModelsChanged([]string{"kpm2"}))
+}
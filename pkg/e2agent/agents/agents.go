@@ -7,10 +7,9 @@ package agents
 import (
 	"context"
 
-	"github.com/onosproject/rrm-son-lib/pkg/handover"
-
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
@@ -20,7 +19,10 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/agents"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 )
 
@@ -33,9 +35,12 @@ type E2Agents struct {
 	ueStore        ues.Store
 	cellStore      cells.Store
 	metricStore    metrics.Store
+	policyStore    policies.Store
+	messageStore   messages.Store
+	faultStore     faults.Store
 	model          *model.Model
-	a3Chan         chan handover.A3HandoverDecision
 	mobilityDriver mobility.Driver
+	scheduler      scheduler.Scheduler
 }
 
 // Agents agents interface
@@ -60,7 +65,7 @@ func (agents *E2Agents) processNodeEvents() {
 			node := nodeEvent.Value.(*model.Node)
 			log.Debugf("Starting e2 agent %d", nodeEvent.Key.(types.GnbID))
 			e2Node, err := e2agent.NewE2Agent(*node, agents.model, agents.nodeStore, agents.ueStore,
-				agents.cellStore, agents.metricStore, agents.a3Chan, agents.mobilityDriver)
+				agents.cellStore, agents.metricStore, agents.policyStore, agents.messageStore, agents.faultStore, agents.mobilityDriver, agents.scheduler)
 			if err != nil {
 				log.Error(err)
 				continue
@@ -78,7 +83,7 @@ func (agents *E2Agents) processNodeEvents() {
 					log.Error(err)
 				}
 			}
-			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, "Running")
+			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, e2agent.RunningStatus)
 			if err != nil {
 				log.Error(err)
 			}
@@ -97,7 +102,7 @@ func (agents *E2Agents) processNodeEvents() {
 				continue
 			}
 
-			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, "Stopped")
+			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, e2agent.StoppedStatus)
 			if err != nil {
 				log.Error(err)
 			}
@@ -112,10 +117,9 @@ func (agents *E2Agents) processNodeEvents() {
 }
 
 // NewE2Agents creates a new collection of E2 agents from the specified list of nodes
-func NewE2Agents(m *model.Model,
-	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store,
-	a3Chan chan handover.A3HandoverDecision, mobilityDriver mobility.Driver) (*E2Agents, error) {
-	agentStore := agents.NewStore()
+func NewE2Agents(m *model.Model, agentStore agents.Store,
+	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store, policyStore policies.Store, messageStore messages.Store,
+	faultStore faults.Store, mobilityDriver mobility.Driver, scheduler scheduler.Scheduler) (*E2Agents, error) {
 	e2agents := &E2Agents{
 		agentStore:     agentStore,
 		nodeStore:      nodeStore,
@@ -123,12 +127,15 @@ func NewE2Agents(m *model.Model,
 		ueStore:        ueStore,
 		cellStore:      cellStore,
 		metricStore:    metricStore,
-		a3Chan:         a3Chan,
+		policyStore:    policyStore,
+		messageStore:   messageStore,
+		faultStore:     faultStore,
 		mobilityDriver: mobilityDriver,
+		scheduler:      scheduler,
 	}
 
 	for _, node := range m.Nodes {
-		e2Node, err := e2agent.NewE2Agent(node, m, nodeStore, ueStore, cellStore, metricStore, a3Chan, mobilityDriver)
+		e2Node, err := e2agent.NewE2Agent(node, m, nodeStore, ueStore, cellStore, metricStore, policyStore, messageStore, faultStore, mobilityDriver, scheduler)
 		if err != nil {
 			log.Error(err)
 			return nil, err
@@ -139,7 +146,7 @@ func NewE2Agents(m *model.Model,
 			log.Error(err)
 			return nil, err
 		}
-		err = nodeStore.SetStatus(context.Background(), node.GnbID, "Running")
+		err = nodeStore.SetStatus(context.Background(), node.GnbID, e2agent.RunningStatus)
 		if err != nil {
 			log.Error(err)
 			return nil, err
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/store/connections"
+)
+
+// allowsUsage returns whether a TNL association with the specified usage may carry the messages of the given usage
+func allowsUsage(associationUsage e2apies.Tnlusage, usage e2apies.Tnlusage) bool {
+	return associationUsage == e2apies.Tnlusage_TNLUSAGE_BOTH || associationUsage == usage
+}
+
+// associations is the E2 client of an E2 interface instance which sends each message over the TNL associations
+// whose usage allows it; the messages are load balanced across these associations and fail over to the next one
+// when sending fails
+type associations struct {
+	e2.ClientConn
+	connectionStore connections.Store
+	next            uint64
+}
+
+func newAssociations(client e2.ClientConn, connectionStore connections.Store) *associations {
+	return &associations{
+		ClientConn:      client,
+		connectionStore: connectionStore,
+	}
+}
+
+// clients returns the clients of the configured TNL associations for the specified usage, starting from the next
+// association in turn
+func (a *associations) clients(ctx context.Context, usage e2apies.Tnlusage) []e2.ClientConn {
+	conns := make([]*connections.Connection, 0)
+	for _, conn := range a.connectionStore.List(ctx) {
+		if conn.Client == nil || conn.Status.Phase != connections.Open || conn.Status.State != connections.Configured {
+			continue
+		}
+		if allowsUsage(conn.Usage, usage) {
+			conns = append(conns, conn)
+		}
+	}
+	if len(conns) == 0 {
+		return nil
+	}
+	sort.Slice(conns, func(i, j int) bool {
+		if conns[i].ID.GetRICIPAddress() != conns[j].ID.GetRICIPAddress() {
+			return conns[i].ID.GetRICIPAddress() < conns[j].ID.GetRICIPAddress()
+		}
+		return conns[i].ID.GetRICPort() < conns[j].ID.GetRICPort()
+	})
+
+	first := int(atomic.AddUint64(&a.next, 1) % uint64(len(conns)))
+	clients := make([]e2.ClientConn, 0, len(conns))
+	for i := range conns {
+		clients = append(clients, conns[(first+i)%len(conns)].Client)
+	}
+	return clients
+}
+
+// send sends a message of the specified usage, failing over to the next TNL association on errors
+func (a *associations) send(ctx context.Context, usage e2apies.Tnlusage, send func(client e2.ClientConn) error) error {
+	clients := a.clients(ctx, usage)
+	if len(clients) == 0 {
+		return errors.NewUnavailable("no TNL association is available for %s", usage.String())
+	}
+	var err error
+	for _, client := range clients {
+		if err = send(client); err == nil {
+			return nil
+		}
+		log.Warnf("Failed to send message over TNL association %s: %v", client.RemoteAddr(), err)
+	}
+	return err
+}
+
+// RICIndication sends the indication over a RIC service TNL association
+func (a *associations) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	return a.send(ctx, e2apies.Tnlusage_TNLUSAGE_RIC_SERVICE, func(client e2.ClientConn) error {
+		return client.RICIndication(ctx, request)
+	})
+}
+
+// E2ConfigurationUpdate sends the configuration update over an E2 support function TNL association
+func (a *associations) E2ConfigurationUpdate(ctx context.Context, request *e2appducontents.E2NodeConfigurationUpdate) (response *e2appducontents.E2NodeConfigurationUpdateAcknowledge, failure *e2appducontents.E2NodeConfigurationUpdateFailure, err error) {
+	err = a.send(ctx, e2apies.Tnlusage_TNLUSAGE_SUPPORT_FUNCTION, func(client e2.ClientConn) error {
+		response, failure, err = client.E2ConfigurationUpdate(ctx, request)
+		return err
+	})
+	return response, failure, err
+}
+
+var _ e2.ClientConn = &associations{}
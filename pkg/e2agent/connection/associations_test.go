@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/connections"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	e2.ClientConn
+	failed        bool
+	indications   int
+	configUpdates int
+}
+
+func (c *testClient) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	if c.failed {
+		return errors.NewUnavailable("connection is closed")
+	}
+	c.indications++
+	return nil
+}
+
+func (c *testClient) E2ConfigurationUpdate(ctx context.Context, request *e2appducontents.E2NodeConfigurationUpdate) (*e2appducontents.E2NodeConfigurationUpdateAcknowledge, *e2appducontents.E2NodeConfigurationUpdateFailure, error) {
+	c.configUpdates++
+	return &e2appducontents.E2NodeConfigurationUpdateAcknowledge{}, nil, nil
+}
+
+func (c *testClient) RemoteAddr() net.Addr {
+	return &net.TCPAddr{}
+}
+
+func addTestConnection(t *testing.T, store connections.Store, port uint64, usage e2apies.Tnlusage) *testClient {
+	client := &testClient{}
+	id := connections.NewConnectionID("10.0.0.1", port)
+	err := store.Add(context.TODO(), id, &connections.Connection{
+		ID:     id,
+		Client: client,
+		Status: connections.ConnectionStatus{
+			Phase: connections.Open,
+			State: connections.Configured,
+		},
+		Usage: usage,
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestAssociations(t *testing.T) {
+	ctx := context.TODO()
+	store := connections.NewStore()
+	both := addTestConnection(t, store, 36421, e2apies.Tnlusage_TNLUSAGE_BOTH)
+	service := addTestConnection(t, store, 36422, e2apies.Tnlusage_TNLUSAGE_RIC_SERVICE)
+	support := addTestConnection(t, store, 36423, e2apies.Tnlusage_TNLUSAGE_SUPPORT_FUNCTION)
+	client := newAssociations(both, store)
+
+	// Indications are load balanced across the RIC service associations only
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, client.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	}
+	assert.Equal(t, 2, both.indications)
+	assert.Equal(t, 2, service.indications)
+	assert.Equal(t, 0, support.indications)
+
+	// Indications fail over to the remaining RIC service association
+	service.failed = true
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, client.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	}
+	assert.Equal(t, 4, both.indications)
+
+	both.failed = true
+	assert.Error(t, client.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	assert.Equal(t, 0, support.indications)
+}
+
+func TestConfigurationUpdateAssociations(t *testing.T) {
+	ctx := context.TODO()
+	store := connections.NewStore()
+	first := addTestConnection(t, store, 36421, e2apies.Tnlusage_TNLUSAGE_SUPPORT_FUNCTION)
+	second := addTestConnection(t, store, 36422, e2apies.Tnlusage_TNLUSAGE_BOTH)
+	ncgi1 := ransimtypes.ToNCGI(0x138426, 0x1)
+	ncgi2 := ransimtypes.ToNCGI(0x138426, 0x2)
+	node := model.Node{GnbID: 144, Cells: []ransimtypes.NCGI{ncgi1, ncgi2}}
+	nodeStore := nodes.NewNodeRegistry(map[string]model.Node{"node1": node})
+	e := NewE2Connection(
+		WithNode(node),
+		WithModel(&model.Model{PlmnID: 0x138426}),
+		WithConnectionStore(store),
+		WithNodeStore(nodeStore),
+		WithCellStore(cells.NewCellRegistry(map[string]model.Cell{
+			"cell1": {NCGI: ncgi1, Neighbors: []ransimtypes.NCGI{ncgi2}},
+			"cell2": {NCGI: ncgi2, Neighbors: []ransimtypes.NCGI{ncgi1}},
+		}, nodeStore)))
+
+	// The configuration updates of the instance are load balanced across its E2 support function associations
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, e.ConfigurationUpdate(ctx))
+	}
+	assert.Equal(t, 2, first.configUpdates)
+	assert.Equal(t, 2, second.configUpdates)
+}
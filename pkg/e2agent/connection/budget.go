@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DropOverflow drops the indications exceeding the budget
+	DropOverflow = "drop"
+	// CoalesceOverflow keeps the last indication exceeding the budget of a subscription and sends it as soon as
+	// the budget allows, replacing the indications still pending
+	CoalesceOverflow = "coalesce"
+)
+
+// IndicationBudget is the indication budget of a node, shared by the subscriptions of all its E2 interface instances,
+// which counts the indications sent, dropped and coalesced by the node
+type IndicationBudget struct {
+	policy    model.IndicationBudget
+	limiter   *rate.Limiter
+	sent      uint64
+	dropped   uint64
+	coalesced uint64
+}
+
+// NewIndicationBudget creates the indication budget of a node from the specified policy
+func NewIndicationBudget(policy model.IndicationBudget) *IndicationBudget {
+	return &IndicationBudget{
+		policy:  policy,
+		limiter: newLimiter(policy.NodeRate, policy.NodeBurst),
+	}
+}
+
+// Counters returns the number of indications sent, dropped and coalesced by the node
+func (b *IndicationBudget) Counters() (sent uint64, dropped uint64, coalesced uint64) {
+	return atomic.LoadUint64(&b.sent), atomic.LoadUint64(&b.dropped), atomic.LoadUint64(&b.coalesced)
+}
+
+func newLimiter(r float64, burst int) *rate.Limiter {
+	if r <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(r), burst)
+}
+
+// reserve reserves an indication in each of the specified limiters if all of them allow one now
+func reserve(limiters ...*rate.Limiter) bool {
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		reservation := limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// budgetedClient is the E2 client of a subscription which sends its indications within the budget of the
+// subscription and of the node
+type budgetedClient struct {
+	e2.ClientConn
+	budget  *IndicationBudget
+	limiter *rate.Limiter
+	mu      sync.Mutex
+	pending *e2appducontents.Ricindication
+}
+
+func newBudgetedClient(client e2.ClientConn, budget *IndicationBudget) e2.ClientConn {
+	if budget == nil {
+		return client
+	}
+	return &budgetedClient{
+		ClientConn: client,
+		budget:     budget,
+		limiter:    newLimiter(budget.policy.SubscriptionRate, budget.policy.SubscriptionBurst),
+	}
+}
+
+// RICIndication sends the indication if the budget allows it; otherwise the indication is dropped or coalesced
+// with the indications of the subscription still pending
+func (c *budgetedClient) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	c.mu.Lock()
+	if c.pending != nil {
+		c.pending = request
+		c.mu.Unlock()
+		atomic.AddUint64(&c.budget.coalesced, 1)
+		return nil
+	}
+	if reserve(c.limiter, c.budget.limiter) {
+		c.mu.Unlock()
+		return c.send(ctx, request)
+	}
+	if c.budget.policy.Overflow != CoalesceOverflow {
+		c.mu.Unlock()
+		atomic.AddUint64(&c.budget.dropped, 1)
+		return nil
+	}
+	c.pending = request
+	c.mu.Unlock()
+	go c.flush(ctx)
+	return nil
+}
+
+// flush sends the pending indication once the budget allows it
+func (c *budgetedClient) flush(ctx context.Context) {
+	for _, limiter := range []*rate.Limiter{c.limiter, c.budget.limiter} {
+		if limiter == nil {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			c.mu.Lock()
+			c.pending = nil
+			c.mu.Unlock()
+			atomic.AddUint64(&c.budget.dropped, 1)
+			return
+		}
+	}
+	c.mu.Lock()
+	request := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+	if err := c.send(ctx, request); err != nil {
+		log.Warn(err)
+	}
+}
+
+// FlushIndications sends the indications held back by the client it wraps
+func (c *budgetedClient) FlushIndications(ctx context.Context) error {
+	if flusher, ok := c.ClientConn.(indicationFlusher); ok {
+		return flusher.FlushIndications(ctx)
+	}
+	return nil
+}
+
+func (c *budgetedClient) send(ctx context.Context, request *e2appducontents.Ricindication) error {
+	if err := c.ClientConn.RICIndication(ctx, request); err != nil {
+		return err
+	}
+	atomic.AddUint64(&c.budget.sent, 1)
+	return nil
+}
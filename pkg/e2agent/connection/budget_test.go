@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndicationBudget(t *testing.T) {
+	ctx := context.TODO()
+
+	// The indications exceeding the budget of the subscription are dropped
+	budget := NewIndicationBudget(model.IndicationBudget{SubscriptionRate: 1, SubscriptionBurst: 2})
+	client := newBudgetedClient(&testClient{}, budget)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, client.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	}
+	sent, dropped, coalesced := budget.Counters()
+	assert.Equal(t, uint64(2), sent)
+	assert.Equal(t, uint64(3), dropped)
+	assert.Equal(t, uint64(0), coalesced)
+
+	// The budget of the node is shared by its subscriptions
+	budget = NewIndicationBudget(model.IndicationBudget{NodeRate: 1, NodeBurst: 3})
+	first := newBudgetedClient(&testClient{}, budget)
+	second := newBudgetedClient(&testClient{}, budget)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, first.RICIndication(ctx, &e2appducontents.Ricindication{}))
+		assert.NoError(t, second.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	}
+	sent, dropped, _ = budget.Counters()
+	assert.Equal(t, uint64(3), sent)
+	assert.Equal(t, uint64(1), dropped)
+
+	// The indications exceeding the budget are coalesced into the last one, which is sent once the budget allows it
+	budget = NewIndicationBudget(model.IndicationBudget{SubscriptionRate: 20, Overflow: CoalesceOverflow})
+	client = newBudgetedClient(&testClient{}, budget)
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, client.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	}
+	assert.Eventually(t, func() bool {
+		sent, dropped, coalesced = budget.Counters()
+		return sent == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), dropped)
+	assert.Equal(t, uint64(2), coalesced)
+}
@@ -10,9 +10,14 @@ import (
 	"fmt"
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdudecoder"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	asn1libgo "github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/utils"
 	"github.com/onosproject/ran-simulator/pkg/utils/f1ap"
 	"github.com/onosproject/ran-simulator/pkg/utils/xnap"
@@ -23,9 +28,14 @@ import (
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
 
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ccc"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm2"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/llc"
 
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2appdudescriptions "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-descriptions"
+	f1apiesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_ies"
+	xnapiesv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-ies"
 
 	connectionsetupfaileditem "github.com/onosproject/ran-simulator/pkg/utils/e2ap/connectionupdate/connectionSetupFailedItemie"
 
@@ -42,13 +52,16 @@ import (
 	"github.com/cenkalti/backoff"
 
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/mho"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ni"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/rc"
 	rcv1 "github.com/onosproject/ran-simulator/pkg/servicemodel/rc/v1"
 	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/indicationerror"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/configupdate"
 	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/setup"
 
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
@@ -57,6 +70,7 @@ import (
 
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transport"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
 )
 
@@ -66,10 +80,34 @@ var log = logging.GetLogger()
 type E2Connection interface {
 	e2.ClientInterface
 
-	Setup() error
+	Setup(ctx context.Context) error
 
 	Close() error
 
+	Reset(ctx context.Context) error
+
+	Disconnect(ctx context.Context) error
+
+	Reconnect() error
+
+	Blackhole()
+
+	Blackholed() bool
+
+	NewClientConn(conn net.Conn) e2.ClientConn
+
+	RICSubscriptionDeleteRequired(ctx context.Context, ncgi ransimtypes.NCGI) error
+
+	ConfigurationUpdate(ctx context.Context) error
+
+	NGRANNodeConfigurationUpdate(ctx context.Context, ncgi ransimtypes.NCGI) error
+
+	RICServiceUpdate(ctx context.Context, added []registry.ServiceModel, modified []registry.ServiceModel, removed []registry.RanFunctionID) error
+
+	ErrorIndications() (uint64, uint64)
+
+	DeleteSubscriptions(ctx context.Context)
+
 	GetClient() e2.ClientConn
 
 	SetClient(e2.ClientConn)
@@ -78,13 +116,30 @@ type E2Connection interface {
 type e2Connection struct {
 	node            model.Node
 	model           *model.Model
+	controller      string
 	client          e2.ClientConn
+	procedures      *procedureConn
 	registry        *registry.ServiceModelRegistry
 	subStore        *subscriptions.Subscriptions
 	connectionStore connections.Store
 	ricAddress      addressing.RICAddress
 	transactionID   uint64
 	cellStore       cells.Store
+	messageStore    messages.Store
+	nodeStore       nodes.Store
+	metricStore     metrics.Store
+	faultStore      faults.Store
+	budget          *IndicationBudget
+	setupBackOff    *setupBackOff
+	disconnected    int32
+	blackholed      int32
+	// ctx is the context of the agent, which ends the connection and setup attempts of the instance
+	ctx context.Context
+	// errorIndicationsSent and errorIndicationsReceived count the error indications exchanged with the RIC
+	errorIndicationsSent     uint64
+	errorIndicationsReceived uint64
+	// configUpdates sends the configuration updates over the E2 support function TNL associations in turn
+	configUpdates *associations
 }
 
 // SetClient sets E2 client
@@ -107,18 +162,28 @@ func NewE2Connection(opts ...InstanceOption) E2Connection {
 	return &e2Connection{
 		model:           instanceOptions.model,
 		node:            instanceOptions.node,
+		controller:      instanceOptions.controller,
 		registry:        instanceOptions.registry,
 		subStore:        instanceOptions.subStore,
 		ricAddress:      instanceOptions.ricAddress,
 		connectionStore: instanceOptions.connectionStore,
 		client:          instanceOptions.e2Client,
 		cellStore:       instanceOptions.cellStore,
+		messageStore:    instanceOptions.messageStore,
+		nodeStore:       instanceOptions.nodeStore,
+		metricStore:     instanceOptions.metricStore,
+		faultStore:      instanceOptions.faultStore,
+		budget:          instanceOptions.budget,
+		configUpdates:   newAssociations(instanceOptions.e2Client, instanceOptions.connectionStore),
 	}
 
 }
 
 // E2ConnectionUpdate implements E2 connection update procedure
 func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
+	if e.blackhole(ctx) {
+		return nil, nil, errors.NewUnavailable("E2 node %d does not respond", e.node.GnbID)
+	}
 	log.Info("Received Connection Update request %v", request)
 	connectionUpdateItemIes := make([]*e2appducontents.E2ConnectionUpdateItemIes, 0)
 	connectionSetupFailedItemIes := make([]*e2appducontents.E2ConnectionSetupFailedItemIes, 0)
@@ -167,7 +232,6 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 		for _, connectionUpdateItem := range connectionUpdateItems {
 			tnlInfo := connectionUpdateItem.GetValue().GetE2ConnectionUpdateItem().GetTnlInformation()
 			tnlUsage := connectionUpdateItem.GetValue().GetE2ConnectionUpdateItem().GetTnlUsage()
-			// TODO handle tnlUsage
 
 			ricAddress = e.getRICAddress(tnlInfo)
 			log.Debugf("RIC and IP and Port information: %v:%v", ricAddress.IPAddress, ricAddress.Port)
@@ -202,6 +266,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 					Phase: connections.Open,
 					State: connections.Connecting,
 				},
+				Usage: tnlUsage,
 			}
 
 			err = e.connectionStore.Add(ctx, connectionID, connection)
@@ -220,7 +285,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 				connectionupdateitem.WithTnlInfo(tnlInfo),
 				connectionupdateitem.WithTnlUsage(tnlUsage)).
 				BuildConnectionUpdateItemIes()
-			connectionUpdateItemIes = append(connectionUpdateItems, connUpdateItemIe)
+			connectionUpdateItemIes = append(connectionUpdateItemIes, connUpdateItemIe)
 
 		}
 	}
@@ -289,9 +354,56 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 		}
 
 	}
-	// TODO modifying connections
+	// If E2 Connection To Modify List IE is contained in the E2 CONNECTION UPDATE message, then the E2 Node shall,
+	// if supported, use it to modify the usage of the existing TNL Association(s) according to the TNL Association
+	// Usage IE in the message.
 	if ies45 != nil {
-		log.Debug("Modifying connections")
+		log.Debugf("Modifying connections: %+v", ies45.GetValue())
+		for _, connectionUpdateItem := range ies45.GetValue() {
+			tnlInfo := connectionUpdateItem.GetValue().GetE2ConnectionUpdateItem().GetTnlInformation()
+			tnlUsage := connectionUpdateItem.GetValue().GetE2ConnectionUpdateItem().GetTnlUsage()
+			ricAddress = e.getRICAddress(tnlInfo)
+			if ricAddress.IPAddress == nil {
+				cause := &e2apies.Cause{
+					Cause: &e2apies.Cause_Protocol{
+						Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_ABSTRACT_SYNTAX_ERROR_FALSELY_CONSTRUCTED_MESSAGE,
+					},
+				}
+				connectionUpdateFailure := connectionupdate.NewConnectionUpdate(
+					connectionupdate.WithCause(cause),
+					connectionupdate.WithTransactionID(trID)).
+					BuildConnectionUpdateFailure()
+				return nil, connectionUpdateFailure, nil
+			}
+
+			connectionID := connections.NewConnectionID(ricAddress.IPAddress.String(), ricAddress.Port)
+			connection, err := e.connectionStore.Get(ctx, connectionID)
+			if err == nil {
+				connection.Usage = tnlUsage
+				err = e.connectionStore.Update(ctx, connection)
+			}
+			if err != nil {
+				log.Warn(err)
+				// The TNL associations which cannot be modified are reported in the E2 Connection Setup Failed List
+				cause := &e2apies.Cause{
+					Cause: &e2apies.Cause_Transport{
+						Transport: e2apies.CauseTransport_CAUSE_TRANSPORT_UNSPECIFIED,
+					},
+				}
+				connSetupFailedItemIe := connectionsetupfaileditem.NewConnectionSetupFailedItemIe(
+					connectionsetupfaileditem.WithTnlInfo(tnlInfo),
+					connectionsetupfaileditem.WithCause(cause)).
+					BuildConnectionSetupFailedItemIes()
+				connectionSetupFailedItemIes = append(connectionSetupFailedItemIes, connSetupFailedItemIe)
+				continue
+			}
+
+			connUpdateItemIe := connectionupdateitem.NewConnectionUpdateItemIe(
+				connectionupdateitem.WithTnlInfo(tnlInfo),
+				connectionupdateitem.WithTnlUsage(tnlUsage)).
+				BuildConnectionUpdateItemIes()
+			connectionUpdateItemIes = append(connectionUpdateItemIes, connUpdateItemIe)
+		}
 	}
 
 	// After successful update of E2 interface connection(s), the E2 Node shall reply with the E2 CONNECTION UPDATE ACKNOWLEDGE message to inform
@@ -306,24 +418,73 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 }
 
 func (e *e2Connection) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
+	if e.blackhole(ctx) {
+		return nil, nil, errors.NewUnavailable("E2 node %d does not respond", e.node.GnbID)
+	}
+	rrID, err := controlutils.GetRequesterID(request)
+	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICcontrol, protocolErrorCause())
+		return nil, nil, err
+	}
 	rfID, err := controlutils.GetRanFunctionID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICcontrol, protocolErrorCause(),
+			indicationerror.WithRequestID(*rrID))
+		return nil, nil, err
+	}
+	riID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICcontrol, protocolErrorCause(),
+			indicationerror.WithRequestID(*rrID),
+			indicationerror.WithRanFuncID(*rfID))
 		return nil, nil, err
 	}
+	if !e.ricServiceAllowed(ctx) {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICcontrol, incompatibleStateCause(),
+			indicationerror.WithRequestID(*rrID),
+			indicationerror.WithRanFuncID(*rfID),
+			indicationerror.WithRicInstanceID(*riID))
+		return nil, nil, errors.NewForbidden("TNL association %s is not used for RIC services", e.ricAddress.IPAddress)
+	}
 	ranFuncID := registry.RanFunctionID(*rfID)
 
 	log.Debugf("Received Control Request %+v for ran function %d", request, ranFuncID)
 	sm, err := e.registry.GetServiceModel(ranFuncID)
 	if err != nil {
 		log.Warn(err)
-		// TODO If the target E2 Node receives a RIC CONTROL REQUEST message
-		//  which contains a RAN Function ID IE that was not previously announced as a s
+		// If the target E2 Node receives a RIC CONTROL REQUEST message
+		//  which contains a RAN Function ID IE that was not previously announced as a
 		//  supported RAN function in the E2 Setup procedure or the RIC Service Update procedure,
 		//  or the E2 Node does not support the specific RIC Control procedure action, then
 		//  the target E2 Node shall ignore message and send an ERROR INDICATION message to the Near-RT RIC.
-
+		cause := &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_RAN_FUNCTION_ID_INVALID,
+			},
+		}
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICcontrol, cause,
+			indicationerror.WithRequestID(*rrID),
+			indicationerror.WithRanFuncID(*rfID),
+			indicationerror.WithRicInstanceID(*riID))
 		return nil, nil, err
 	}
+	if e.faultStore != nil && injected(e.faultStore.Get(ctx, e.node.GnbID).ControlFailureRate) {
+		e.faultStore.Inject(ctx, e.node.GnbID, faults.FailedControl)
+		cause := &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE,
+			},
+		}
+		failure, err := controlutils.NewControl(
+			controlutils.WithRequestID(*rrID),
+			controlutils.WithRanFuncID(*rfID),
+			controlutils.WithRicInstanceID(*riID),
+			controlutils.WithCause(cause)).BuildControlFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
+	}
 	switch sm.RanFunctionID {
 	case registry.Rcpre2:
 		client := sm.Client.(*rc.Client)
@@ -334,6 +495,12 @@ func (e *e2Connection) RICControl(ctx context.Context, request *e2appducontents.
 	case registry.Rc:
 		client := sm.Client.(*rcv1.Client)
 		response, failure, err = client.RICControl(ctx, request)
+	case registry.Ccc:
+		client := sm.Client.(*ccc.Client)
+		response, failure, err = client.RICControl(ctx, request)
+	case registry.Llc:
+		client := sm.Client.(*llc.Client)
+		response, failure, err = client.RICControl(ctx, request)
 	}
 	if err != nil {
 		return nil, nil, err
@@ -343,39 +510,39 @@ func (e *e2Connection) RICControl(ctx context.Context, request *e2appducontents.
 }
 
 func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
-	rfID, err := subutils.GetRanFunctionID(request)
-	if err != nil {
-		return nil, nil, err
-	}
-	registeredRanFuncID := registry.RanFunctionID(*rfID)
-	log.Debugf("Received Subscription Request %v for ran function %d", request, registeredRanFuncID)
-	sm, err := e.registry.GetServiceModel(registeredRanFuncID)
-	if err != nil {
-		return nil, nil, err
+	if e.blackhole(ctx) {
+		return nil, nil, errors.NewUnavailable("E2 node %d does not respond", e.node.GnbID)
 	}
-	rrID, err := subutils.GetRequesterID(request)
-	if err != nil {
-		return nil, nil, err
-	}
-	riID, err := subutils.GetRicInstanceID(request)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	id := subscriptions.NewID(*riID, *rrID, *rfID)
-
 	reqID, err := subutils.GetRequesterID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscription, protocolErrorCause())
 		return nil, nil, err
 	}
 	ranFuncID, err := subutils.GetRanFunctionID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscription, protocolErrorCause(),
+			indicationerror.WithRequestID(*reqID))
 		return nil, nil, err
 	}
 	ricInstanceID, err := subutils.GetRicInstanceID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscription, protocolErrorCause(),
+			indicationerror.WithRequestID(*reqID),
+			indicationerror.WithRanFuncID(*ranFuncID))
 		return nil, nil, err
 	}
+	if !e.ricServiceAllowed(ctx) {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscription, incompatibleStateCause(),
+			indicationerror.WithRequestID(*reqID),
+			indicationerror.WithRanFuncID(*ranFuncID),
+			indicationerror.WithRicInstanceID(*ricInstanceID))
+		return nil, nil, errors.NewForbidden("TNL association %s is not used for RIC services", e.ricAddress.IPAddress)
+	}
+	registeredRanFuncID := registry.RanFunctionID(*ranFuncID)
+	log.Debugf("Received Subscription Request %v for ran function %d", request, registeredRanFuncID)
+
+	id := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	sm, err := e.registry.GetServiceModel(registeredRanFuncID)
 	if err != nil {
 		log.Warn(err)
 		// If the target E2 Node receives a RIC SUBSCRIPTION REQUEST
@@ -400,7 +567,32 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 		}
 		return nil, failure, nil
 	}
-	subscription, err := subscriptions.NewSubscription(id, request, e.client)
+	if e.faultStore != nil {
+		if name := e.faultStore.Get(ctx, e.node.GnbID).SubscriptionRejectCause; name != "" {
+			cause, ok := causeFromName(name)
+			if !ok {
+				log.Warnf("Unknown cause %s of the subscription rejection fault", name)
+				cause = &e2apies.Cause{
+					Cause: &e2apies.Cause_Misc{
+						Misc: e2apies.CauseMisc_CAUSE_MISC_UNSPECIFIED,
+					},
+				}
+			}
+			e.faultStore.Inject(ctx, e.node.GnbID, faults.RejectedSubscription)
+			subscription := subutils.NewSubscription(
+				subutils.WithRequestID(*reqID),
+				subutils.WithRanFuncID(*ranFuncID),
+				subutils.WithRicInstanceID(*ricInstanceID),
+				subutils.WithCause(cause))
+			failure, err := subscription.BuildSubscriptionFailure()
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, failure, nil
+		}
+	}
+	client := newBudgetedClient(newFaultInjector(newAssociations(e.client, e.connectionStore), e.faultStore, e.node.GnbID), e.budget)
+	subscription, err := subscriptions.NewSubscription(id, request, client)
 	if err != nil {
 		log.Warn(err)
 		cause := &e2apies.Cause{
@@ -454,7 +646,15 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 	case registry.Rc:
 		client := sm.Client.(*rcv1.Client)
 		response, failure, err = client.RICSubscription(ctx, request)
-
+	case registry.Ccc:
+		client := sm.Client.(*ccc.Client)
+		response, failure, err = client.RICSubscription(ctx, request)
+	case registry.Ni:
+		client := sm.Client.(*ni.Client)
+		response, failure, err = client.RICSubscription(ctx, request)
+	case registry.Llc:
+		client := sm.Client.(*llc.Client)
+		response, failure, err = client.RICSubscription(ctx, request)
 	}
 	// Ric subscription is failed
 	if err != nil {
@@ -480,6 +680,14 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 }
 
 func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	if e.blackhole(ctx) {
+		return nil, nil, errors.NewUnavailable("E2 node %d does not respond", e.node.GnbID)
+	}
+	return e.ricSubscriptionDelete(ctx, request)
+}
+
+// ricSubscriptionDelete deletes the subscription of the request, also on behalf of the node
+func (e *e2Connection) ricSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
 	var ranFunctionID int32
 	for _, v := range request.GetProtocolIes() {
 		if v.Id == int32(v2.ProtocolIeIDRanfunctionID) {
@@ -493,19 +701,33 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 
 	rrID, err := subdeleteutils.GetRequesterID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscriptionDelete, protocolErrorCause(),
+			indicationerror.WithRanFuncID(ranFunctionID))
 		return nil, nil, err
 	}
 	rfID, err := subdeleteutils.GetRanFunctionID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscriptionDelete, protocolErrorCause(),
+			indicationerror.WithRequestID(*rrID))
 		return nil, nil, err
 	}
 	riID, err := subdeleteutils.GetRicInstanceID(request)
 	if err != nil {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscriptionDelete, protocolErrorCause(),
+			indicationerror.WithRequestID(*rrID),
+			indicationerror.WithRanFuncID(*rfID))
 		return nil, nil, err
 	}
 
+	if !e.ricServiceAllowed(ctx) {
+		e.errorIndication(ctx, v2.ProcedureCodeIDRICsubscriptionDelete, incompatibleStateCause(),
+			indicationerror.WithRequestID(*rrID),
+			indicationerror.WithRanFuncID(*rfID),
+			indicationerror.WithRicInstanceID(*riID))
+		return nil, nil, errors.NewForbidden("TNL association %s is not used for RIC services", e.ricAddress.IPAddress)
+	}
 	subID := subscriptions.NewID(*riID, *rrID, *rfID)
-	_, err = e.subStore.Get(subID)
+	sub, err := e.subStore.Get(subID)
 	if err != nil {
 		log.Warn(err)
 		//  If the target E2 Node receives a RIC SUBSCRIPTION DELETE REQUEST
@@ -514,23 +736,9 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 		//  to the Near-RT RIC. The message shall contain the Cause IE with an appropriate value.
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
-				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_REQUEST_ID_UNKNOWN,
 			},
 		}
-
-		rrID, err := subdeleteutils.GetRequesterID(request)
-		if err != nil {
-			return nil, nil, err
-		}
-		rfID, err := subdeleteutils.GetRanFunctionID(request)
-		if err != nil {
-			return nil, nil, err
-		}
-		riID, err := subdeleteutils.GetRicInstanceID(request)
-		if err != nil {
-			return nil, nil, err
-		}
-
 		subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
 			subdeleteutils.WithRanFuncID(*rfID),
 			subdeleteutils.WithRequestID(*rrID),
@@ -582,6 +790,9 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 		return nil, failure, nil
 	}
 
+	// The indications held back are sent before the subscription is deleted
+	flushIndications(ctx, sub.E2Channel)
+
 	switch sm.RanFunctionID {
 	case registry.Rcpre2:
 		client := sm.Client.(*rc.Client)
@@ -595,7 +806,15 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 	case registry.Rc:
 		client := sm.Client.(*rcv1.Client)
 		response, failure, err = client.RICSubscriptionDelete(ctx, request)
-
+	case registry.Ccc:
+		client := sm.Client.(*ccc.Client)
+		response, failure, err = client.RICSubscriptionDelete(ctx, request)
+	case registry.Ni:
+		client := sm.Client.(*ni.Client)
+		response, failure, err = client.RICSubscriptionDelete(ctx, request)
+	case registry.Llc:
+		client := sm.Client.(*llc.Client)
+		response, failure, err = client.RICSubscriptionDelete(ctx, request)
 	}
 	// Ric subscription delete procedure is failed so we are not going to update subscriptions store
 	if err != nil {
@@ -611,46 +830,353 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 	return response, failure, err
 }
 
-func (e *e2Connection) connectAndSetup() error {
+// Disconnect drops the TNL associations of the E2 interface instance; the instance does not reconnect until
+// Reconnect is called
+func (e *e2Connection) Disconnect(ctx context.Context) error {
+	log.Infof("Disconnecting E2 node %d from controller %s", e.node.GnbID, e.controller)
+	atomic.StoreInt32(&e.disconnected, 1)
+	e.setConnectionState(DisconnectedPhase, "")
+	for _, conn := range e.connectionStore.List(ctx) {
+		if conn.Client == nil {
+			continue
+		}
+		if err := conn.Client.Close(); err != nil {
+			log.Warn(err)
+		}
+		if err := e.connectionStore.Remove(ctx, conn.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconnect re-establishes the E2 interface instance with a new E2 setup and stops blackholing the requests
+func (e *e2Connection) Reconnect() error {
+	log.Infof("Reconnecting E2 node %d to controller %s", e.node.GnbID, e.controller)
+	atomic.StoreInt32(&e.blackholed, 0)
+	if atomic.CompareAndSwapInt32(&e.disconnected, 1, 0) {
+		go func() {
+			if err := e.Setup(e.ctx); err != nil {
+				log.Warnf("E2 node %d failed to reconnect to controller %s: %v", e.node.GnbID, e.controller, err)
+			}
+		}()
+		return nil
+	}
+	// Closing the connection makes the instance reconnect
+	return e.Close()
+}
+
+// Blackhole makes the E2 interface instance accept the requests of the RIC without ever responding
+func (e *e2Connection) Blackhole() {
+	log.Infof("Blackholing the requests of controller %s to E2 node %d", e.controller, e.node.GnbID)
+	atomic.StoreInt32(&e.blackholed, 1)
+}
+
+// Blackholed returns whether the requests of the RIC are blackholed
+func (e *e2Connection) Blackholed() bool {
+	return atomic.LoadInt32(&e.blackholed) == 1
+}
+
+// blackhole holds a blackholed request until the connection is closed; it returns false if the
+// requests are not blackholed
+func (e *e2Connection) blackhole(ctx context.Context) bool {
+	if !e.Blackholed() {
+		return false
+	}
+	log.Debugf("E2 node %d does not respond to the blackholed request", e.node.GnbID)
+	select {
+	case <-ctx.Done():
+	case <-e.client.Context().Done():
+	}
+	return true
+}
+
+// Reset resets the E2 node: all the subscriptions are deleted, stopping their reports, and the RIC is requested
+// to release its resources of the node with the RESET procedure
+func (e *e2Connection) Reset(ctx context.Context) error {
+	log.Infof("Resetting E2 node %d", e.node.GnbID)
+	if e.procedures == nil {
+		return errors.NewUnavailable("E2 node %d is not connected to controller %s", e.node.GnbID, e.controller)
+	}
+	e.DeleteSubscriptions(ctx)
+	transactionID := int32(atomic.AddUint64(&e.transactionID, 1) % 255)
+	request, err := pdubuilder.CreateResetRequestE2apPdu(transactionID, omInterventionCause())
+	if err != nil {
+		return err
+	}
+	response, err := e.procedures.request(ctx, v2.ProcedureCodeIDReset, transactionID, request)
+	if err != nil {
+		return err
+	}
+	log.Infof("Reset response is received: %+v", response)
+	return nil
+}
+
+// ResetRequest handles the RESET REQUEST of the RIC: all the subscriptions are deleted, stopping their reports,
+// and the reset is acknowledged with a RESET RESPONSE on the TNL association of the request
+func (e *e2Connection) ResetRequest(ctx context.Context, conn *procedureConn, request *e2appdudescriptions.E2ApPdu) {
+	cause, transactionID, err := pdudecoder.DecodeResetRequestPdu(request)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	log.Infof("E2 node %d is reset by controller %s: %v", e.node.GnbID, e.controller, cause)
+	e.DeleteSubscriptions(ctx)
+	response, err := pdubuilder.CreateResetResponseE2apPdu(*transactionID)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	if err := conn.send(response); err != nil {
+		log.Warn(err)
+	}
+}
+
+// DeleteSubscriptions deletes all the subscriptions of the node through the subscription delete procedure of
+// their service models
+func (e *e2Connection) DeleteSubscriptions(ctx context.Context) {
+	subs, err := e.subStore.List()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, sub := range subs {
+		e.deleteSubscription(ctx, sub)
+	}
+}
+
+func protocolErrorCause() *e2apies.Cause {
+	return &e2apies.Cause{
+		Cause: &e2apies.Cause_Protocol{
+			Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_ABSTRACT_SYNTAX_ERROR_FALSELY_CONSTRUCTED_MESSAGE,
+		},
+	}
+}
+
+func omInterventionCause() *e2apies.Cause {
+	return &e2apies.Cause{
+		Cause: &e2apies.Cause_Misc{
+			Misc: e2apies.CauseMisc_CAUSE_MISC_OM_INTERVENTION,
+		},
+	}
+}
+
+func incompatibleStateCause() *e2apies.Cause {
+	return &e2apies.Cause{
+		Cause: &e2apies.Cause_Protocol{
+			Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_MESSAGE_NOT_COMPATIBLE_WITH_RECEIVER_STATE,
+		},
+	}
+}
+
+// ricServiceAllowed returns whether the TNL association of the connection may carry RIC services
+func (e *e2Connection) ricServiceAllowed(ctx context.Context) bool {
+	if e.connectionStore == nil || e.ricAddress.IPAddress == nil {
+		return true
+	}
+	connectionID := connections.NewConnectionID(e.ricAddress.IPAddress.String(), e.ricAddress.Port)
+	connection, err := e.connectionStore.Get(ctx, connectionID)
+	if err != nil {
+		return true
+	}
+	return allowsUsage(connection.Usage, e2apies.Tnlusage_TNLUSAGE_RIC_SERVICE)
+}
+
+// errorIndication sends an ERROR INDICATION for the given procedure to the RIC and counts it
+func (e *e2Connection) errorIndication(ctx context.Context, procedureCode v2.ProcedureCodeT, cause *e2apies.Cause, options ...func(*indicationerror.ErrorIndication)) {
+	options = append(options,
+		indicationerror.WithCause(cause),
+		indicationerror.WithFailureProcCode(int32(procedureCode)),
+		indicationerror.WithFailureCriticality(e2apcommondatatypes.Criticality_CRITICALITY_REJECT),
+		indicationerror.WithFailureTriggeringMessage(e2apcommondatatypes.TriggeringMessage_TRIGGERING_MESSAGE_INITIATING_MESSAGE))
+	errorIndication, err := indicationerror.NewErrorIndication(options...).BuildPdu()
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	log.Warnf("E2 node %d is sending error indication for procedure %d to controller %s: %v", e.node.GnbID, procedureCode, e.controller, errorIndication)
+	if e.procedures == nil {
+		log.Warnf("E2 node %d is not connected to controller %s", e.node.GnbID, e.controller)
+		return
+	}
+	if err := e.procedures.send(errorIndication); err != nil {
+		log.Warn(err)
+		return
+	}
+	atomic.AddUint64(&e.errorIndicationsSent, 1)
+}
+
+// ErrorIndication handles an ERROR INDICATION of the RIC, which is logged and counted
+func (e *e2Connection) ErrorIndication(ctx context.Context, indication *e2appdudescriptions.E2ApPdu) {
+	atomic.AddUint64(&e.errorIndicationsReceived, 1)
+	_, cause, ranFunctionID, ricRequestID, procedureCode, _, _, _, _, err := pdudecoder.DecodeErrorIndicationPdu(indication)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	log.Warnf("E2 node %d received error indication from controller %s: cause %v, procedure %v, RAN function %v, request %v",
+		e.node.GnbID, e.controller, cause, procedureCode, ranFunctionID, ricRequestID)
+}
+
+// ErrorIndications returns the number of error indications sent to and received from the RIC
+func (e *e2Connection) ErrorIndications() (uint64, uint64) {
+	return atomic.LoadUint64(&e.errorIndicationsSent), atomic.LoadUint64(&e.errorIndicationsReceived)
+}
+
+// RICSubscriptionDeleteRequired requires the RIC to delete the subscriptions which can no longer be served once the
+// cell is deleted, leaving alone the ones reporting on other cells; the RIC deletes them with the RIC subscription
+// delete procedure
+func (e *e2Connection) RICSubscriptionDeleteRequired(ctx context.Context, ncgi ransimtypes.NCGI) error {
+	if e.procedures == nil {
+		return errors.NewUnavailable("E2 node %d is not connected to controller %s", e.node.GnbID, e.controller)
+	}
+	subs, err := e.subStore.List()
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		sm, err := e.registry.GetServiceModel(registry.RanFunctionID(sub.FnID.GetValue()))
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		if !sm.SubscriptionDeleteRequired(sub, ncgi) {
+			continue
+		}
+		ricRequest := types.RicRequest{
+			RequestorID: types.RicRequestorID(sub.ReqID.GetRicRequestorId()),
+			InstanceID:  types.RicInstanceID(sub.ReqID.GetRicInstanceId()),
+		}
+		// The cell has been deleted by the operator
+		cause := omInterventionCause()
+		deleteRequired, err := pdubuilder.CreateRicSubscriptionDeleteRequiredE2apPdu(types.RicSubscriptionWithCauseList{
+			types.RanFunctionID(sub.FnID.GetValue()): {RicRequestID: ricRequest, Cause: cause},
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("E2 node %d requires the deletion of subscription %s after the deletion of cell %v: %v",
+			e.node.GnbID, sub.ID, ncgi, deleteRequired)
+		if err := e.procedures.send(deleteRequired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteSubscription deletes the subscription through the subscription delete procedure of its service model
+func (e *e2Connection) deleteSubscription(ctx context.Context, sub *subscriptions.Subscription) {
+	ricRequest := types.RicRequest{
+		RequestorID: types.RicRequestorID(sub.ReqID.GetRicRequestorId()),
+		InstanceID:  types.RicInstanceID(sub.ReqID.GetRicInstanceId()),
+	}
+	request, err := pdubuilder.NewRicSubscriptionDeleteRequest(ricRequest, types.RanFunctionID(sub.FnID.GetValue()))
+	if err == nil {
+		_, _, err = e.ricSubscriptionDelete(ctx, request)
+	}
+	if err != nil {
+		log.Warnf("Failed to delete subscription %s of E2 node %d: %v", sub.ID, e.node.GnbID, err)
+		// The subscription is removed anyway so that its reports are not sent anymore
+		if err := e.subStore.Remove(sub.ID); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// Connection phases recorded on the node
+const (
+	ConnectingPhase   = "Connecting"
+	SettingUpPhase    = "SettingUp"
+	SetupFailedPhase  = "SetupFailed"
+	ConnectedPhase    = "Connected"
+	FailedPhase       = "Failed"
+	DisconnectedPhase = "Disconnected"
+)
+
+// setConnectionState records the connection phase and the last setup failure cause of the connection to the
+// controller on the node and in its metrics
+func (e *e2Connection) setConnectionState(phase string, failureCause string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if e.nodeStore != nil {
+		if err := e.nodeStore.SetConnectionState(ctx, e.node.GnbID, e.controller, phase, failureCause); err != nil {
+			log.Warn(err)
+		}
+	}
+	if e.metricStore != nil {
+		if err := e.metricStore.Set(ctx, uint64(e.node.GnbID), "e2_connection_phase_"+e.controller, phase); err != nil {
+			log.Warn(err)
+		}
+		if failureCause != "" {
+			if err := e.metricStore.Set(ctx, uint64(e.node.GnbID), "e2_setup_failure_cause_"+e.controller, failureCause); err != nil {
+				log.Warn(err)
+			}
+		}
+	}
+}
+
+// connectAndSetup connects to the controller and runs the E2 setup procedure, both attempted following the retry
+// policy of the node until the context is done
+func (e *e2Connection) connectAndSetup(ctx context.Context) error {
 	log.Infof("E2 node %d is starting; attempting to connect", e.node.GnbID)
-	b := newExpBackoff()
+	e.setConnectionState(ConnectingPhase, "")
+	b := backoff.WithContext(newRetryBackOff(e.node.SetupRetry), ctx)
 
 	// Attempt to connect to the E2T controller; use exponential back-off retry
 	count := 0
 	connectNotify := func(err error, t time.Duration) {
 		count++
-		log.Infof("E2 node %d failed to connect; retry after %v; attempt %d", e.node.GnbID, b.GetElapsedTime(), count)
+		log.Infof("E2 node %d failed to connect; retry after %v; attempt %d", e.node.GnbID, t, count)
 	}
 
 	err := backoff.RetryNotify(e.connect, b, connectNotify)
 	if err != nil {
+		e.setConnectionState(FailedPhase, err.Error())
 		return err
 	}
 	log.Infof("E2 node %d connected; attempting setup", e.node.GnbID)
+	e.setConnectionState(SettingUpPhase, "")
 
-	// Attempt to negotiate E2 setup procedure; use exponential back-off retry
+	// Attempt to negotiate E2 setup procedure; use exponential back-off retry following the retry policy
+	// of the node and the time to wait requested by the RIC
 	count = 0
 	setupNotify := func(err error, t time.Duration) {
 		count++
-		log.Infof("E2 node %d failed setup procedure; retry after %v; attempt %d: %+v", e.node.GnbID, b.GetElapsedTime(), count, err)
+		log.Infof("E2 node %d failed setup procedure; retry after %v; attempt %d: %+v", e.node.GnbID, t, count, err)
 	}
 
-	err = backoff.RetryNotify(e.setup, b, setupNotify)
-	log.Infof("E2 node %d completed connection setup: %+v", e.node.GnbID, err)
-	return err
-
+	e.setupBackOff = newSetupBackOff(e.node.SetupRetry)
+	err = backoff.RetryNotify(e.setup, backoff.WithContext(e.setupBackOff, ctx), setupNotify)
+	if err != nil {
+		log.Warnf("E2 node %d gave up the setup procedure: %+v", e.node.GnbID, err)
+		e.setConnectionState(FailedPhase, "")
+		if closeErr := e.client.Close(); closeErr != nil {
+			log.Warn(closeErr)
+		}
+		return err
+	}
+	log.Infof("E2 node %d completed connection setup", e.node.GnbID)
+	e.setConnectionState(ConnectedPhase, "")
+	return nil
 }
 
-func (e *e2Connection) Setup() error {
-	err := e.connectAndSetup()
+// Setup connects to the controller and runs the E2 setup procedure; the connection is re-established whenever it
+// is lost until the context of the agent is done
+func (e *e2Connection) Setup(ctx context.Context) error {
+	e.ctx = ctx
+	err := e.connectAndSetup(ctx)
 	if err != nil {
 		return err
 	}
 
 	go func() {
 		<-e.client.Context().Done()
-		log.Warn("Context is cancelled, reconnecting...")
-		controller, err := e.model.GetController(e.node.Controllers[0])
+		if atomic.LoadInt32(&e.disconnected) == 1 {
+			log.Infof("E2 node %d is disconnected from controller %s", e.node.GnbID, e.controller)
+			return
+		}
+		log.Warnf("Context is cancelled, reconnecting to controller %s...", e.controller)
+		controller, err := e.model.GetController(e.controller)
 		if err != nil {
 			return
 		}
@@ -665,7 +1191,7 @@ func (e *e2Connection) Setup() error {
 			Port:      uint64(controller.Port),
 		}
 		e.ricAddress = ricAddress
-		err = e.Setup()
+		err = e.Setup(ctx)
 		if err != nil {
 			return
 		}
@@ -675,25 +1201,47 @@ func (e *e2Connection) Setup() error {
 	return err
 }
 
+// connect connects to the E2T over the transport of the controller
 func (e *e2Connection) connect() error {
+	controller, err := e.model.GetController(e.controller)
+	if err != nil {
+		return err
+	}
+	t, err := transport.NewTransport(controller)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
 	addr := fmt.Sprintf("%s:%d", e.ricAddress.IPAddress.String(), e.ricAddress.Port)
-	log.Info("Connecting to E2T with IP address:", addr)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	client, err := e2.Connect(ctx, addr,
-		func(channel e2.ClientConn) e2.ClientInterface {
-			return e
-		},
-	)
-
+	log.Infof("Connecting to E2T with IP address %s over %s", addr, transportName(controller))
+	// The attempt is aborted when the agent is stopped
+	conn, err := t.Dial(e.ctx, e.ricAddress)
 	if err != nil {
 		return err
 	}
 
-	e.client = client
+	e.procedures = newProcedureConn(conn, e, e.faultStore, e.node.GnbID)
+	e.client = e2.NewClientConn(e.procedures, func(channel e2.ClientConn) e2.ClientInterface {
+		return e
+	})
 	return nil
 }
 
+// NewClientConn opens an E2AP client connection of the instance over an additional TNL association
+func (e *e2Connection) NewClientConn(conn net.Conn) e2.ClientConn {
+	return e2.NewClientConn(newProcedureConn(conn, e, e.faultStore, e.node.GnbID), func(channel e2.ClientConn) e2.ClientInterface {
+		return e
+	})
+}
+
+// transportName returns the name of the transport of the E2 interface with the controller
+func transportName(controller model.Controller) string {
+	if controller.Transport == "" {
+		return transport.SCTP
+	}
+	return controller.Transport
+}
+
+// The defaults of the F1 and Xn setup content which is not modeled by the node and its cells
 var (
 	defaultGnBDUID                         = int64(21)
 	defaultRRCVerBytes                     = []byte{0xE0}
@@ -706,21 +1254,346 @@ var (
 	defaultTacBytes                        = []byte{0x01, 0x01, 0x01}
 	defaultSD                              = []byte{0x01, 0x23, 0x45}
 	defaultSST                             = []byte{0x01}
-	defaultAMFRegionValue                  = []byte{0xdd} // todo need to be changed
+	defaultAMFRegionID                     = uint8(0xdd)
 	defaultAMFRegionLen                    = uint32(8)
 )
 
-func (e *e2Connection) setup() error {
+// gnbDUID returns the gNB-DU ID of the node
+func (e *e2Connection) gnbDUID() int64 {
+	if e.node.GnbDUID != 0 {
+		return int64(e.node.GnbDUID)
+	}
+	return defaultGnBDUID
+}
+
+// tacBytes returns the tracking area code of the cell, defaulting to the one of the node
+func (e *e2Connection) tacBytes(cell *model.Cell) []byte {
+	tac := e.node.TAC
+	if cell != nil && cell.TAC != 0 {
+		tac = cell.TAC
+	}
+	if tac == 0 {
+		return defaultTacBytes
+	}
+	return ransimtypes.NewUint24(tac).ToBytes()
+}
+
+// servedPlmns returns the PLMNs and slices served by the cell, defaulting to the ones of the node
+func (e *e2Connection) servedPlmns(cell *model.Cell) []model.ServedPlmn {
+	if cell != nil && len(cell.ServedPlmns) > 0 {
+		return cell.ServedPlmns
+	}
+	return e.node.ServedPlmns
+}
+
+// f1ServedPlmns returns the served PLMNs of the F1 setup request of the cell
+func (e *e2Connection) f1ServedPlmns(cell *model.Cell) []f1ap.ServedPlmnInfo {
+	servedPlmns := make([]f1ap.ServedPlmnInfo, 0)
+	for _, servedPlmn := range e.servedPlmns(cell) {
+		slices := make([]f1ap.SliceInfo, 0, len(servedPlmn.Slices))
+		for _, slice := range servedPlmn.Slices {
+			slices = append(slices, f1ap.SliceInfo{
+				Sst: []byte{byte(slice.Sst)},
+				Sd:  sliceDifferentiator(slice),
+			})
+		}
+		servedPlmns = append(servedPlmns, f1ap.ServedPlmnInfo{
+			PlmnIDBytes: ransimtypes.NewUint24(uint32(servedPlmn.PlmnID())).ToBytes(),
+			Slices:      slices,
+		})
+	}
+	return servedPlmns
+}
+
+// xnSlices returns the slices supported by the node in its Xn setup request
+func (e *e2Connection) xnSlices() []xnap.XnItemSlice {
+	slices := make([]xnap.XnItemSlice, 0)
+	for _, servedPlmn := range e.node.ServedPlmns {
+		for _, slice := range servedPlmn.Slices {
+			slices = append(slices, xnap.XnItemSlice{
+				Sst: []byte{byte(slice.Sst)},
+				Sd:  sliceDifferentiator(slice),
+			})
+		}
+	}
+	if len(slices) == 0 {
+		return []xnap.XnItemSlice{{Sst: defaultSST, Sd: defaultSD}}
+	}
+	return slices
+}
+
+// sliceDifferentiator returns the encoded slice differentiator of the slice, if any
+func sliceDifferentiator(slice model.Slice) []byte {
+	if slice.Sd == 0 {
+		return nil
+	}
+	return ransimtypes.NewUint24(slice.Sd).ToBytes()
+}
+
+// frequencyBands returns the NR operating band and supplementary uplink band of the cell
+func frequencyBands(cell *model.Cell) (int32, int32) {
+	band, sulBand := defaultFreqBandIndicatorNr, defaultSulFreqBandIndicationNr
+	if cell.FrequencyBand != 0 {
+		band = int32(cell.FrequencyBand)
+	}
+	if cell.SulFrequencyBand != 0 {
+		sulBand = int32(cell.SulFrequencyBand)
+	}
+	return band, sulBand
+}
+
+// transmissionBandwidth returns the NR transmission bandwidth of the cell
+func transmissionBandwidth(cell *model.Cell) (f1apiesv1.Nrscs, f1apiesv1.Nrnrb) {
+	if cell.SubcarrierSpacing != 0 {
+		nrscs, nrnrb, err := f1ap.TransmissionBandwidth(cell.SubcarrierSpacing, cell.Bandwidth)
+		if err == nil {
+			return nrscs, nrnrb
+		}
+		log.Warnf("Cell %v transmission bandwidth is not valid: %v", cell.NCGI, err)
+	}
+	return f1apiesv1.Nrscs_NRSCS_SCS120, f1apiesv1.Nrnrb_NRNRB_NRB11
+}
+
+// xnTransmissionBandwidth returns the NR transmission bandwidth of the cell in its Xn served cell information
+func xnTransmissionBandwidth(cell *model.Cell) (xnapiesv1.Nrscs, xnapiesv1.Nrnrb) {
+	nrscs, nrnrb := transmissionBandwidth(cell)
+	return xnapiesv1.Nrscs(xnapiesv1.Nrscs_value[nrscs.String()]), xnapiesv1.Nrnrb(xnapiesv1.Nrnrb_value[nrnrb.String()])
+}
+
+// amfRegion returns the AMF region of the node in its Xn setup
+func (e *e2Connection) amfRegion() xnap.XnItemAMFRegion {
+	amfRegionID := e.node.AmfRegionID
+	if amfRegionID == 0 {
+		amfRegionID = defaultAMFRegionID
+	}
+	return xnap.XnItemAMFRegion{AmfRegionID: []byte{amfRegionID}, AmfRegionIDLen: defaultAMFRegionLen}
+}
+
+// neighborTacBytes returns the tracking area code of a neighbour cell, defaulting to the one of the node
+// owning the cell
+func (e *e2Connection) neighborTacBytes(ctx context.Context, cell *model.Cell) []byte {
+	if cell.TAC != 0 {
+		return ransimtypes.NewUint24(cell.TAC).ToBytes()
+	}
+	if e.nodeStore != nil {
+		node, err := e.nodeStore.Get(ctx, ransimtypes.GetGnbID(uint64(cell.NCGI)))
+		if err == nil && node.TAC != 0 {
+			return ransimtypes.NewUint24(node.TAC).ToBytes()
+		}
+	}
+	return nil
+}
+
+// f1SetupResponse returns the F1 setup response of the gNB-CU of the node
+func (e *e2Connection) f1SetupResponse() ([]byte, error) {
+	return f1ap.CreateF1SetupResponse(1, fmt.Sprintf("gnb-cu-%x", uint64(e.node.GnbID)), defaultRRCVerBytes, defaultRRCVerLen)
+}
+
+// recordSetupMessage records an interface setup message the node sends as part of the E2 setup
+func (e *e2Connection) recordSetupMessage(iface messages.Interface, procedure string, payload []byte) {
+	if e.messageStore == nil {
+		return
+	}
+	e.messageStore.Add(context.Background(), &messages.Message{
+		GnbID:     e.node.GnbID,
+		Interface: iface,
+		Direction: messages.Outgoing,
+		Procedure: procedure,
+		Payload:   payload,
+	})
+}
+
+// ConfigurationUpdate sends an E2 node configuration update with the F1 and Xn components of the node
+// regenerated from its current cells
+func (e *e2Connection) ConfigurationUpdate(ctx context.Context) error {
+	f1SetupRequestBytes, xnSetupRequestBytes, xnSetupResponseBytes, err := e.componentSetupMessages(ctx)
+	if err != nil {
+		return err
+	}
+	f1SetupResponseBytes, err := e.f1SetupResponse()
+	if err != nil {
+		return err
+	}
+	configUpdateItems := []*types.E2NodeComponentConfigUpdateItem{
+		{
+			E2NodeComponentType: e2apies.E2NodeComponentInterfaceType_E2NODE_COMPONENT_INTERFACE_TYPE_F1,
+			E2NodeComponentID:   e.f1ComponentID(),
+			E2NodeComponentConfiguration: e2apies.E2NodeComponentConfiguration{
+				E2NodeComponentRequestPart:  f1SetupRequestBytes,
+				E2NodeComponentResponsePart: f1SetupResponseBytes,
+			},
+		},
+		{
+			E2NodeComponentType: e2apies.E2NodeComponentInterfaceType_E2NODE_COMPONENT_INTERFACE_TYPE_XN,
+			E2NodeComponentID:   e.xnComponentID(),
+			E2NodeComponentConfiguration: e2apies.E2NodeComponentConfiguration{
+				E2NodeComponentRequestPart:  xnSetupRequestBytes,
+				E2NodeComponentResponsePart: xnSetupResponseBytes,
+			},
+		},
+	}
+
 	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
+	transactionID := atomic.AddUint64(&e.transactionID, 1) % 255
+	configUpdate, err := configupdate.NewConfigurationUpdate(
+		configupdate.WithTransactionID(int32(transactionID)),
+		configupdate.WithE2NodeID(uint64(e.node.GnbID)),
+		configupdate.WithPlmnID(plmnID.Value()),
+		configupdate.WithComponentConfigUpdates(configUpdateItems)).
+		Build()
+	if err != nil {
+		return err
+	}
+	log.Infof("E2 node %d is sending configuration update: %+v", e.node.GnbID, configUpdate)
+	configUpdateAck, configUpdateFailure, err := e.configUpdates.E2ConfigurationUpdate(ctx, configUpdate)
+	if err != nil {
+		return err
+	}
+	if configUpdateFailure != nil {
+		return errors.NewUnknown("E2 node %d configuration update failed: %+v", e.node.GnbID, configUpdateFailure)
+	}
+	log.Infof("Config update ack is received:%+v", configUpdateAck)
+	return nil
+}
 
-	configAdditionList := &e2appducontents.E2NodeComponentConfigAdditionList{
-		Value: make([]*e2appducontents.E2NodeComponentConfigAdditionItemIes, 0),
+// RICServiceUpdate registers the added service models, replaces the RAN function definitions of the modified ones
+// and unregisters the removed ones, whose subscriptions are deleted, and announces the changes of the RAN functions
+// of the node to the RIC with a RIC service update
+func (e *e2Connection) RICServiceUpdate(ctx context.Context, added []registry.ServiceModel, modified []registry.ServiceModel, removed []registry.RanFunctionID) error {
+	if e.procedures == nil {
+		return errors.NewUnavailable("E2 node %d is not connected to controller %s", e.node.GnbID, e.controller)
+	}
+	ranFunctionsAdded := make(types.RanFunctions)
+	for _, sm := range added {
+		ranFunctionsAdded[types.RanFunctionID(sm.RanFunctionID)] = types.RanFunctionItem{
+			Description: sm.Description,
+			Revision:    types.RanFunctionRevision(sm.Revision),
+			OID:         types.RanFunctionOID(sm.OID),
+		}
+	}
+	ranFunctionsDeleted := make(types.RanFunctionRevisions)
+	for _, ranFunctionID := range removed {
+		sm, err := e.registry.GetServiceModel(ranFunctionID)
+		if err != nil {
+			return err
+		}
+		ranFunctionsDeleted[types.RanFunctionID(ranFunctionID)] = types.RanFunctionRevision(sm.Revision)
+	}
+
+	// The subscriptions are deleted while their service models are still registered to stop their reports
+	subs, err := e.subStore.List()
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if _, ok := ranFunctionsDeleted[types.RanFunctionID(sub.FnID.GetValue())]; ok {
+			e.deleteSubscription(ctx, sub)
+		}
+	}
+	for _, ranFunctionID := range removed {
+		if err := e.registry.UnregisterServiceModel(ranFunctionID); err != nil {
+			return err
+		}
+	}
+	for _, sm := range added {
+		if err := e.registry.RegisterServiceModel(sm); err != nil {
+			return err
+		}
+	}
+	ranFunctionsModified := make(types.RanFunctions)
+	for _, sm := range modified {
+		sm, err := e.registry.ModifyServiceModel(sm.RanFunctionID, sm.Description)
+		if err != nil {
+			return err
+		}
+		ranFunctionsModified[types.RanFunctionID(sm.RanFunctionID)] = types.RanFunctionItem{
+			Description: sm.Description,
+			Revision:    types.RanFunctionRevision(sm.Revision),
+			OID:         types.RanFunctionOID(sm.OID),
+		}
+	}
+	return e.ricServiceUpdate(ctx, e.procedures, ranFunctionsAdded, ranFunctionsModified, ranFunctionsDeleted)
+}
+
+// ricServiceUpdate runs the RIC service update procedure with the added, modified and deleted RAN functions on
+// the given TNL association
+func (e *e2Connection) ricServiceUpdate(ctx context.Context, conn *procedureConn, added types.RanFunctions, modified types.RanFunctions, deleted types.RanFunctionRevisions) error {
+	transactionID := int32(atomic.AddUint64(&e.transactionID, 1) % 255)
+	serviceUpdate, err := pdubuilder.CreateRicServiceUpdateE2apPdu(transactionID)
+	if err != nil {
+		return err
+	}
+	ricServiceUpdate := serviceUpdate.GetInitiatingMessage().GetValue().GetRicServiceUpdate()
+	if len(added) > 0 {
+		ricServiceUpdate.SetRanFunctionsAdded(added)
+	}
+	if len(modified) > 0 {
+		ricServiceUpdate.SetRanFunctionsModified(modified)
+	}
+	if len(deleted) > 0 {
+		ricServiceUpdate.SetRanFunctionsDeleted(deleted)
+	}
+	log.Infof("E2 node %d updates its RAN functions: %v", e.node.GnbID, serviceUpdate)
+	response, err := conn.request(ctx, v2.ProcedureCodeIDRICserviceUpdate, transactionID, serviceUpdate)
+	if err != nil {
+		return err
+	}
+	if response.GetUnsuccessfulOutcome() != nil {
+		_, cause, _, _, _, _, _, _, err := pdudecoder.DecodeRicServiceUpdateFailurePdu(response)
+		if err != nil {
+			return err
+		}
+		return errors.NewUnavailable("E2 node %d RIC service update failed: %v", e.node.GnbID, cause)
+	}
+	_, accepted, rejected, err := pdudecoder.DecodeRicServiceUpdateAcknowledgePdu(response)
+	if err != nil {
+		return err
+	}
+	for ranFunctionID, cause := range rejected {
+		log.Warnf("Controller %s rejected RAN function %d of E2 node %d: %v", e.controller, ranFunctionID, e.node.GnbID, cause)
+	}
+	log.Infof("RIC service update ack is received, accepted RAN functions: %v", accepted)
+	return nil
+}
+
+// RICServiceQuery handles the RIC SERVICE QUERY of the RIC: the node announces with a RIC service update the RAN
+// functions the RIC does not know or knows with another revision, and withdraws the ones it no longer has, on the
+// TNL association of the query
+func (e *e2Connection) RICServiceQuery(ctx context.Context, conn *procedureConn, request *e2appdudescriptions.E2ApPdu) {
+	_, accepted, err := pdudecoder.DecodeRicServiceQueryPdu(request)
+	if err != nil {
+		log.Warn(err)
+		return
 	}
+	log.Infof("E2 node %d is queried by controller %s about its RAN functions", e.node.GnbID, e.controller)
+	added := e.registry.GetRanFunctions()
+	modified := make(types.RanFunctions)
+	deleted := make(types.RanFunctionRevisions)
+	for ranFunctionID, revision := range accepted {
+		ranFunction, ok := added[ranFunctionID]
+		if !ok {
+			deleted[ranFunctionID] = revision
+			continue
+		}
+		if ranFunction.Revision != revision {
+			modified[ranFunctionID] = ranFunction
+		}
+		delete(added, ranFunctionID)
+	}
+	if err := e.ricServiceUpdate(ctx, conn, added, modified, deleted); err != nil {
+		log.Warnf("E2 node %d failed to respond to the RIC service query of controller %s: %v", e.node.GnbID, e.controller, err)
+	}
+}
+
+// f1ComponentID returns the ID of the F1 component of the node
+func (e *e2Connection) f1ComponentID() *e2apies.E2NodeComponentId {
+	return pdubuilder.CreateE2NodeComponentIDF1(e.gnbDUID())
+}
 
-	// TODO initialize component interfaces properly. It is just initialized with some default values
-	// 	to avoid encoding error.
-	e2ncIDF1 := pdubuilder.CreateE2NodeComponentIDF1(21)
-	e2ncIDXn := pdubuilder.CreateE2NodeComponentIDXn(&e2apies.GlobalNgRannodeId{
+// xnComponentID returns the ID of the Xn component of the node
+func (e *e2Connection) xnComponentID() *e2apies.E2NodeComponentId {
+	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
+	return pdubuilder.CreateE2NodeComponentIDXn(&e2apies.GlobalNgRannodeId{
 		GlobalNgRannodeId: &e2apies.GlobalNgRannodeId_GNb{
 			GNb: &e2apies.GlobalgNbId{
 				PlmnId: &e2apcommondatatypes.PlmnIdentity{
@@ -737,93 +1610,201 @@ func (e *e2Connection) setup() error {
 			},
 		},
 	})
+}
 
+// nodeCells returns the current cells of the node
+func (e *e2Connection) nodeCells(ctx context.Context) []ransimtypes.NCGI {
+	if e.nodeStore != nil {
+		node, err := e.nodeStore.Get(ctx, e.node.GnbID)
+		if err == nil {
+			return node.Cells
+		}
+		log.Warn(err)
+	}
+	return e.node.Cells
+}
+
+// componentSetupMessages returns the F1 and Xn setup requests of the node and the Xn setup response generated
+// from its current cells; the simulated Xn peer of the node responds with the same tracking area and cells
+func (e *e2Connection) componentSetupMessages(ctx context.Context) ([]byte, []byte, []byte, error) {
+	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
 	sCellItemListF1 := make([]f1ap.SCellItemInfo, 0)
-	sCellItemListXn := make([]xnap.XnItemCellInfo, 0)
-	nCellItemMapXn := make(map[ransimtypes.NCGI][]xnap.XnItemCellInfo)
 	e2NodePlmn := plmnID.ToBytes()
-	for _, c := range e.node.Cells {
-		m, err := e.cellStore.Get(context.Background(), c)
+	nodeCells := e.nodeCells(ctx)
+	for _, c := range nodeCells {
+		m, err := e.cellStore.Get(ctx, c)
 		if err != nil {
-			log.Warnf("failed to fetch cell %+v: %+v", m, err)
+			log.Warnf("failed to fetch cell %+v: %+v", c, err)
+			continue
 		}
 		nci := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(m.NCGI)))
+		band, sulBand := frequencyBands(m)
+		nrscs, nrnrb := transmissionBandwidth(m)
 		sCellItem := f1ap.SCellItemInfo{
 			PlmnIDBytes:                     e2NodePlmn,
 			NrCellIDBytes:                   nci.Bytes(),
 			NrCellIDLen:                     defaultNrCellIDLen,
 			NrPCI:                           int32(m.PCI),
-			SulFreqBandIndicationNr:         defaultSulFreqBandIndicationNr,
-			FreqBandIndicatorNr:             defaultFreqBandIndicatorNr,
+			SulFreqBandIndicationNr:         sulBand,
+			FreqBandIndicatorNr:             band,
 			NrArfcn:                         int32(m.Earfcn),
+			Nrscs:                           nrscs,
+			Nrnrb:                           nrnrb,
 			MeasureTimingConfigurationBytes: defaultMeasureTimingConfigurationBytes,
+			FiveGsTacBytes:                  e.tacBytes(m),
+			ServedPlmns:                     e.f1ServedPlmns(m),
 		}
 		sCellItemListF1 = append(sCellItemListF1, sCellItem)
+	}
+	sCellItemListXn, nCellItemMapXn := e.xnCells(ctx, nodeCells)
+
+	f1SetupRequestBytes, err := f1ap.CreateF1SetupRequest(e.gnbDUID(), defaultRRCVerBytes, defaultRRCVerLen, sCellItemListF1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gnbIDBytes := utils.Uint64ToBitString(uint64(e.node.GnbID), 22)
+	xnSetupRequestBytes, err := xnap.CreateXnSetupRequest(e2NodePlmn, gnbIDBytes, e.tacBytes(nil), e.xnSlices(),
+		e.amfRegion(), sCellItemListXn, nCellItemMapXn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	xnSetupResponseBytes, err := xnap.CreateXnSetupResponse(e2NodePlmn, gnbIDBytes, e.tacBytes(nil), e.xnSlices(),
+		sCellItemListXn, nCellItemMapXn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return f1SetupRequestBytes, xnSetupRequestBytes, xnSetupResponseBytes, nil
+}
+
+// xnCells returns the Xn served cell information of the specified cells of the node and of their neighbours
+func (e *e2Connection) xnCells(ctx context.Context, ncgis []ransimtypes.NCGI) ([]xnap.XnItemCellInfo, map[ransimtypes.NCGI][]xnap.XnItemCellInfo) {
+	sCellItemListXn := make([]xnap.XnItemCellInfo, 0)
+	nCellItemMapXn := make(map[ransimtypes.NCGI][]xnap.XnItemCellInfo)
+	for _, c := range ncgis {
+		m, err := e.cellStore.Get(ctx, c)
+		if err != nil {
+			log.Warnf("failed to fetch cell %+v: %+v", c, err)
+			continue
+		}
+		nci := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(m.NCGI)))
+		band, sulBand := frequencyBands(m)
+		nrscs, nrnrb := xnTransmissionBandwidth(m)
 		xnSCellItem := xnap.XnItemCellInfo{
 			NCGIKey:                         m.NCGI,
 			NrCellIDBytes:                   nci.Bytes(),
 			NrCellIDLen:                     defaultNrCellIDLen,
 			NrPCI:                           int32(m.PCI),
 			NrArfcn:                         int32(m.Earfcn),
-			SulFreqBand:                     defaultSulFreqBandIndicationNr,
-			FreqBand:                        defaultFreqBandIndicatorNr,
+			SulFreqBand:                     sulBand,
+			FreqBand:                        band,
 			MeasureTimingConfigurationBytes: defaultMeasureTimingConfigurationBytes,
 			RanAC:                           defaultRANAC,
+			TacBytes:                        e.tacBytes(m),
+			Nrscs:                           nrscs,
+			Nrnrb:                           nrnrb,
 		}
 		sCellItemListXn = append(sCellItemListXn, xnSCellItem)
 
 		nCellItemListXn := make([]xnap.XnItemCellInfo, 0)
 		for _, n := range m.Neighbors {
-			nCell, err := e.cellStore.Get(context.Background(), n)
+			nCell, err := e.cellStore.Get(ctx, n)
 			if err != nil {
-				log.Warnf("failed to fetch neighbor cell %+v, err: %+v", nCell, err)
+				log.Warnf("failed to fetch neighbor cell %+v, err: %+v", n, err)
 				continue
 			}
 			neighborNci := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(nCell.NCGI)))
+			neighborBand, neighborSulBand := frequencyBands(nCell)
 			xnNCellitem := xnap.XnItemCellInfo{
 				NCGIKey:                         nCell.NCGI,
 				NrCellIDBytes:                   neighborNci.Bytes(),
 				NrCellIDLen:                     defaultNrCellIDLen,
 				NrPCI:                           int32(nCell.PCI),
 				NrArfcn:                         int32(nCell.Earfcn),
-				SulFreqBand:                     defaultSulFreqBandIndicationNr,
-				FreqBand:                        defaultFreqBandIndicatorNr,
+				SulFreqBand:                     neighborSulBand,
+				FreqBand:                        neighborBand,
 				MeasureTimingConfigurationBytes: defaultMeasureTimingConfigurationBytes,
 				RanAC:                           defaultRANAC,
+				TacBytes:                        e.neighborTacBytes(ctx, nCell),
 			}
 			nCellItemListXn = append(nCellItemListXn, xnNCellitem)
 		}
 		nCellItemMapXn[m.NCGI] = nCellItemListXn
 	}
+	return sCellItemListXn, nCellItemMapXn
+}
 
-	f1SetupRequestBytes, err := f1ap.CreateF1SetupRequest(defaultGnBDUID, defaultRRCVerBytes, defaultRRCVerLen, sCellItemListF1)
+// NGRANNodeConfigurationUpdate sends an Xn NG-RAN node configuration update with the current neighbours of the
+// specified cell of the node to the nodes owning these neighbours. There are no Xn connections between the
+// simulated nodes, so the update is recorded as sent by the node and as received by each of its peers.
+func (e *e2Connection) NGRANNodeConfigurationUpdate(ctx context.Context, ncgi ransimtypes.NCGI) error {
+	servedCells, neighborCells := e.xnCells(ctx, []ransimtypes.NCGI{ncgi})
+	if len(servedCells) == 0 {
+		return errors.NewNotFound("cell %v of E2 node %d not found", ncgi, e.node.GnbID)
+	}
+	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
+	configurationUpdate, err := xnap.CreateNgRanNodeConfigurationUpdate(plmnID.ToBytes(), e.tacBytes(nil), e.xnSlices(), servedCells, neighborCells)
 	if err != nil {
 		return err
 	}
-	xnSetupRequestBytes, err := xnap.CreateXnSetupRequest(e2NodePlmn, utils.Uint64ToBitString(uint64(e.node.GnbID), 22), defaultTacBytes, []xnap.XnItemSlice{
-		{
-			Sst: defaultSST,
-			Sd:  defaultSD,
-		},
-	}, xnap.XnItemAMFRegion{AmfRegionID: defaultAMFRegionValue, AmfRegionIDLen: defaultAMFRegionLen}, sCellItemListXn, nCellItemMapXn)
+	if e.messageStore == nil {
+		return nil
+	}
+	peers := make(map[ransimtypes.GnbID]bool)
+	for _, neighbor := range neighborCells[ncgi] {
+		peer := ransimtypes.GetGnbID(uint64(neighbor.NCGIKey))
+		if peer == e.node.GnbID || peers[peer] {
+			continue
+		}
+		peers[peer] = true
+		log.Debugf("E2 node %d sends NG-RAN node configuration update of cell %v to node %d", e.node.GnbID, ncgi, peer)
+		for _, message := range []*messages.Message{
+			{GnbID: e.node.GnbID, PeerGnbID: peer, Direction: messages.Outgoing},
+			{GnbID: peer, PeerGnbID: e.node.GnbID, Direction: messages.Incoming},
+		} {
+			message.Interface = messages.Xn
+			message.Procedure = messages.NGRANNodeConfigurationUpdate
+			message.NCGI = ncgi
+			message.Payload = configurationUpdate
+			e.messageStore.Add(ctx, message)
+		}
+	}
+	return nil
+}
+
+func (e *e2Connection) setup() error {
+	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
+
+	configAdditionList := &e2appducontents.E2NodeComponentConfigAdditionList{
+		Value: make([]*e2appducontents.E2NodeComponentConfigAdditionItemIes, 0),
+	}
+
+	f1SetupRequestBytes, xnSetupRequestBytes, xnSetupResponseBytes, err := e.componentSetupMessages(context.Background())
+	if err != nil {
+		return err
+	}
+	f1SetupResponseBytes, err := e.f1SetupResponse()
 	if err != nil {
 		return err
 	}
+	e.recordSetupMessage(messages.F1, messages.F1SetupRequest, f1SetupRequestBytes)
+	e.recordSetupMessage(messages.F1, messages.F1SetupResponse, f1SetupResponseBytes)
+	e.recordSetupMessage(messages.Xn, messages.XnSetupRequest, xnSetupRequestBytes)
+	e.recordSetupMessage(messages.Xn, messages.XnSetupResponse, xnSetupResponseBytes)
 	configComponentAdditionItems := []*types.E2NodeComponentConfigAdditionItem{
 		{
 			E2NodeComponentType: e2apies.E2NodeComponentInterfaceType_E2NODE_COMPONENT_INTERFACE_TYPE_F1,
-			E2NodeComponentID:   e2ncIDF1,
+			E2NodeComponentID:   e.f1ComponentID(),
 			E2NodeComponentConfiguration: e2apies.E2NodeComponentConfiguration{
 				E2NodeComponentRequestPart:  f1SetupRequestBytes,
-				E2NodeComponentResponsePart: []byte{0x04, 0x05, 0x06},
+				E2NodeComponentResponsePart: f1SetupResponseBytes,
 			},
 		},
 		{
 			E2NodeComponentType: e2apies.E2NodeComponentInterfaceType_E2NODE_COMPONENT_INTERFACE_TYPE_XN,
-			E2NodeComponentID:   e2ncIDXn,
+			E2NodeComponentID:   e.xnComponentID(),
 			E2NodeComponentConfiguration: e2apies.E2NodeComponentConfiguration{
 				E2NodeComponentRequestPart:  xnSetupRequestBytes,
-				E2NodeComponentResponsePart: []byte{0x04, 0x05, 0x06},
+				E2NodeComponentResponsePart: xnSetupResponseBytes,
 			},
 		},
 	}
@@ -864,9 +1845,21 @@ func (e *e2Connection) setup() error {
 	e2SetupAck, e2SetupFailure, err := e.client.E2Setup(ctx, e2SetupRequest)
 	if err != nil {
 		log.Warn(err)
+		e.setConnectionState(SetupFailedPhase, err.Error())
 		return errors.NewUnknown("E2 setup failed: %v", err)
 	} else if e2SetupFailure != nil {
-		err := errors.NewInvalid("E2 setup failed")
+		// Honours the time to wait before the next attempt, if any
+		var cause *e2apies.Cause
+		for _, v := range e2SetupFailure.GetProtocolIes() {
+			if v.Id == int32(v2.ProtocolIeIDCause) {
+				cause = v.GetValue().GetCause()
+			}
+			if v.Id == int32(v2.ProtocolIeIDTimeToWait) && e.setupBackOff != nil {
+				e.setupBackOff.timeToWait = timesToWait[v.GetValue().GetTimeToWait()]
+			}
+		}
+		e.setConnectionState(SetupFailedPhase, causeString(cause))
+		err := errors.NewInvalid("E2 setup failed: %s", causeString(cause))
 		log.Warn(err)
 		return err
 	}
@@ -881,6 +1874,7 @@ func (e *e2Connection) setup() error {
 			State: connections.Configured,
 		},
 		Client: e.client,
+		Usage:  e2apies.Tnlusage_TNLUSAGE_BOTH,
 	}
 
 	err = e.connectionStore.Add(ctx,
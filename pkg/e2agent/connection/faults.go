@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+)
+
+// reorderHoldTime is the longest time an indication is held back waiting for the next indication
+const reorderHoldTime = time.Second
+
+// injected returns whether a fault of the specified rate is injected
+func injected(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate
+}
+
+// indicationFlusher is the E2 client of a subscription which may hold indications back
+type indicationFlusher interface {
+	// FlushIndications sends the indications held back
+	FlushIndications(ctx context.Context) error
+}
+
+// flushIndications sends the indications held back by the E2 client of a subscription
+func flushIndications(ctx context.Context, client e2.ClientConn) {
+	if flusher, ok := client.(indicationFlusher); ok {
+		if err := flusher.FlushIndications(ctx); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// faultInjector is the E2 client of a subscription which injects the faults of the profile of the node
+// in the indications it sends
+type faultInjector struct {
+	e2.ClientConn
+	faultStore faults.Store
+	gnbID      types.GnbID
+	mu         sync.Mutex
+	held       *e2appducontents.Ricindication
+	flushTimer *time.Timer
+}
+
+func newFaultInjector(client e2.ClientConn, faultStore faults.Store, gnbID types.GnbID) e2.ClientConn {
+	if faultStore == nil {
+		return client
+	}
+	return &faultInjector{
+		ClientConn: client,
+		faultStore: faultStore,
+		gnbID:      gnbID,
+	}
+}
+
+// RICIndication sends the indication, possibly delayed, dropped, held back until the next indication or
+// duplicated; the corruption of the indications is injected on their encoded messages by the TNL association
+func (f *faultInjector) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	profile := f.faultStore.Get(ctx, f.gnbID)
+	if injected(profile.DropRate) {
+		f.faultStore.Inject(ctx, f.gnbID, faults.Dropped)
+		return nil
+	}
+	if profile.IndicationDelay > 0 {
+		f.faultStore.Inject(ctx, f.gnbID, faults.Delayed)
+		select {
+		case <-time.After(profile.IndicationDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	held := f.takeHeld()
+	if held == nil && injected(profile.ReorderRate) {
+		// The indication is sent after the next one, or flushed if none follows in time
+		f.held = request
+		f.flushTimer = time.AfterFunc(reorderHoldTime, func() {
+			if err := f.FlushIndications(context.Background()); err != nil {
+				log.Warn(err)
+			}
+		})
+		f.mu.Unlock()
+		f.faultStore.Inject(ctx, f.gnbID, faults.Reordered)
+		return nil
+	}
+	f.mu.Unlock()
+
+	if err := f.ClientConn.RICIndication(ctx, request); err != nil {
+		return err
+	}
+	if held != nil {
+		if err := f.ClientConn.RICIndication(ctx, held); err != nil {
+			return err
+		}
+	}
+	if injected(profile.DuplicateRate) {
+		f.faultStore.Inject(ctx, f.gnbID, faults.Duplicated)
+		return f.ClientConn.RICIndication(ctx, request)
+	}
+	return nil
+}
+
+// FlushIndications sends the indication held back, if any, without waiting for the next indication
+func (f *faultInjector) FlushIndications(ctx context.Context) error {
+	f.mu.Lock()
+	held := f.takeHeld()
+	f.mu.Unlock()
+	if held == nil {
+		return nil
+	}
+	f.faultStore.Inject(ctx, f.gnbID, faults.Flushed)
+	return f.ClientConn.RICIndication(ctx, held)
+}
+
+// takeHeld takes the indication held back and stops its flush timer; the caller holds the lock
+func (f *faultInjector) takeHeld() *e2appducontents.Ricindication {
+	held := f.held
+	f.held = nil
+	if f.flushTimer != nil {
+		f.flushTimer.Stop()
+		f.flushTimer = nil
+	}
+	return held
+}
+
+// isIndication returns whether the encoded message is a RIC INDICATION
+func isIndication(b []byte) bool {
+	return len(b) >= minimumMessageLength && b[0]&messageTypeBitsMask == initiatingMessage &&
+		v2.ProcedureCodeT(b[procedureCodeOctet]) == v2.ProcedureCodeIDRICindication
+}
+
+// corrupt returns a copy of the encoded message whose content following its type and procedure code is corrupted
+func corrupt(b []byte) []byte {
+	corrupted := append([]byte{}, b...)
+	if len(corrupted) <= minimumMessageLength {
+		return corrupted
+	}
+	for i := minimumMessageLength; i < len(corrupted); i++ {
+		if rand.Intn(4) == 0 {
+			corrupted[i] ^= 0xFF
+		}
+	}
+	corrupted[minimumMessageLength+rand.Intn(len(corrupted)-minimumMessageLength)] ^= 0x5A
+	return corrupted
+}
+
+// causeFromName returns the E2AP cause with the specified value name, e.g. CAUSE_MISC_OM_INTERVENTION
+func causeFromName(name string) (*e2apies.Cause, bool) {
+	if v, ok := e2apies.CauseRicrequest_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_RicRequest{RicRequest: e2apies.CauseRicrequest(v)}}, true
+	}
+	if v, ok := e2apies.CauseRicservice_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_RicService{RicService: e2apies.CauseRicservice(v)}}, true
+	}
+	if v, ok := e2apies.CauseE2Node_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_E2Node{E2Node: e2apies.CauseE2Node(v)}}, true
+	}
+	if v, ok := e2apies.CauseTransport_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_Transport{Transport: e2apies.CauseTransport(v)}}, true
+	}
+	if v, ok := e2apies.CauseProtocol_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_Protocol{Protocol: e2apies.CauseProtocol(v)}}, true
+	}
+	if v, ok := e2apies.CauseMisc_value[name]; ok {
+		return &e2apies.Cause{Cause: &e2apies.Cause_Misc{Misc: e2apies.CauseMisc(v)}}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderedIndications(t *testing.T) {
+	ctx := context.TODO()
+	faultStore := faults.NewFaultRegistry(map[string]model.Node{})
+	faultStore.Set(ctx, 144470, model.FaultProfile{ReorderRate: 1})
+	client := &testClient{}
+	subscriptionClient := newBudgetedClient(newFaultInjector(client, faultStore, 144470), NewIndicationBudget(model.IndicationBudget{}))
+
+	// The indication held back is sent after the next one
+	assert.NoError(t, subscriptionClient.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	assert.Equal(t, 0, client.indications)
+	assert.NoError(t, subscriptionClient.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	assert.Equal(t, 2, client.indications)
+
+	// The indication held back is flushed when its subscription is deleted
+	assert.NoError(t, subscriptionClient.RICIndication(ctx, &e2appducontents.Ricindication{}))
+	flushIndications(ctx, subscriptionClient)
+	assert.Equal(t, 3, client.indications)
+	assert.Equal(t, uint64(1), faultStore.Counters(ctx, 144470)[faults.Flushed])
+
+	// The indication held back is flushed if no indication follows in time
+	assert.NoError(t, newFaultInjector(&testClient{}, faultStore, 144470).RICIndication(ctx, &e2appducontents.Ricindication{}))
+	assert.Eventually(t, func() bool {
+		return faultStore.Counters(ctx, 144470)[faults.Flushed] == 2
+	}, 2*reorderHoldTime, 10*time.Millisecond)
+	assert.Equal(t, uint64(3), faultStore.Counters(ctx, 144470)[faults.Reordered])
+}
+
+func TestCorruptedIndications(t *testing.T) {
+	ctx := context.TODO()
+	faultStore := faults.NewFaultRegistry(map[string]model.Node{})
+	faultStore.Set(ctx, 144470, model.FaultProfile{CorruptRate: 1})
+	nodeConn, ricConn := net.Pipe()
+	conn := newProcedureConn(nodeConn, nil, faultStore, 144470)
+	defer func() {
+		_ = conn.Close()
+		_ = ricConn.Close()
+	}()
+	write := func(b []byte) []byte {
+		go func() {
+			n, err := conn.Write(b)
+			assert.NoError(t, err)
+			assert.Equal(t, len(b), n)
+		}()
+		buf := make([]byte, 64)
+		n, err := ricConn.Read(buf)
+		assert.NoError(t, err)
+		return buf[:n]
+	}
+
+	// The encoded indication is corrupted but keeps its type and procedure code
+	indication := []byte{initiatingMessage, byte(v2.ProcedureCodeIDRICindication), 0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	corrupted := write(indication)
+	assert.Len(t, corrupted, len(indication))
+	assert.Equal(t, indication[:minimumMessageLength], corrupted[:minimumMessageLength])
+	assert.NotEqual(t, indication, corrupted)
+	assert.Equal(t, uint64(1), faultStore.Counters(ctx, 144470)[faults.Corrupted])
+
+	// The other messages are not corrupted
+	response := []byte{successfulOutcome, byte(v2.ProcedureCodeIDRICsubscription), 0x01, 0x02}
+	assert.Equal(t, response, write(response))
+	assert.Equal(t, uint64(1), faultStore.Counters(ctx, 144470)[faults.Corrupted])
+}
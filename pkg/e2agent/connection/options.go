@@ -11,6 +11,10 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 )
 
@@ -18,12 +22,18 @@ import (
 type InstanceOptions struct {
 	node            model.Node
 	model           *model.Model
+	controller      string
 	ricAddress      addressing.RICAddress
 	e2Client        e2.ClientConn
 	registry        *registry.ServiceModelRegistry
 	subStore        *subscriptions.Subscriptions
 	connectionStore connections.Store
 	cellStore       cells.Store
+	messageStore    messages.Store
+	nodeStore       nodes.Store
+	metricStore     metrics.Store
+	faultStore      faults.Store
+	budget          *IndicationBudget
 }
 
 // InstanceOption instance option
@@ -43,6 +53,13 @@ func WithModel(model *model.Model) func(options *InstanceOptions) {
 	}
 }
 
+// WithController sets the name of the controller of the connection
+func WithController(controller string) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.controller = controller
+	}
+}
+
 // WithRICAddress sets RIC address
 func WithRICAddress(ricAddress addressing.RICAddress) func(options *InstanceOptions) {
 	return func(options *InstanceOptions) {
@@ -84,3 +101,38 @@ func WithCellStore(cellStore cells.Store) func(options *InstanceOptions) {
 		options.cellStore = cellStore
 	}
 }
+
+// WithMessageStore sets the log of the interface messages exchanged by the node
+func WithMessageStore(messageStore messages.Store) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.messageStore = messageStore
+	}
+}
+
+// WithNodeStore sets node store
+func WithNodeStore(nodeStore nodes.Store) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.nodeStore = nodeStore
+	}
+}
+
+// WithMetricStore sets metric store
+func WithMetricStore(metricStore metrics.Store) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.metricStore = metricStore
+	}
+}
+
+// WithFaultStore sets fault store
+func WithFaultStore(faultStore faults.Store) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.faultStore = faultStore
+	}
+}
+
+// WithIndicationBudget sets the indication budget of the node
+func WithIndicationBudget(budget *IndicationBudget) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.budget = budget
+	}
+}
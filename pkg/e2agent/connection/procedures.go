@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appdudescriptions "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-descriptions"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/encoder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdudecoder"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+)
+
+// The first octet of an aligned PER encoded E2AP PDU selects its type
+const (
+	initiatingMessage    = 0x00
+	successfulOutcome    = 0x20
+	unsuccessfulOutcome  = 0x40
+	messageTypeBitsMask  = 0x60
+	procedureCodeOctet   = 1
+	minimumMessageLength = 2
+)
+
+// procedureTimeout is the time given to the RIC to respond to a procedure initiated by the node
+const procedureTimeout = 10 * time.Second
+
+// procedureHandler handles the messages of the E2AP procedures which the E2AP library does not support
+type procedureHandler interface {
+	// ResetRequest handles a RESET REQUEST initiated by the RIC, which is answered on the association receiving it
+	ResetRequest(ctx context.Context, conn *procedureConn, request *e2appdudescriptions.E2ApPdu)
+
+	// RICServiceQuery handles a RIC SERVICE QUERY initiated by the RIC, which is answered on the association receiving it
+	RICServiceQuery(ctx context.Context, conn *procedureConn, request *e2appdudescriptions.E2ApPdu)
+
+	// ErrorIndication handles an ERROR INDICATION sent by the RIC
+	ErrorIndication(ctx context.Context, indication *e2appdudescriptions.E2ApPdu)
+}
+
+// procedureKey identifies a procedure initiated by the node which waits for the response of the RIC
+type procedureKey struct {
+	procedureCode v2.ProcedureCodeT
+	transactionID int32
+}
+
+// procedureConn is the TNL association of an E2 interface instance which carries, next to the procedures of the
+// E2AP library, the E2AP procedures the library does not support: their messages are intercepted before they
+// reach the library and sent directly on the association.
+type procedureConn struct {
+	net.Conn
+	handler    procedureHandler
+	faultStore faults.Store
+	gnbID      types.GnbID
+	writeMu    sync.Mutex
+	mu         sync.Mutex
+	pending    map[procedureKey]chan *e2appdudescriptions.E2ApPdu
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+func newProcedureConn(conn net.Conn, handler procedureHandler, faultStore faults.Store, gnbID types.GnbID) *procedureConn {
+	return &procedureConn{
+		Conn:       conn,
+		handler:    handler,
+		faultStore: faultStore,
+		gnbID:      gnbID,
+		pending:    make(map[procedureKey]chan *e2appdudescriptions.E2ApPdu),
+		closed:     make(chan struct{}),
+	}
+}
+
+// Read reads the next message for the E2AP library, handling the messages of the procedures it does not support
+func (c *procedureConn) Read(b []byte) (int, error) {
+	for {
+		n, err := c.Conn.Read(b)
+		if err != nil {
+			c.closeOnce.Do(func() { close(c.closed) })
+			return n, err
+		}
+		if !intercepted(b[:n]) {
+			return n, nil
+		}
+		pdu, err := encoder.PerDecodeE2ApPdu(b[:n])
+		if err != nil {
+			// The E2AP library reports the message which cannot be decoded
+			return n, nil
+		}
+		c.dispatch(pdu)
+	}
+}
+
+// Write writes a message on the association; the messages of the E2AP library and of the procedures it does
+// not support are never interleaved. The indications are corrupted following the fault profile of the node.
+func (c *procedureConn) Write(b []byte) (int, error) {
+	if c.faultStore != nil && isIndication(b) {
+		ctx := context.Background()
+		if injected(c.faultStore.Get(ctx, c.gnbID).CorruptRate) {
+			c.faultStore.Inject(ctx, c.gnbID, faults.Corrupted)
+			b = corrupt(b)
+		}
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.Conn.Write(b)
+}
+
+// Close closes the association
+func (c *procedureConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Conn.Close()
+}
+
+// intercepted returns whether the encoded message belongs to a procedure the E2AP library does not support
+func intercepted(b []byte) bool {
+	if len(b) < minimumMessageLength {
+		return false
+	}
+	procedureCode := v2.ProcedureCodeT(b[procedureCodeOctet])
+	switch b[0] & messageTypeBitsMask {
+	case initiatingMessage:
+		return procedureCode == v2.ProcedureCodeIDReset || procedureCode == v2.ProcedureCodeIDRICserviceQuery ||
+			procedureCode == v2.ProcedureCodeIDErrorIndication
+	case successfulOutcome:
+		return procedureCode == v2.ProcedureCodeIDReset || procedureCode == v2.ProcedureCodeIDRICserviceUpdate
+	case unsuccessfulOutcome:
+		return procedureCode == v2.ProcedureCodeIDRICserviceUpdate
+	}
+	return false
+}
+
+// dispatch hands a request of the RIC to the handler and a response to the procedure waiting for it
+func (c *procedureConn) dispatch(pdu *e2appdudescriptions.E2ApPdu) {
+	ctx := context.Background()
+	switch {
+	case pdu.GetInitiatingMessage().GetValue().GetReset_() != nil:
+		go c.handler.ResetRequest(ctx, c, pdu)
+	case pdu.GetInitiatingMessage().GetValue().GetRicServiceQuery() != nil:
+		go c.handler.RICServiceQuery(ctx, c, pdu)
+	case pdu.GetInitiatingMessage().GetValue().GetErrorIndication() != nil:
+		c.handler.ErrorIndication(ctx, pdu)
+	case pdu.GetSuccessfulOutcome().GetValue().GetReset_() != nil:
+		transactionID, _, _, _, _, _, err := pdudecoder.DecodeResetResponsePdu(pdu)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		c.respond(procedureKey{procedureCode: v2.ProcedureCodeIDReset, transactionID: *transactionID}, pdu)
+	case pdu.GetSuccessfulOutcome().GetValue().GetRicServiceUpdate() != nil:
+		transactionID, _, _, err := pdudecoder.DecodeRicServiceUpdateAcknowledgePdu(pdu)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		c.respond(procedureKey{procedureCode: v2.ProcedureCodeIDRICserviceUpdate, transactionID: *transactionID}, pdu)
+	case pdu.GetUnsuccessfulOutcome().GetValue().GetRicServiceUpdate() != nil:
+		transactionID, _, _, _, _, _, _, _, err := pdudecoder.DecodeRicServiceUpdateFailurePdu(pdu)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		c.respond(procedureKey{procedureCode: v2.ProcedureCodeIDRICserviceUpdate, transactionID: *transactionID}, pdu)
+	}
+}
+
+// respond hands the response to the procedure waiting for it
+func (c *procedureConn) respond(key procedureKey, pdu *e2appdudescriptions.E2ApPdu) {
+	c.mu.Lock()
+	ch, ok := c.pending[key]
+	delete(c.pending, key)
+	c.mu.Unlock()
+	if !ok {
+		log.Warnf("Unexpected response of procedure %d with transaction ID %d", key.procedureCode, key.transactionID)
+		return
+	}
+	ch <- pdu
+}
+
+// send encodes and sends the message
+func (c *procedureConn) send(pdu *e2appdudescriptions.E2ApPdu) error {
+	bytes, err := encoder.PerEncodeE2ApPdu(pdu)
+	if err != nil {
+		return err
+	}
+	_, err = c.Write(bytes)
+	return err
+}
+
+// request sends the message initiating the procedure and waits for the response of the RIC
+func (c *procedureConn) request(ctx context.Context, procedureCode v2.ProcedureCodeT, transactionID int32, pdu *e2appdudescriptions.E2ApPdu) (*e2appdudescriptions.E2ApPdu, error) {
+	ctx, cancel := context.WithTimeout(ctx, procedureTimeout)
+	defer cancel()
+	key := procedureKey{procedureCode: procedureCode, transactionID: transactionID}
+	ch := make(chan *e2appdudescriptions.E2ApPdu, 1)
+	c.mu.Lock()
+	c.pending[key] = ch
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, key)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(pdu); err != nil {
+		return nil, err
+	}
+	select {
+	case response := <-ch:
+		return response, nil
+	case <-ctx.Done():
+		return nil, errors.NewTimeout("the RIC did not respond to procedure %d: %v", procedureCode, ctx.Err())
+	case <-c.closed:
+		return nil, errors.NewUnavailable("the connection is closed before the response of procedure %d", procedureCode)
+	}
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appdudescriptions "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-descriptions"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/encoder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdudecoder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/indicationerror"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestProcedureConn connects an E2 interface instance to a RIC peer over a pipe; the messages passed to the
+// E2AP library are returned on the channel
+func newTestProcedureConn(t *testing.T) (*e2Connection, net.Conn, chan []byte) {
+	e := &e2Connection{
+		node:     model.Node{GnbID: 144},
+		subStore: subscriptions.NewStore(),
+		registry: registry.NewServiceModelRegistry(),
+	}
+	var ricConn net.Conn
+	var library chan []byte
+	e.procedures, ricConn, library = newTestAssociation(t, e)
+	return e, ricConn, library
+}
+
+// newTestAssociation opens a TNL association of the E2 interface instance to a RIC peer over a pipe
+func newTestAssociation(t *testing.T, e *e2Connection) (*procedureConn, net.Conn, chan []byte) {
+	nodeConn, ricConn := net.Pipe()
+	conn := newProcedureConn(nodeConn, e, nil, 0)
+	library := make(chan []byte, 1)
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			library <- append([]byte{}, buf[:n]...)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = ricConn.Close()
+		_ = conn.Close()
+	})
+	return conn, ricConn, library
+}
+
+func readPdu(t *testing.T, conn net.Conn) *e2appdudescriptions.E2ApPdu {
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	assert.NoError(t, err)
+	pdu, err := encoder.PerDecodeE2ApPdu(buf[:n])
+	assert.NoError(t, err)
+	return pdu
+}
+
+func writePdu(t *testing.T, conn net.Conn, pdu *e2appdudescriptions.E2ApPdu) []byte {
+	bytes, err := encoder.PerEncodeE2ApPdu(pdu)
+	assert.NoError(t, err)
+	_, err = conn.Write(bytes)
+	assert.NoError(t, err)
+	return bytes
+}
+
+func TestReset(t *testing.T) {
+	e, ricConn, library := newTestProcedureConn(t)
+
+	// the RIC resets the node, which responds with the transaction ID of the request
+	request, err := pdubuilder.CreateResetRequestE2apPdu(7, omInterventionCause())
+	assert.NoError(t, err)
+	writePdu(t, ricConn, request)
+	transactionID, _, _, _, _, _, err := pdudecoder.DecodeResetResponsePdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), *transactionID)
+
+	// the node resets itself and waits for the response of the RIC
+	done := make(chan error)
+	go func() {
+		done <- e.Reset(context.Background())
+	}()
+	cause, transactionID, err := pdudecoder.DecodeResetRequestPdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.Equal(t, omInterventionCause().String(), cause.String())
+	response, err := pdubuilder.CreateResetResponseE2apPdu(*transactionID)
+	assert.NoError(t, err)
+	writePdu(t, ricConn, response)
+	assert.NoError(t, <-done)
+
+	// the messages of the other procedures are passed to the E2AP library
+	connectionUpdate, err := pdubuilder.CreateE2connectionUpdateE2apPdu(3)
+	assert.NoError(t, err)
+	bytes := writePdu(t, ricConn, connectionUpdate)
+	assert.Equal(t, bytes, <-library)
+}
+
+func TestResetOnAdditionalAssociation(t *testing.T) {
+	e, ricConn, _ := newTestProcedureConn(t)
+	_, additionalRicConn, _ := newTestAssociation(t, e)
+
+	// the reset requested on an additional association is answered on that association
+	request, err := pdubuilder.CreateResetRequestE2apPdu(9, omInterventionCause())
+	assert.NoError(t, err)
+	writePdu(t, additionalRicConn, request)
+	transactionID, _, _, _, _, _, err := pdudecoder.DecodeResetResponsePdu(readPdu(t, additionalRicConn))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(9), *transactionID)
+
+	// the first association is left alone
+	assert.NoError(t, ricConn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err = ricConn.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
+
+func addTestSubscription(t *testing.T, e *e2Connection, ranFunctionID registry.RanFunctionID) {
+	id := subscriptions.NewID(1, int32(ranFunctionID), int32(ranFunctionID))
+	err := e.subStore.Add(&subscriptions.Subscription{
+		ID:    id,
+		ReqID: &e2apies.RicrequestId{RicRequestorId: int32(ranFunctionID), RicInstanceId: 1},
+		FnID:  &e2apies.RanfunctionId{Value: int32(ranFunctionID)},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, e.registry.RegisterServiceModel(registry.ServiceModel{RanFunctionID: ranFunctionID, OID: "1.3.6.1.4.1.53148.1.2.2"}))
+}
+
+// testCellTargeter is a service model whose subscriptions report on a single cell
+type testCellTargeter struct {
+	servicemodel.Client
+	ncgi ransimtypes.NCGI
+}
+
+func (sm testCellTargeter) Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	return ncgi == sm.ncgi
+}
+
+func TestRICSubscriptionDeleteRequired(t *testing.T) {
+	e, ricConn, _ := newTestProcedureConn(t)
+	addTestSubscription(t, e, registry.Kpm2)
+	addTestSubscription(t, e, registry.Mho)
+	assert.NoError(t, e.subStore.Add(&subscriptions.Subscription{
+		ID:    subscriptions.NewID(1, int32(registry.Llc), int32(registry.Llc)),
+		ReqID: &e2apies.RicrequestId{RicRequestorId: int32(registry.Llc), RicInstanceId: 1},
+		FnID:  &e2apies.RanfunctionId{Value: int32(registry.Llc)},
+	}))
+	assert.NoError(t, e.registry.RegisterServiceModel(registry.ServiceModel{RanFunctionID: registry.Llc, Client: testCellTargeter{ncgi: 0x2}}))
+
+	// only the KPM subscription reporting on the deleted cell requires its deletion, the LLC subscription reporting
+	// on a surviving cell is left alone
+	done := make(chan error)
+	go func() {
+		done <- e.RICSubscriptionDeleteRequired(context.Background(), 0x1)
+	}()
+	required, err := pdudecoder.DecodeRicSubscriptionDeleteRequiredPdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	assert.Len(t, required, 1)
+	assert.Equal(t, types.RicRequestorID(registry.Kpm2), required[types.RanFunctionID(registry.Kpm2)].RicRequestID.RequestorID)
+
+	// the subscriptions are kept until the RIC deletes them
+	count, err := e.subStore.Len()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestRICServiceUpdate(t *testing.T) {
+	e, ricConn, _ := newTestProcedureConn(t)
+	addTestSubscription(t, e, registry.Internal)
+	addTestSubscription(t, e, registry.Mho)
+	kpm := registry.ServiceModel{RanFunctionID: registry.Kpm2, Description: []byte{0x01}, Revision: 1, OID: "1.3.6.1.4.1.53148.1.2.2.2"}
+	mho := registry.ServiceModel{RanFunctionID: registry.Mho, Description: []byte{0x02}}
+
+	// the removed service model is withdrawn and its subscriptions deleted, the modified one keeps its subscriptions
+	done := make(chan error)
+	go func() {
+		done <- e.RICServiceUpdate(context.Background(), []registry.ServiceModel{kpm}, []registry.ServiceModel{mho}, []registry.RanFunctionID{registry.Internal})
+	}()
+	transactionID, added, deleted, modified, err := pdudecoder.DecodeRicServiceUpdatePdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.Contains(t, added, types.RanFunctionID(registry.Kpm2))
+	assert.Contains(t, deleted, types.RanFunctionID(registry.Internal))
+	assert.Equal(t, types.RanFunctionRevision(1), modified[types.RanFunctionID(registry.Mho)].Revision)
+	ack, err := pdubuilder.CreateRicServiceUpdateAcknowledgeE2apPdu(*transactionID, types.RanFunctionRevisions{types.RanFunctionID(registry.Kpm2): 1})
+	assert.NoError(t, err)
+	writePdu(t, ricConn, ack)
+	assert.NoError(t, <-done)
+	count, err := e.subStore.Len()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	_, err = e.registry.GetServiceModel(registry.Internal)
+	assert.Error(t, err)
+
+	// a rejected update fails
+	go func() {
+		done <- e.RICServiceUpdate(context.Background(), nil, nil, []registry.RanFunctionID{registry.Kpm2})
+	}()
+	transactionID, _, _, _, err = pdudecoder.DecodeRicServiceUpdatePdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	failure, err := pdubuilder.CreateRicServiceUpdateFailureE2apPdu(*transactionID, omInterventionCause())
+	assert.NoError(t, err)
+	writePdu(t, ricConn, failure)
+	assert.Error(t, <-done)
+}
+
+func TestRICServiceQuery(t *testing.T) {
+	e, ricConn, _ := newTestProcedureConn(t)
+	assert.NoError(t, e.registry.RegisterServiceModel(registry.ServiceModel{RanFunctionID: registry.Kpm2, Description: []byte{0x01}, Revision: 2, OID: "1.3.6.1.4.1.53148.1.2.2.2"}))
+	assert.NoError(t, e.registry.RegisterServiceModel(registry.ServiceModel{RanFunctionID: registry.Mho, Description: []byte{0x02}, Revision: 1, OID: "1.3.6.1.4.1.53148.1.2.2.101"}))
+
+	// the RIC knows an older revision of KPM and a RAN function the node does not have
+	query, err := pdubuilder.CreateRicServiceQueryE2apPdu(5)
+	assert.NoError(t, err)
+	query.GetInitiatingMessage().GetValue().GetRicServiceQuery().SetRanFunctionsAccepted(types.RanFunctionRevisions{
+		types.RanFunctionID(registry.Kpm2): 1,
+		types.RanFunctionID(registry.Ni):   1,
+	})
+	writePdu(t, ricConn, query)
+	transactionID, added, deleted, modified, err := pdudecoder.DecodeRicServiceUpdatePdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.Len(t, added, 1)
+	assert.Contains(t, added, types.RanFunctionID(registry.Mho))
+	assert.Len(t, modified, 1)
+	assert.Contains(t, modified, types.RanFunctionID(registry.Kpm2))
+	assert.Len(t, deleted, 1)
+	assert.Contains(t, deleted, types.RanFunctionID(registry.Ni))
+	ack, err := pdubuilder.CreateRicServiceUpdateAcknowledgeE2apPdu(*transactionID, types.RanFunctionRevisions{
+		types.RanFunctionID(registry.Kpm2): 2,
+		types.RanFunctionID(registry.Mho):  1,
+	})
+	assert.NoError(t, err)
+	writePdu(t, ricConn, ack)
+}
+
+func TestRICServiceQueryOnAdditionalAssociation(t *testing.T) {
+	e, _, _ := newTestProcedureConn(t)
+	_, additionalRicConn, _ := newTestAssociation(t, e)
+	assert.NoError(t, e.registry.RegisterServiceModel(registry.ServiceModel{RanFunctionID: registry.Kpm2, Description: []byte{0x01}, Revision: 1, OID: "1.3.6.1.4.1.53148.1.2.2.2"}))
+
+	// the query received on an additional association is answered with a service update on that association
+	query, err := pdubuilder.CreateRicServiceQueryE2apPdu(6)
+	assert.NoError(t, err)
+	writePdu(t, additionalRicConn, query)
+	transactionID, added, _, _, err := pdudecoder.DecodeRicServiceUpdatePdu(readPdu(t, additionalRicConn))
+	assert.NoError(t, err)
+	assert.Contains(t, added, types.RanFunctionID(registry.Kpm2))
+	ack, err := pdubuilder.CreateRicServiceUpdateAcknowledgeE2apPdu(*transactionID, types.RanFunctionRevisions{types.RanFunctionID(registry.Kpm2): 1})
+	assert.NoError(t, err)
+	writePdu(t, additionalRicConn, ack)
+}
+
+func TestErrorIndication(t *testing.T) {
+	e, ricConn, _ := newTestProcedureConn(t)
+
+	// the node sends an error indication for a malformed request
+	go e.errorIndication(context.Background(), v2.ProcedureCodeIDRICsubscription, protocolErrorCause(),
+		indicationerror.WithRanFuncID(int32(registry.Kpm2)))
+	_, cause, ranFunctionID, _, procedureCode, _, _, _, _, err := pdudecoder.DecodeErrorIndicationPdu(readPdu(t, ricConn))
+	assert.NoError(t, err)
+	assert.Equal(t, protocolErrorCause().String(), cause.String())
+	assert.Equal(t, types.RanFunctionID(registry.Kpm2), *ranFunctionID)
+	assert.Equal(t, v2.ProcedureCodeIDRICsubscription, *procedureCode)
+
+	// the error indication of the RIC is counted
+	indication, err := indicationerror.NewErrorIndication(indicationerror.WithCause(omInterventionCause())).BuildPdu()
+	assert.NoError(t, err)
+	writePdu(t, ricConn, indication)
+	assert.Eventually(t, func() bool {
+		sent, received := e.ErrorIndications()
+		return sent == 1 && received == 1
+	}, time.Second, 10*time.Millisecond)
+}
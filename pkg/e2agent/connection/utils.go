@@ -6,6 +6,7 @@ package connection
 
 import (
 	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/model"
 
 	"time"
 
@@ -38,12 +39,82 @@ const (
 	maxBackoffTime  = 5 * time.Second
 )
 
-func newExpBackoff() *backoff.ExponentialBackOff {
+// newExpBackoff creates the exponential back-off of the attempts of a node following its retry policy
+func newExpBackoff(policy model.SetupRetryPolicy) *backoff.ExponentialBackOff {
 	b := backoff.NewExponentialBackOff()
 	b.InitialInterval = backoffInterval
+	if policy.BaseDelay > 0 {
+		b.InitialInterval = policy.BaseDelay
+	}
 	// MaxInterval caps the RetryInterval
 	b.MaxInterval = maxBackoffTime
+	if policy.MaxDelay > 0 {
+		b.MaxInterval = policy.MaxDelay
+	}
+	if policy.Jitter > 0 {
+		b.RandomizationFactor = policy.Jitter
+	}
 	// Never stops retrying
 	b.MaxElapsedTime = 0
 	return b
 }
+
+// setupBackOff is the back-off of the E2 setup attempts of a node: it stops after the maximum number of attempts
+// of the retry policy of the node and waits at least the time to wait requested by the RIC in its last failure
+type setupBackOff struct {
+	backoff.BackOff
+	timeToWait time.Duration
+}
+
+func newSetupBackOff(policy model.SetupRetryPolicy) *setupBackOff {
+	return &setupBackOff{BackOff: newRetryBackOff(policy)}
+}
+
+// newRetryBackOff creates the back-off of the attempts of a node which stops after the maximum number of attempts
+// of its retry policy
+func newRetryBackOff(policy model.SetupRetryPolicy) backoff.BackOff {
+	var b backoff.BackOff = newExpBackoff(policy)
+	if policy.MaxAttempts > 0 {
+		// The retries follow the first attempt
+		b = backoff.WithMaxRetries(b, policy.MaxAttempts-1)
+	}
+	return b
+}
+
+// NextBackOff returns the delay before the next attempt
+func (b *setupBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next != backoff.Stop && next < b.timeToWait {
+		next = b.timeToWait
+	}
+	b.timeToWait = 0
+	return next
+}
+
+var timesToWait = map[e2apies.TimeToWait]time.Duration{
+	e2apies.TimeToWait_TIME_TO_WAIT_V1S:  time.Second,
+	e2apies.TimeToWait_TIME_TO_WAIT_V2S:  2 * time.Second,
+	e2apies.TimeToWait_TIME_TO_WAIT_V5S:  5 * time.Second,
+	e2apies.TimeToWait_TIME_TO_WAIT_V10S: 10 * time.Second,
+	e2apies.TimeToWait_TIME_TO_WAIT_V20S: 20 * time.Second,
+	e2apies.TimeToWait_TIME_TO_WAIT_V60S: 60 * time.Second,
+}
+
+// causeString returns the cause as the name of its value
+func causeString(cause *e2apies.Cause) string {
+	switch cause.GetCause().(type) {
+	case *e2apies.Cause_RicRequest:
+		return cause.GetRicRequest().String()
+	case *e2apies.Cause_RicService:
+		return cause.GetRicService().String()
+	case *e2apies.Cause_E2Node:
+		return cause.GetE2Node().String()
+	case *e2apies.Cause_Transport:
+		return cause.GetTransport().String()
+	case *e2apies.Cause_Protocol:
+		return cause.GetProtocol().String()
+	case *e2apies.Cause_Misc:
+		return cause.GetMisc().String()
+	}
+	return "unknown"
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupBackOff(t *testing.T) {
+	b := newSetupBackOff(model.SetupRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	})
+	b.Reset()
+
+	// The time to wait requested by the RIC overrides a shorter delay once
+	b.timeToWait = timesToWait[e2apies.TimeToWait_TIME_TO_WAIT_V5S]
+	assert.Equal(t, 5*time.Second, b.NextBackOff())
+
+	next := b.NextBackOff()
+	assert.NotEqual(t, backoff.Stop, next)
+	assert.LessOrEqual(t, next, time.Second)
+
+	// The node gives up after the maximum number of attempts
+	assert.Equal(t, backoff.Stop, b.NextBackOff())
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	sctpaddressing "github.com/onosproject/onos-lib-go/pkg/sctp/addressing"
+	"github.com/onosproject/onos-lib-go/pkg/sctp/connection"
+	"github.com/onosproject/onos-lib-go/pkg/sctp/types"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// Socket options of the SCTP associations not exposed by the SCTP library
+const (
+	sctpRtoInfo       = 0
+	sctpPeerAddrParam = 9
+	// sppHbEnable enables the heartbeats of the peer addresses
+	sppHbEnable = 1
+)
+
+// rtoInfo is the struct sctp_rtoinfo of the SCTP_RTOINFO socket option; the values are in milliseconds
+type rtoInfo struct {
+	AssocID int32
+	Initial uint32
+	Max     uint32
+	Min     uint32
+}
+
+// peerAddrParams is the packed struct sctp_paddrparams of the SCTP_PEER_ADDR_PARAMS socket option; the unaligned
+// fields are held in byte arrays
+type peerAddrParams struct {
+	AssocID    int32
+	Address    [128]byte
+	HbInterval uint32
+	PathMaxRxt uint16
+	PathMtu    [4]byte
+	SackDelay  [4]byte
+	Flags      [4]byte
+	FlowLabel  [4]byte
+	Dscp       uint8
+	_          uint8
+}
+
+// nativeEndian is the byte order of the socket options
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// NewSCTPTransport returns a transport of the E2 interface over SCTP with the parameters; the association is
+// multi-homed to the additional addresses of the controller, if any
+func NewSCTPTransport(params model.SCTPParams) Transport {
+	return &sctpTransport{
+		params: params,
+	}
+}
+
+type sctpTransport struct {
+	params model.SCTPParams
+}
+
+func (t *sctpTransport) Dial(ctx context.Context, address addressing.RICAddress) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	raddr, err := newAddress(append([]string{address.IPAddress.String()}, t.params.Addresses...), int(address.Port))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connection.NewSCTPConnection(connection.NewConfig(
+		connection.WithAddressFamily(raddr.AddressFamily),
+		connection.WithOptions(types.InitMsg{
+			NumOstreams:  t.params.OutStreams,
+			MaxInstreams: t.params.MaxInStreams,
+			MaxAttempts:  t.params.MaxInitAttempts,
+		}),
+		connection.WithMode(types.OneToOne),
+		connection.WithNonBlocking(false)))
+	if err != nil {
+		return nil, err
+	}
+	if err := t.setup(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// The blocking connect is aborted by shutting the association down when the context is done
+	done := make(chan error, 1)
+	go func() {
+		done <- conn.Connect(raddr)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	case <-ctx.Done():
+		_ = syscall.Shutdown(conn.FD(), syscall.SHUT_RDWR)
+		go func() {
+			<-done
+			_ = conn.Close()
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// setup binds the association to the local addresses and sets the RTO and heartbeat parameters
+func (t *sctpTransport) setup(conn *connection.SCTPConn) error {
+	if len(t.params.LocalAddresses) > 0 {
+		laddr, err := newAddress(t.params.LocalAddresses, 0)
+		if err != nil {
+			return err
+		}
+		if err := conn.Bind(laddr); err != nil {
+			return err
+		}
+	}
+	if t.params.RtoInitial != 0 || t.params.RtoMin != 0 || t.params.RtoMax != 0 {
+		info := rtoInfo{
+			Initial: milliseconds(t.params.RtoInitial),
+			Max:     milliseconds(t.params.RtoMax),
+			Min:     milliseconds(t.params.RtoMin),
+		}
+		if err := setsockopt(conn.FD(), sctpRtoInfo, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+			return err
+		}
+	}
+	if t.params.HeartbeatInterval != 0 {
+		params := peerAddrParams{
+			HbInterval: milliseconds(t.params.HeartbeatInterval),
+		}
+		nativeEndian.PutUint32(params.Flags[:], sppHbEnable)
+		if err := setsockopt(conn.FD(), sctpPeerAddrParam, unsafe.Pointer(&params), unsafe.Sizeof(params)); err != nil {
+			return err
+		}
+	}
+	log.Debugf("SCTP association set up with parameters %+v", t.params)
+	return nil
+}
+
+// newAddress returns the SCTP address of the IP addresses with the port
+func newAddress(ips []string, port int) (*sctpaddressing.Address, error) {
+	address := &sctpaddressing.Address{
+		Port:          port,
+		AddressFamily: types.Sctp4,
+	}
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, errors.NewInvalid("invalid SCTP address %s", s)
+		}
+		if ip.To4() == nil {
+			address.AddressFamily = types.Sctp6
+		}
+		address.IPAddrs = append(address.IPAddrs, net.IPAddr{IP: ip})
+	}
+	return address, nil
+}
+
+func milliseconds(d time.Duration) uint32 {
+	return uint32(d / time.Millisecond)
+}
+
+func setsockopt(fd int, optname uintptr, optval unsafe.Pointer, optlen uintptr) error {
+	_, _, errno := syscall.Syscall6(syscall.SYS_SETSOCKOPT, uintptr(fd), types.SolSctp, optname, uintptr(optval), optlen, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+)
+
+// headerLen is the length of the header framing each message on a TCP stream
+const headerLen = 4
+
+// NewTCPTransport returns a transport of the E2 interface over TCP. Since TCP is a byte stream, each E2AP message
+// is framed by a header carrying its length as a 32-bit integer in network byte order. This framing is specific to
+// the simulator, E2AP over TCP not being standardized: the controller must frame its messages the same way.
+func NewTCPTransport() Transport {
+	return &tcpTransport{}
+}
+
+type tcpTransport struct{}
+
+func (t *tcpTransport) Dial(ctx context.Context, address addressing.RICAddress) (net.Conn, error) {
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address.IPAddress.String(), strconv.FormatUint(address.Port, 10)))
+	if err != nil {
+		return nil, err
+	}
+	return newFramedConn(conn), nil
+}
+
+// framedConn is a TCP connection carrying length-prefixed messages
+type framedConn struct {
+	net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+func newFramedConn(conn net.Conn) *framedConn {
+	return &framedConn{
+		Conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+}
+
+// Write writes the message with its header
+func (c *framedConn) Write(b []byte) (int, error) {
+	frame := make([]byte, headerLen+len(b))
+	binary.BigEndian.PutUint32(frame, uint32(len(b)))
+	copy(frame[headerLen:], b)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.Conn.Write(frame); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Read reads the next message; the message is discarded if it does not fit in the buffer
+func (c *framedConn) Read(b []byte) (int, error) {
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint32(header))
+	if n > len(b) {
+		if _, err := c.reader.Discard(n); err != nil {
+			return 0, err
+		}
+		return 0, io.ErrShortBuffer
+	}
+	return io.ReadFull(c.reader, b[:n])
+}
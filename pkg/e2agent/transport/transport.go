@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"net"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+var log = logging.GetLogger()
+
+const (
+	// SCTP is the transport of the E2 interface specified by O-RAN
+	SCTP = "sctp"
+	// TCP is the transport of the E2 interface for the controllers not supporting SCTP
+	TCP = "tcp"
+)
+
+// Transport establishes the E2 interface of a node with its controller. The connections preserve the boundaries
+// of the messages: each write sends one E2AP message and each read returns one E2AP message.
+type Transport interface {
+	// Dial connects to the controller at the address
+	Dial(ctx context.Context, address addressing.RICAddress) (net.Conn, error)
+}
+
+// NewTransport returns the transport of the E2 interface with the controller
+func NewTransport(controller model.Controller) (Transport, error) {
+	switch controller.Transport {
+	case "", SCTP:
+		return NewSCTPTransport(controller.SCTP), nil
+	case TCP:
+		return NewTCPTransport(), nil
+	default:
+		return nil, errors.NewInvalid("unknown transport %s of controller %s", controller.Transport, controller.ID)
+	}
+}
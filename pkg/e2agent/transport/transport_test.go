@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	liberrors "github.com/onosproject/onos-lib-go/pkg/errors"
+	sctpaddressing "github.com/onosproject/onos-lib-go/pkg/sctp/addressing"
+	"github.com/onosproject/onos-lib-go/pkg/sctp/connection"
+	"github.com/onosproject/onos-lib-go/pkg/sctp/listener"
+	"github.com/onosproject/onos-lib-go/pkg/sctp/types"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr, err := NewTransport(model.Controller{})
+	assert.NoError(t, err)
+	assert.IsType(t, &sctpTransport{}, tr)
+	tr, err = NewTransport(model.Controller{Transport: TCP})
+	assert.NoError(t, err)
+	assert.IsType(t, &tcpTransport{}, tr)
+	_, err = NewTransport(model.Controller{Transport: "quic"})
+	assert.True(t, liberrors.IsInvalid(err))
+
+	// The socket options match the layout of the kernel structs
+	assert.Equal(t, uintptr(16), unsafe.Sizeof(rtoInfo{}))
+	assert.Equal(t, uintptr(156), unsafe.Sizeof(peerAddrParams{}))
+}
+
+func TestTCPTransport(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	// The controller echoes the messages
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		conn := newFramedConn(c)
+		buf := make([]byte, 64)
+		for {
+			n, err := conn.Read(buf)
+			if errors.Is(err, io.ErrShortBuffer) {
+				continue
+			} else if err != nil {
+				return
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return
+			}
+		}
+	}()
+
+	tcpAddr := ln.Addr().(*net.TCPAddr)
+	conn, err := NewTCPTransport().Dial(context.Background(), addressing.RICAddress{IPAddress: tcpAddr.IP, Port: uint64(tcpAddr.Port)})
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// Each read returns one message, even if the messages are written back to back
+	_, err = conn.Write([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	_, err = conn.Write(make([]byte, 100))
+	assert.NoError(t, err)
+	_, err = conn.Write([]byte{4, 5})
+	assert.NoError(t, err)
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+	n, err = conn.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4, 5}, buf[:n])
+}
+
+func TestSCTPTransport(t *testing.T) {
+	laddr := &sctpaddressing.Address{
+		IPAddrs:       []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}},
+		AddressFamily: types.Sctp4,
+	}
+	ln, err := listener.NewListener(laddr, listener.WithMode(types.OneToOne), listener.WithNonBlocking(false))
+	if errors.Is(err, syscall.EPROTONOSUPPORT) {
+		t.Skip("SCTP is not supported by the kernel")
+	}
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		buf := make([]byte, 64)
+		n, err := c.Read(buf)
+		if err != nil {
+			return
+		}
+		_, _ = c.Write(buf[:n])
+	}()
+
+	// The association is multi-homed to a second loopback address of the controller
+	tr := NewSCTPTransport(model.SCTPParams{
+		Addresses:         []string{"127.0.0.2"},
+		OutStreams:        2,
+		MaxInStreams:      2,
+		HeartbeatInterval: time.Second,
+		RtoInitial:        500 * time.Millisecond,
+		RtoMin:            100 * time.Millisecond,
+		RtoMax:            time.Second,
+	})
+	port := ln.LocalAddr().(*sctpaddressing.Address).Port
+	conn, err := tr.Dial(context.Background(), addressing.RICAddress{IPAddress: net.IPv4(127, 0, 0, 1), Port: uint64(port)})
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+
+	// The retransmission timeouts and the heartbeats are set on the association
+	fd := conn.(*connection.SCTPConn).FD()
+	var info rtoInfo
+	assert.NoError(t, getsockopt(fd, sctpRtoInfo, unsafe.Pointer(&info), unsafe.Sizeof(info)))
+	assert.Equal(t, uint32(500), info.Initial)
+	assert.Equal(t, uint32(100), info.Min)
+	assert.Equal(t, uint32(1000), info.Max)
+	var params peerAddrParams
+	assert.NoError(t, getsockopt(fd, sctpPeerAddrParam, unsafe.Pointer(&params), unsafe.Sizeof(params)))
+	assert.Equal(t, uint32(1000), params.HbInterval)
+	assert.NotZero(t, nativeEndian.Uint32(params.Flags[:])&sppHbEnable)
+
+	// The association is not attempted once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tr.Dial(ctx, addressing.RICAddress{IPAddress: net.IPv4(127, 0, 0, 1), Port: uint64(port)})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func getsockopt(fd int, optname uintptr, optval unsafe.Pointer, optlen uintptr) error {
+	length := uint32(optlen)
+	_, _, errno := syscall.Syscall6(syscall.SYS_GETSOCKOPT, uintptr(fd), types.SolSctp, optname, uintptr(optval), uintptr(unsafe.Pointer(&length)), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
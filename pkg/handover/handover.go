@@ -6,11 +6,16 @@ package handover
 
 import (
 	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	"github.com/onosproject/rrm-son-lib/pkg/handover"
 	"github.com/onosproject/rrm-son-lib/pkg/model/device"
+	"github.com/onosproject/rrm-son-lib/pkg/model/id"
 )
 
 var logHoCtrl = logging.GetLogger("handover", "controller")
@@ -18,11 +23,11 @@ var logHoCtrl = logging.GetLogger("handover", "controller")
 // NewHOController returns the hanover controller
 func NewHOController(hoType HOType, cellStore cells.Store, ueStore ues.Store) HOController {
 	return &hoController{
-		hoType:     hoType,
-		cellStore:  cellStore,
-		ueStore:    ueStore,
-		inputChan:  make(chan device.UE),
-		outputChan: make(chan handover.A3HandoverDecision),
+		hoType:    hoType,
+		cellStore: cellStore,
+		ueStore:   ueStore,
+		inputChan: make(chan device.UE),
+		watchers:  make(map[uuid.UUID]*hoWatcher),
 	}
 }
 
@@ -34,8 +39,10 @@ type HOController interface {
 	// GetInputChan returns input channel
 	GetInputChan() chan device.UE
 
-	// GetOutputChan returns output channel
-	GetOutputChan() chan handover.A3HandoverDecision
+	// Watch watches the handover decisions using the supplied channel; only the decisions for the UEs
+	// served by the specified cells are sent, or all decisions if no cell is specified.
+	// The channel is closed when the context is done.
+	Watch(ctx context.Context, ch chan<- handover.A3HandoverDecision, cells ...types.NCGI) error
 }
 
 // HOType is the type of hanover - currently it is string
@@ -43,11 +50,28 @@ type HOController interface {
 type HOType string
 
 type hoController struct {
-	cellStore  cells.Store
-	ueStore    ues.Store
-	hoType     HOType
-	inputChan  chan device.UE
-	outputChan chan handover.A3HandoverDecision
+	cellStore cells.Store
+	ueStore   ues.Store
+	hoType    HOType
+	inputChan chan device.UE
+	mu        sync.RWMutex
+	watchers  map[uuid.UUID]*hoWatcher
+}
+
+// hoWatcher is a consumer of the handover decisions
+type hoWatcher struct {
+	ctx   context.Context
+	ch    chan<- handover.A3HandoverDecision
+	cells map[types.NCGI]bool
+}
+
+// accepts returns true if the decision is for a UE served by one of the cells of the watcher
+func (w *hoWatcher) accepts(hoDecision handover.A3HandoverDecision) bool {
+	if len(w.cells) == 0 {
+		return true
+	}
+	ecgi, ok := hoDecision.ServingCell.GetID().GetID().(id.ECGI)
+	return ok && w.cells[types.NCGI(ecgi)]
 }
 
 func (h *hoController) Start(ctx context.Context) {
@@ -78,7 +102,22 @@ func (h *hoController) forwardReportToA3HandoverHandler(handler A3Handover) {
 func (h *hoController) forwardHandoverDecision(handler A3Handover) {
 	for hoDecision := range handler.GetOutputChan() {
 		logHoCtrl.Debugf("[output] Handover decision: %v", hoDecision)
-		h.outputChan <- hoDecision
+		h.send(hoDecision)
+	}
+}
+
+// send sends the decision to each watcher accepting it; a decision no watcher accepts is dropped
+func (h *hoController) send(hoDecision handover.A3HandoverDecision) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, w := range h.watchers {
+		if !w.accepts(hoDecision) {
+			continue
+		}
+		select {
+		case w.ch <- hoDecision:
+		case <-w.ctx.Done():
+		}
 	}
 }
 
@@ -86,6 +125,26 @@ func (h *hoController) GetInputChan() chan device.UE {
 	return h.inputChan
 }
 
-func (h *hoController) GetOutputChan() chan handover.A3HandoverDecision {
-	return h.outputChan
+func (h *hoController) Watch(ctx context.Context, ch chan<- handover.A3HandoverDecision, cells ...types.NCGI) error {
+	w := &hoWatcher{
+		ctx:   ctx,
+		ch:    ch,
+		cells: make(map[types.NCGI]bool),
+	}
+	for _, ncgi := range cells {
+		w.cells[ncgi] = true
+	}
+	watcherID := uuid.New()
+	h.mu.Lock()
+	h.watchers[watcherID] = w
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		delete(h.watchers, watcherID)
+		h.mu.Unlock()
+		close(ch)
+	}()
+	return nil
 }
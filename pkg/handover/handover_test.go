@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/rrm-son-lib/pkg/handover"
+	"github.com/onosproject/rrm-son-lib/pkg/model/device"
+	"github.com/onosproject/rrm-son-lib/pkg/model/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDecision(servingCell uint64, targetCell uint64) handover.A3HandoverDecision {
+	return handover.A3HandoverDecision{
+		ServingCell: device.NewCell(id.NewECGI(servingCell), 0, 0, 0, 0, 0),
+		TargetCell:  device.NewCell(id.NewECGI(targetCell), 0, 0, 0, 0, 0),
+	}
+}
+
+func receive(t *testing.T, ch <-chan handover.A3HandoverDecision) handover.A3HandoverDecision {
+	select {
+	case hoDecision := <-ch:
+		return hoDecision
+	case <-time.After(time.Second):
+		t.Fatal("handover decision not received")
+	}
+	return handover.A3HandoverDecision{}
+}
+
+func TestWatch(t *testing.T) {
+	h := NewHOController("A3", nil, nil).(*hoController)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch1 := make(chan handover.A3HandoverDecision, 2)
+	ch2 := make(chan handover.A3HandoverDecision, 2)
+	all := make(chan handover.A3HandoverDecision, 3)
+	assert.NoError(t, h.Watch(ctx, ch1, types.NCGI(1)))
+	assert.NoError(t, h.Watch(ctx, ch2, types.NCGI(2), types.NCGI(3)))
+	assert.NoError(t, h.Watch(ctx, all))
+
+	h.send(newDecision(1, 2))
+	h.send(newDecision(3, 1))
+	h.send(newDecision(4, 1))
+
+	assert.Equal(t, id.NewECGI(1), receive(t, ch1).ServingCell.GetID())
+	assert.Len(t, ch1, 0)
+	assert.Equal(t, id.NewECGI(3), receive(t, ch2).ServingCell.GetID())
+	assert.Len(t, ch2, 0)
+	assert.Equal(t, id.NewECGI(1), receive(t, all).ServingCell.GetID())
+	assert.Equal(t, id.NewECGI(3), receive(t, all).ServingCell.GetID())
+
+	// the channels are closed and the decisions dropped once the watchers are gone
+	cancel()
+	_, ok := <-ch1
+	assert.False(t, ok)
+	h.send(newDecision(1, 2))
+}
@@ -8,23 +8,32 @@ import (
 	"context"
 	"time"
 
+	"github.com/onosproject/ran-simulator/pkg/amf"
 	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/onos-lib-go/pkg/northbound"
 	cellapi "github.com/onosproject/ran-simulator/pkg/api/cells"
+	faultapi "github.com/onosproject/ran-simulator/pkg/api/faults"
+	messageapi "github.com/onosproject/ran-simulator/pkg/api/messages"
 	metricsapi "github.com/onosproject/ran-simulator/pkg/api/metrics"
 	modelapi "github.com/onosproject/ran-simulator/pkg/api/model"
 	nodeapi "github.com/onosproject/ran-simulator/pkg/api/nodes"
+	policyapi "github.com/onosproject/ran-simulator/pkg/api/policies"
 	routeapi "github.com/onosproject/ran-simulator/pkg/api/routes"
 	"github.com/onosproject/ran-simulator/pkg/api/trafficsim"
 	ueapi "github.com/onosproject/ran-simulator/pkg/api/ues"
 	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	agentstore "github.com/onosproject/ran-simulator/pkg/store/agents"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/faults"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 )
 
@@ -59,6 +68,7 @@ type Manager struct {
 	modelapi.ManagementDelegate
 	config         Config
 	agents         *agents.E2Agents
+	agentStore     agentstore.Store
 	model          *model.Model
 	server         *northbound.Server
 	nodeStore      nodes.Store
@@ -66,7 +76,12 @@ type Manager struct {
 	ueStore        ues.Store
 	routeStore     routes.Store
 	metricsStore   metrics.Store
+	policyStore    policies.Store
+	messageStore   messages.Store
+	faultStore     faults.Store
+	amf            amf.AMF
 	mobilityDriver mobility.Driver
+	scheduler      scheduler.Scheduler
 }
 
 // Run starts the manager and the associated services
@@ -95,10 +110,10 @@ func (m *Manager) Start() error {
 		return err
 	}
 
-	m.mobilityDriver = mobility.NewMobilityDriver(m.cellStore, m.routeStore, m.ueStore, m.model.APIKey, m.config.HOLogic, m.model.UECountPerCell, m.model.RrcStateChangesDisabled, m.model.WayPointRoute)
-	// TODO: Make initial speeds configurable
-	m.mobilityDriver.GenerateRoutes(context.Background(), 720000, 1080000, 20000, m.model.RouteEndPoints, m.model.DirectRoute)
-	m.mobilityDriver.Start(context.Background())
+	err = m.startUEs()
+	if err != nil {
+		return err
+	}
 
 	// Start E2 agents
 	err = m.startE2Agents()
@@ -114,7 +129,32 @@ func (m *Manager) Close() {
 	log.Info("Closing Manager")
 	m.stopE2Agents()
 	m.stopNorthboundServer()
+	m.stopUEs()
+}
+
+// startUEs starts the AMF, the mobility driver and the scheduler over the UEs and cells of the model stores
+func (m *Manager) startUEs() error {
+	m.amf = amf.NewAMF(m.model, m.ueStore, m.messageStore, m.metricsStore)
+	err := m.amf.Start(context.Background())
+	if err != nil {
+		return err
+	}
+
+	m.mobilityDriver = mobility.NewMobilityDriver(m.cellStore, m.routeStore, m.ueStore, m.messageStore, m.amf, m.model.APIKey, m.config.HOLogic, m.model.UECountPerCell, m.model.RrcStateChangesDisabled, m.model.WayPointRoute)
+	// TODO: Make initial speeds configurable
+	m.mobilityDriver.GenerateRoutes(context.Background(), 720000, 1080000, 20000, m.model.RouteEndPoints, m.model.DirectRoute)
+	m.mobilityDriver.Start(context.Background())
+
+	m.scheduler = scheduler.NewScheduler(m.cellStore, m.ueStore)
+	m.scheduler.Start(context.Background())
+	return nil
+}
+
+// stopUEs stops the AMF, the mobility driver and the scheduler
+func (m *Manager) stopUEs() {
+	m.amf.Stop()
 	m.mobilityDriver.Stop()
+	m.scheduler.Stop()
 }
 
 func (m *Manager) initModelStores() {
@@ -129,6 +169,18 @@ func (m *Manager) initModelStores() {
 
 	// Create an empty route registry
 	m.routeStore = routes.NewRouteRegistry()
+
+	// Create an empty ledger of the RIC policies applied by the nodes
+	m.policyStore = policies.NewPolicyRegistry()
+
+	// Create an empty log of the interface messages exchanged by the nodes
+	m.messageStore = messages.NewMessageLog(messages.DefaultCapacity)
+
+	// Create the registry of the fault profiles of the nodes primed with the pre-loaded profiles
+	m.faultStore = faults.NewFaultRegistry(m.model.Nodes)
+
+	// Create an empty registry of the agents of the nodes
+	m.agentStore = agentstore.NewStore()
 }
 
 func (m *Manager) initMetricStore() {
@@ -147,13 +199,16 @@ func (m *Manager) startNorthboundServer() error {
 		northbound.SecurityConfig{}))
 
 	m.server.AddService(logging.Service{})
-	m.server.AddService(nodeapi.NewService(m.nodeStore, m.model.PlmnID))
+	m.server.AddService(nodeapi.NewService(m.nodeStore, m.agentStore, m.model.PlmnID))
 	m.server.AddService(cellapi.NewService(m.cellStore))
 	m.server.AddService(trafficsim.NewService(m.model, m.cellStore, m.ueStore))
 	m.server.AddService(metricsapi.NewService(m.metricsStore))
 	m.server.AddService(ueapi.NewService(m.ueStore))
 	m.server.AddService(routeapi.NewService(m.routeStore))
 	m.server.AddService(modelapi.NewService(m))
+	m.server.AddService(policyapi.NewService(m.policyStore))
+	m.server.AddService(faultapi.NewService(m.faultStore))
+	m.server.AddService(messageapi.NewService(m.messageStore))
 
 	doneCh := make(chan error)
 	go func() {
@@ -171,7 +226,7 @@ func (m *Manager) startNorthboundServer() error {
 func (m *Manager) startE2Agents() error {
 	// Create the E2 agents for all simulated nodes and specified controllers
 	var err error
-	m.agents, err = agents.NewE2Agents(m.model, m.nodeStore, m.ueStore, m.cellStore, m.metricsStore, m.mobilityDriver.GetHoCtrl().GetOutputChan(), m.mobilityDriver)
+	m.agents, err = agents.NewE2Agents(m.model, m.agentStore, m.nodeStore, m.ueStore, m.cellStore, m.metricsStore, m.policyStore, m.messageStore, m.faultStore, m.mobilityDriver, m.scheduler)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -200,6 +255,10 @@ func (m *Manager) PauseAndClear(ctx context.Context) {
 	m.nodeStore.Clear(ctx)
 	m.cellStore.Clear(ctx)
 	m.metricsStore.Clear(ctx)
+	m.policyStore.Clear(ctx)
+	m.messageStore.Clear(ctx)
+	m.faultStore.Clear(ctx)
+	m.amf.Clear(ctx)
 }
 
 // LoadModel loads the new model into the simulator
@@ -208,8 +267,10 @@ func (m *Manager) LoadModel(ctx context.Context, data []byte) error {
 	if err := model.LoadConfigFromBytes(m.model, data); err != nil {
 		return err
 	}
+	// The AMF, the mobility driver and the scheduler are rebuilt over the stores of the new model
+	m.stopUEs()
 	m.initModelStores()
-	return nil
+	return m.startUEs()
 }
 
 // LoadMetrics loads new metrics into the simulator
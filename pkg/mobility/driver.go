@@ -16,14 +16,18 @@ import (
 	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/amf"
 	"github.com/onosproject/ran-simulator/pkg/handover"
 	"github.com/onosproject/ran-simulator/pkg/measurement"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	"github.com/onosproject/ran-simulator/pkg/utils"
+	rrmhandover "github.com/onosproject/rrm-son-lib/pkg/handover"
 	"github.com/onosproject/rrm-son-lib/pkg/model/id"
 )
 
@@ -49,8 +53,9 @@ type Driver interface {
 	// GetRrcCtrl returns the Rrc Controller
 	GetRrcCtrl() RrcCtrl
 
-	// Handover
-	Handover(ctx context.Context, imsi types.IMSI, tCell *model.UECell)
+	// Handover hands the UE over to the target cell; it fails if the UE is unknown or not connected or if the
+	// target cell is barred
+	Handover(ctx context.Context, imsi types.IMSI, tCell *model.UECell) error
 
 	//GetHoLogic
 	GetHoLogic() string
@@ -58,14 +63,16 @@ type Driver interface {
 	//SetHoLogic
 	SetHoLogic(hoLogic string)
 
-	// AddRrcChan
-	AddRrcChan(ch chan model.UE)
+	// WatchRrcStateChanges watches the RRC state changes of the UEs using the supplied channel
+	WatchRrcStateChanges(ctx context.Context, ch chan<- model.UE) error
 }
 
 type driver struct {
 	cellStore               cells.Store
 	routeStore              routes.Store
 	ueStore                 ues.Store
+	messageStore            messages.Store
+	amf                     amf.AMF
 	apiKey                  string
 	ticker                  *time.Ticker
 	done                    chan bool
@@ -81,12 +88,15 @@ type driver struct {
 	wayPointRoute           bool
 }
 
-// NewMobilityDriver returns a driving engine capable of "driving" UEs along pre-specified routes
-func NewMobilityDriver(cellStore cells.Store, routeStore routes.Store, ueStore ues.Store, apiKey string, hoLogic string, ueCountPerCell uint, rrcStateChangesDisabled bool, wayPointRoute bool) Driver {
+// NewMobilityDriver returns a driving engine capable of "driving" UEs along pre-specified routes; the RRC state
+// changes of the UEs go through the NG procedures of the AMF, if any
+func NewMobilityDriver(cellStore cells.Store, routeStore routes.Store, ueStore ues.Store, messageStore messages.Store, amf amf.AMF, apiKey string, hoLogic string, ueCountPerCell uint, rrcStateChangesDisabled bool, wayPointRoute bool) Driver {
 	return &driver{
 		cellStore:               cellStore,
 		routeStore:              routeStore,
 		ueStore:                 ueStore,
+		messageStore:            messageStore,
+		amf:                     amf,
 		hoLogic:                 hoLogic,
 		rrcCtrl:                 NewRrcCtrl(ueCountPerCell),
 		rrcStateChangesDisabled: rrcStateChangesDisabled,
@@ -165,13 +175,13 @@ func (d *driver) SetHoLogic(hoLogic string) {
 		d.stopLocalHO <- true
 	} else if d.hoLogic == "mho" && hoLogic == "local" {
 		log.Info("Starting local HO")
-		go d.linkMeasCtrlHoCtrl()
+		go d.processHandoverDecision(context.Background())
 	}
 	d.hoLogic = hoLogic
 }
 
-func (d *driver) AddRrcChan(ch chan model.UE) {
-	d.addRrcChan(ch)
+func (d *driver) WatchRrcStateChanges(ctx context.Context, ch chan<- model.UE) error {
+	return d.rrcCtrl.Watch(ctx, ch)
 }
 
 func (d *driver) lockUE(imsi types.IMSI) {
@@ -287,9 +297,19 @@ func (d *driver) linkMeasCtrlHoCtrl() {
 
 func (d *driver) processHandoverDecision(ctx context.Context) {
 	log.Info("Handover decision process starting")
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ch := make(chan rrmhandover.A3HandoverDecision)
+	if err := d.hoCtrl.Watch(watchCtx, ch); err != nil {
+		log.Error(err)
+		return
+	}
 	for {
 		select {
-		case hoDecision := <-d.hoCtrl.GetOutputChan():
+		case hoDecision, ok := <-ch:
+			if !ok {
+				return
+			}
 			log.Debugf("Received HO Decision: %v", hoDecision)
 			imsi := hoDecision.UE.GetID().GetID().(id.UEID).IMSI
 			tCellcgi := hoDecision.TargetCell.GetID().GetID().(id.ECGI)
@@ -297,7 +317,9 @@ func (d *driver) processHandoverDecision(ctx context.Context) {
 				ID:   types.GnbID(tCellcgi),
 				NCGI: types.NCGI(tCellcgi),
 			}
-			d.Handover(ctx, types.IMSI(imsi), tCell)
+			if err := d.Handover(ctx, types.IMSI(imsi), tCell); err != nil {
+				log.Warn(err)
+			}
 		case <-d.stopLocalHO:
 			log.Info("local HO stopped")
 			return
@@ -306,7 +328,7 @@ func (d *driver) processHandoverDecision(ctx context.Context) {
 }
 
 // Handover handovers ue to target cell
-func (d *driver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UECell) {
+func (d *driver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UECell) error {
 	log.Infof("Handover() imsi:%v, tCell:%v", imsi, tCell)
 	d.lockUE(imsi)
 	defer d.unlockUE(imsi)
@@ -314,29 +336,36 @@ func (d *driver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UEC
 	// Update RRC state on handover
 	ue, err := d.ueStore.Get(ctx, imsi)
 	if err != nil {
-		log.Warn("Unable to find UE %d", imsi)
-		return
+		return errors.NewNotFound("unable to find UE %d", imsi)
 	}
 
 	if ue.Cell.NCGI == tCell.NCGI {
 		log.Infof("Duplicate HO skipped imsi%d, cgi:%v", imsi, tCell.NCGI)
-		return
+		return nil
 	}
 
 	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
 		//d.cellStore.DecrementRrcIdleCount(ctx, ue.Cell.NCGI)
 		//d.cellStore.IncrementRrcIdleCount(ctx, tCell.NCGI)
-		log.Warnf("HO skipped for not connected UE %d", imsi)
-		return
+		return errors.NewConflict("HO skipped for not connected UE %d", imsi)
 	}
 
-	d.cellStore.DecrementRrcConnectedCount(ctx, ue.Cell.NCGI)
+	if targetCell, err := d.cellStore.Get(ctx, tCell.NCGI); err == nil && targetCell.Barred {
+		return errors.NewUnavailable("HO skipped for UE %d: target cell %v is barred", imsi, tCell.NCGI)
+	}
+
+	sourceNCGI := ue.Cell.NCGI
+	d.cellStore.DecrementRrcConnectedCount(ctx, sourceNCGI)
 	d.cellStore.IncrementRrcConnectedCount(ctx, tCell.NCGI)
 
 	err = d.ueStore.UpdateCell(ctx, imsi, tCell)
 	if err != nil {
-		log.Warn("Unable to update UE %d cell info", imsi)
+		d.cellStore.DecrementRrcConnectedCount(ctx, tCell.NCGI)
+		d.cellStore.IncrementRrcConnectedCount(ctx, sourceNCGI)
+		log.Warnf("Unable to update UE %d cell info", imsi)
+		return err
 	}
+	d.recordHandoverMessages(ctx, *ue, sourceNCGI, tCell.NCGI)
 
 	// after changing serving cell, calculate channel quality/signal strength again
 	d.updateUESignalStrength(ctx, imsi)
@@ -345,6 +374,7 @@ func (d *driver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UEC
 	d.ueStore.UpdateMaxUEsPerCell(ctx)
 
 	log.Infof("HO is done successfully: %v to %v", imsi, tCell)
+	return nil
 }
 
 // UpdateUESignalStrength updates UE signal strength
@@ -385,7 +415,7 @@ func (d *driver) updateUESignalStrengthCandServCells(ctx context.Context, ue *mo
 		if math.IsNaN(rsrp) {
 			continue
 		}
-		if ue.Cell.NCGI == cell.NCGI {
+		if ue.Cell.NCGI == cell.NCGI || cell.Barred {
 			continue
 		}
 		ueCell := &model.UECell{
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	f1apiesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_ies"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/f1ap"
+	"github.com/onosproject/ran-simulator/pkg/utils/xnap"
+)
+
+const (
+	defaultFiveQI       = 9
+	defaultNrCellIDLen  = uint32(36)
+	defaultAMFRegionLen = uint32(8)
+	// defaultDrbID is the DRB of the UE whose PDCP status is transferred
+	defaultDrbID = int32(1)
+)
+
+var (
+	// slice and AMF region advertised by the nodes in their Xn setup
+	defaultSD             = []byte{0x01, 0x23, 0x45}
+	defaultSST            = []byte{0x01}
+	defaultAMFRegionValue = []byte{0xdd}
+	defaultTransportIPv4  = []byte{0x7f, 0x00, 0x00, 0x01}
+)
+
+// recordHandoverMessages records the interface messages exchanged by the nodes when a UE is handed over
+// from a cell of one node to a cell of another node: the source node requests the handover over Xn, the
+// target node acknowledges it, the source node transfers the PDCP status of the UE, the target node releases
+// the UE context at the source and the source gNB-CU releases the UE context at its gNB-DU. Handovers between the cells of one node are not visible on these interfaces.
+// TODO: the NGAP Path Switch Request is not recorded since NGAP is not encoded by the simulator
+func (d *driver) recordHandoverMessages(ctx context.Context, ue model.UE, sourceNCGI types.NCGI, targetNCGI types.NCGI) {
+	if d.messageStore == nil {
+		return
+	}
+	sourceGnbID := types.GetGnbID(uint64(sourceNCGI))
+	targetGnbID := types.GetGnbID(uint64(targetNCGI))
+	if sourceGnbID == targetGnbID {
+		return
+	}
+
+	ueID := int64(ue.CRNTI)
+	fiveQI := int32(ue.FiveQi)
+	if fiveQI == 0 {
+		fiveQI = defaultFiveQI
+	}
+	handoverRequest, err := xnap.CreateHandoverRequest(xnap.XnItemHandoverRequest{
+		PlmnIDBytes:          types.NewUint24(uint32(types.GetPlmnID(uint64(sourceNCGI)))).ToBytes(),
+		SourceUeXnApID:       ueID,
+		SourceNrCellIDBytes:  utils.NewNCellIDWithUint64(uint64(types.GetNCI(sourceNCGI))).Bytes(),
+		TargetNrCellIDBytes:  utils.NewNCellIDWithUint64(uint64(types.GetNCI(targetNCGI))).Bytes(),
+		NrCellIDLen:          defaultNrCellIDLen,
+		AmfRegion:            xnap.XnItemAMFRegion{AmfRegionID: defaultAMFRegionValue, AmfRegionIDLen: defaultAMFRegionLen},
+		AmfUeNgapID:          int64(ue.AmfUeNgapID),
+		Slice:                xnap.XnItemSlice{Sst: defaultSST, Sd: defaultSD},
+		FiveQI:               fiveQI,
+		TransportAddressIPv4: defaultTransportIPv4,
+	})
+	if err != nil {
+		log.Warnf("Unable to encode Xn handover request of UE %d: %v", ue.IMSI, err)
+		return
+	}
+	d.recordXnMessage(ctx, ue.IMSI, sourceGnbID, targetGnbID, sourceNCGI, messages.HandoverRequest, handoverRequest)
+
+	handoverRequestAcknowledge, err := xnap.CreateHandoverRequestAcknowledge(ueID, ueID)
+	if err != nil {
+		log.Warnf("Unable to encode Xn handover request acknowledge of UE %d: %v", ue.IMSI, err)
+		return
+	}
+	d.recordXnMessage(ctx, ue.IMSI, targetGnbID, sourceGnbID, targetNCGI, messages.HandoverRequestAcknowledge, handoverRequestAcknowledge)
+
+	snStatusTransfer, err := xnap.CreateSNStatusTransfer(ueID, ueID, []xnap.XnItemDrbStatus{{DrbID: defaultDrbID}})
+	if err != nil {
+		log.Warnf("Unable to encode Xn SN status transfer of UE %d: %v", ue.IMSI, err)
+		return
+	}
+	d.recordXnMessage(ctx, ue.IMSI, sourceGnbID, targetGnbID, sourceNCGI, messages.SNStatusTransfer, snStatusTransfer)
+
+	ueContextRelease, err := xnap.CreateUEContextRelease(ueID, ueID)
+	if err != nil {
+		log.Warnf("Unable to encode Xn UE context release of UE %d: %v", ue.IMSI, err)
+		return
+	}
+	d.recordXnMessage(ctx, ue.IMSI, targetGnbID, sourceGnbID, targetNCGI, messages.UEContextRelease, ueContextRelease)
+
+	ueContextReleaseCommand, err := f1ap.CreateUEContextReleaseCommand(ueID, ueID, f1apiesv1.CauseRadioNetwork_CAUSE_RADIO_NETWORK_NORMAL_RELEASE)
+	if err != nil {
+		log.Warnf("Unable to encode F1 UE context release command of UE %d: %v", ue.IMSI, err)
+		return
+	}
+	d.messageStore.Add(ctx, &messages.Message{
+		GnbID:     sourceGnbID,
+		Interface: messages.F1,
+		Direction: messages.Outgoing,
+		Procedure: messages.UEContextReleaseCommand,
+		IMSI:      ue.IMSI,
+		NCGI:      sourceNCGI,
+		Payload:   ueContextReleaseCommand,
+	})
+}
+
+// recordXnMessage records an Xn message as sent by one node and as received by its peer
+func (d *driver) recordXnMessage(ctx context.Context, imsi types.IMSI, from types.GnbID, to types.GnbID, ncgi types.NCGI, procedure string, payload []byte) {
+	d.messageStore.Add(ctx, &messages.Message{
+		GnbID:     from,
+		PeerGnbID: to,
+		Interface: messages.Xn,
+		Direction: messages.Outgoing,
+		Procedure: procedure,
+		IMSI:      imsi,
+		NCGI:      ncgi,
+		Payload:   payload,
+	})
+	d.messageStore.Add(ctx, &messages.Message{
+		GnbID:     to,
+		PeerGnbID: from,
+		Interface: messages.Xn,
+		Direction: messages.Incoming,
+		Procedure: procedure,
+		IMSI:      imsi,
+		NCGI:      ncgi,
+		Payload:   payload,
+	})
+}
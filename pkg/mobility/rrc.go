@@ -6,10 +6,14 @@ package mobility
 
 import (
 	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/ran-simulator/pkg/model"
-	"math/rand"
 )
 
 // RrcStateChangeProbability determines the rate of change of RRC states in ransim
@@ -24,10 +28,25 @@ var RrcStateChangeVariance = 0.9
 // UeCountPerCellDefault is the default number of RRC Connected UEs per cell
 var UeCountPerCellDefault uint = 15
 
+// rrcWatcherBufferSize is the number of RRC state changes buffered for a watcher; the changes a slow watcher
+// cannot take are dropped rather than stalling the mobility driver
+const rrcWatcherBufferSize = 1000
+
 // RrcCtrl is the RRC controller
 type RrcCtrl struct {
-	rrcUpdateChan  chan model.UE
 	ueCountPerCell uint
+	watchers       *rrcWatchers
+}
+
+// rrcWatchers are the consumers of the RRC state changes of the UEs
+type rrcWatchers struct {
+	mu       sync.RWMutex
+	watchers map[uuid.UUID]*rrcWatcher
+	dropped  uint64
+}
+
+type rrcWatcher struct {
+	buffer chan model.UE
 }
 
 // NewRrcCtrl returns a new RRC Controller
@@ -37,11 +56,67 @@ func NewRrcCtrl(ueCountPerCell uint) RrcCtrl {
 	}
 	return RrcCtrl{
 		ueCountPerCell: ueCountPerCell,
+		watchers: &rrcWatchers{
+			watchers: make(map[uuid.UUID]*rrcWatcher),
+		},
 	}
 }
 
-func (d *driver) addRrcChan(ch chan model.UE) {
-	d.rrcCtrl.rrcUpdateChan = ch
+// Watch watches the RRC state changes of the UEs using the supplied channel; each change is sent as a copy
+// of the UE in its new RRC state. The changes the watcher falls too far behind to take are dropped.
+// The channel is closed when the context is done.
+func (r RrcCtrl) Watch(ctx context.Context, ch chan<- model.UE) error {
+	watcherID := uuid.New()
+	w := &rrcWatcher{buffer: make(chan model.UE, rrcWatcherBufferSize)}
+	r.watchers.mu.Lock()
+	r.watchers.watchers[watcherID] = w
+	r.watchers.mu.Unlock()
+
+	go func() {
+		defer func() {
+			r.watchers.mu.Lock()
+			delete(r.watchers.watchers, watcherID)
+			r.watchers.mu.Unlock()
+			close(ch)
+		}()
+		for {
+			select {
+			case update := <-w.buffer:
+				select {
+				case ch <- update:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Dropped returns the number of RRC state changes dropped for the watchers falling behind
+func (r RrcCtrl) Dropped() uint64 {
+	return atomic.LoadUint64(&r.watchers.dropped)
+}
+
+// send sends the RRC state change of the UE to all watchers without waiting for them
+func (r RrcCtrl) send(ue *model.UE) {
+	update := *ue
+	if ue.Cell != nil {
+		cell := *ue.Cell
+		update.Cell = &cell
+	}
+	r.watchers.mu.RLock()
+	defer r.watchers.mu.RUnlock()
+	for _, w := range r.watchers.watchers {
+		select {
+		case w.buffer <- update:
+		default:
+			dropped := atomic.AddUint64(&r.watchers.dropped, 1)
+			log.Debugf("RRC state change of UE %d is dropped for a watcher falling behind, %d dropped", ue.IMSI, dropped)
+		}
+	}
 }
 
 func (d *driver) totalUeCount(ctx context.Context, ncgi types.NCGI) uint {
@@ -62,10 +137,15 @@ func (d *driver) connectedUeCount(ctx context.Context, ncgi types.NCGI) uint {
 	return uint(cell.RrcConnectedCount)
 }
 
+// paged returns whether the UE is paged by the AMF
+func (d *driver) paged(imsi types.IMSI) bool {
+	return d.amf != nil && d.amf.Paged(imsi)
+}
+
 func (d *driver) updateRrc(ctx context.Context, imsi types.IMSI) {
 	var rrcStateChanged bool
 
-	if rand.Float64() < RrcStateChangeProbability {
+	if rand.Float64() < RrcStateChangeProbability || d.paged(imsi) {
 		ue, err := d.ueStore.Get(ctx, imsi)
 		if err != nil {
 			log.Error(err)
@@ -80,9 +160,8 @@ func (d *driver) updateRrc(ctx context.Context, imsi types.IMSI) {
 			return
 		}
 
-		if err == nil && d.hoLogic != "local" && rrcStateChanged && d.rrcCtrl.rrcUpdateChan != nil {
-			// TODO - check subscription for RRC state changes
-			d.rrcCtrl.rrcUpdateChan <- *ue
+		if err == nil && rrcStateChanged {
+			d.rrcCtrl.send(ue)
 		}
 	}
 }
@@ -149,6 +228,12 @@ func (d *driver) rrcIdle(ctx context.Context, imsi types.IMSI, p float64) (bool,
 		rrcStateChanged = true
 	}
 
+	if rrcStateChanged && d.amf != nil {
+		if err := d.amf.Release(ctx, imsi); err != nil {
+			return false, err
+		}
+	}
+
 	if rrcStateChanged {
 		log.Infof("RRC state change imsi:%d from CONNECTED to IDLE", imsi)
 		ue.RrcState = mho.Rrcstatus_RRCSTATUS_IDLE
@@ -168,7 +253,10 @@ func (d *driver) rrcConnected(ctx context.Context, imsi types.IMSI, p float64) (
 		return false, err
 	}
 
-	if d.totalUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
+	// The UEs paged by the AMF connect regardless of the load of their cell
+	if d.paged(imsi) {
+		rrcStateChanged = true
+	} else if d.totalUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
 		r := rand.Float64()
 		if d.connectedUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
 			if r < 1-p {
@@ -183,6 +271,14 @@ func (d *driver) rrcConnected(ctx context.Context, imsi types.IMSI, p float64) (
 		rrcStateChanged = true
 	}
 
+	// The UE only connects once the AMF accepts its service request
+	if rrcStateChanged && d.amf != nil {
+		if err := d.amf.Connect(ctx, imsi); err != nil {
+			log.Infof("RRC state change imsi:%d from IDLE to CONNECTED failed: %v", imsi, err)
+			return false, err
+		}
+	}
+
 	if rrcStateChanged {
 		log.Infof("RRC state change imsi:%d from IDLE to CONNECTED", imsi)
 		ue.RrcState = mho.Rrcstatus_RRCSTATUS_CONNECTED
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRrcWatch(t *testing.T) {
+	rrcCtrl := NewRrcCtrl(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch1 := make(chan model.UE, 1)
+	ch2 := make(chan model.UE, 1)
+	assert.NoError(t, rrcCtrl.Watch(ctx, ch1))
+	assert.NoError(t, rrcCtrl.Watch(ctx, ch2))
+
+	ue := &model.UE{IMSI: 1, RrcState: mho.Rrcstatus_RRCSTATUS_CONNECTED, Cell: &model.UECell{NCGI: 2}}
+	rrcCtrl.send(ue)
+	ue.RrcState = mho.Rrcstatus_RRCSTATUS_IDLE
+	ue.Cell.NCGI = 3
+
+	// each watcher receives a copy of the UE in the state it had when the change was sent
+	for _, ch := range []chan model.UE{ch1, ch2} {
+		update := <-ch
+		assert.Equal(t, mho.Rrcstatus_RRCSTATUS_CONNECTED, update.RrcState)
+		assert.Equal(t, uint64(2), uint64(update.Cell.NCGI))
+	}
+
+	cancel()
+	_, ok := <-ch1
+	assert.False(t, ok)
+}
+
+func TestRrcWatchSlowWatcher(t *testing.T) {
+	rrcCtrl := NewRrcCtrl(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slow := make(chan model.UE)
+	assert.NoError(t, rrcCtrl.Watch(ctx, slow))
+
+	// the changes the watcher does not take are dropped without blocking the sender
+	for i := 0; i < rrcWatcherBufferSize+10; i++ {
+		rrcCtrl.send(&model.UE{IMSI: types.IMSI(i)})
+	}
+	assert.GreaterOrEqual(t, rrcCtrl.Dropped(), uint64(9))
+	assert.LessOrEqual(t, rrcCtrl.Dropped(), uint64(10))
+
+	// the buffered changes are delivered in order
+	assert.Equal(t, types.IMSI(0), (<-slow).IMSI)
+	assert.Equal(t, types.IMSI(1), (<-slow).IMSI)
+}
@@ -13,12 +13,19 @@ import (
 // powerFactor relates power to distance in decimal degrees
 const powerFactor = 0.001
 
+// antennaHeightKM is the height of the cell antennas above the UEs
+const antennaHeightKM = 0.03
+
+// verticalBeamwidth is the 3dB vertical beamwidth of the cell antennas in degrees
+const verticalBeamwidth = 10.0
+
 // StrengthAtLocation returns the signal strength at location relative to the specified cell.
 func StrengthAtLocation(coord model.Coordinate, cell model.Cell) float64 {
 	distAtt := distanceAttenuation(coord, cell)
 	angleAtt := angleAttenuation(coord, cell)
+	tiltAtt := tiltAttenuation(coord, cell)
 	pathLoss := getPathLoss(coord, cell)
-	return cell.TxPowerDB + distAtt + angleAtt - pathLoss
+	return cell.TxPowerDB + distAtt + angleAtt + tiltAtt - pathLoss
 }
 
 // distanceAttenuation is the antenna Gain as a function of the dist
@@ -53,6 +60,20 @@ func angleAttenuation(coord model.Coordinate, cell model.Cell) float64 {
 	return -math.Min(12*math.Pow((angularOffset/(math.Pi*2/3)/angleScaling), 2), 30)
 }
 
+// tiltAttenuation is the change in power reaching a UE when the antenna is electrically tilted
+// down by the cell tilt, relative to the untilted antenna the rest of the model is based on.
+// A downtilt moves the main lobe of the vertical radiation pattern closer to the cell, trading
+// coverage at the cell edge for signal strength near the antenna.
+func tiltAttenuation(coord model.Coordinate, cell model.Cell) float64 {
+	elevation := math.Atan2(antennaHeightKM, getEuclianDistanceFromGPS(coord, cell)) * 180 / math.Pi
+	return verticalAttenuation(elevation-cell.Tilt) - verticalAttenuation(elevation)
+}
+
+// verticalAttenuation is the attenuation in dB at the given angle off the vertical beam centre
+func verticalAttenuation(angle float64) float64 {
+	return -math.Min(12*math.Pow(angle/verticalBeamwidth, 2), 20)
+}
+
 func getPathLoss(coord model.Coordinate, cell model.Cell) float64 {
 	return getFreeSpacePathLoss(coord, cell)
 }
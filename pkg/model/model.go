@@ -5,6 +5,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
@@ -28,6 +30,7 @@ type Model struct {
 	PlmnID                  types.PlmnID            `mapstructure:"plmnNumber" yaml:"plmnNumber"` // overridden and derived post-load from "Plmn" field
 	APIKey                  string                  `mapstructure:"apiKey" yaml:"apiKey"`         // Google Maps API key (optional)
 	Guami                   Guami                   `mapstructure:"guami" yaml:"guami"`
+	AMF                     AMF                     `mapstructure:"amf" yaml:"amf"`
 }
 
 // Coordinate represents a geographical location
@@ -64,11 +67,84 @@ type Route struct {
 
 // Node e2 node
 type Node struct {
-	GnbID         types.GnbID  `mapstructure:"gnbid"`
-	Controllers   []string     `mapstructure:"controllers"`
-	ServiceModels []string     `mapstructure:"servicemodels"`
-	Cells         []types.NCGI `mapstructure:"cells"`
-	Status        string       `mapstructure:"status"`
+	GnbID         types.GnbID      `mapstructure:"gnbid"`
+	Controllers   []string         `mapstructure:"controllers"`
+	ServiceModels []string         `mapstructure:"servicemodels"`
+	Cells         []types.NCGI     `mapstructure:"cells"`
+	Status        string           `mapstructure:"status"`
+	SetupRetry    SetupRetryPolicy `mapstructure:"setupretry"`
+	Faults        FaultProfile     `mapstructure:"faults"`
+	// GnbDUID is the gNB-DU ID of the node in its F1 setup
+	GnbDUID uint64 `mapstructure:"gnbduid"`
+	// TAC and ServedPlmns are the tracking area code and the PLMNs and slices served by the cells of the node,
+	// unless set by the cells themselves
+	TAC         uint32       `mapstructure:"tac"`
+	ServedPlmns []ServedPlmn `mapstructure:"servedplmns"`
+	// AmfRegionID is the AMF region the node advertises in its Xn setup
+	AmfRegionID uint8 `mapstructure:"amfregionid"`
+	// IndicationBudget limits the rate of the indications sent by the node
+	IndicationBudget IndicationBudget `mapstructure:"indicationbudget"`
+	// ConnectionStates are the states of the E2 connections of the node, by controller
+	ConnectionStates map[string]ConnectionState `mapstructure:"connectionstates"`
+}
+
+// ConnectionState is the state of the E2 connection of a node to one of its controllers
+type ConnectionState struct {
+	// Phase is the phase of the last connection attempt
+	Phase string `mapstructure:"phase"`
+	// SetupFailureCause is the cause of the last setup failure
+	SetupFailureCause string `mapstructure:"setupfailurecause"`
+}
+
+// FaultProfile is the profile of the faults injected on the E2 interface of a node; the rates are the
+// probabilities, between 0 and 1, of injecting the fault in a message
+type FaultProfile struct {
+	// IndicationDelay delays every indication
+	IndicationDelay time.Duration `mapstructure:"indicationdelay"`
+	DropRate        float64       `mapstructure:"droprate"`
+	DuplicateRate   float64       `mapstructure:"duplicaterate"`
+	// ReorderRate is the rate of the indications held back and sent after the next indication, or flushed after a
+	// second or when their subscription is deleted
+	ReorderRate float64 `mapstructure:"reorderrate"`
+	// CorruptRate is the rate of the indications whose encoded E2AP message is corrupted
+	CorruptRate float64 `mapstructure:"corruptrate"`
+	// SubscriptionRejectCause is the name of the E2AP cause rejecting every subscription, e.g. CAUSE_MISC_OM_INTERVENTION
+	SubscriptionRejectCause string  `mapstructure:"subscriptionrejectcause"`
+	ControlFailureRate      float64 `mapstructure:"controlfailurerate"`
+}
+
+// IndicationBudget is the indication budget of a node; the rates are in indications per second and are unlimited
+// if not set
+type IndicationBudget struct {
+	// SubscriptionRate is the rate of the indications of each subscription
+	SubscriptionRate  float64 `mapstructure:"subscriptionrate"`
+	SubscriptionBurst int     `mapstructure:"subscriptionburst"`
+	// NodeRate is the rate of the indications of all the subscriptions of the node
+	NodeRate  float64 `mapstructure:"noderate"`
+	NodeBurst int     `mapstructure:"nodeburst"`
+	// Overflow is the behaviour of the indications exceeding the budget: "drop" (default) or "coalesce"
+	Overflow string `mapstructure:"overflow"`
+}
+
+// SetupRetryPolicy is the retry policy of the E2 connection and setup of a node; zero values select the defaults
+type SetupRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts; the node retries forever if not set
+	MaxAttempts uint64 `mapstructure:"maxattempts"`
+	// BaseDelay is the delay before the first retry, which grows exponentially up to MaxDelay
+	BaseDelay time.Duration `mapstructure:"basedelay"`
+	MaxDelay  time.Duration `mapstructure:"maxdelay"`
+	// Jitter is the randomization factor of the delays, between 0 and 1
+	Jitter float64 `mapstructure:"jitter"`
+}
+
+// AMF is the profile of the AMF simulated for the nodes; zero values select the defaults
+type AMF struct {
+	// Latency is the time taken by each NGAP message exchanged with the AMF; a connection takes three messages
+	Latency time.Duration `mapstructure:"latency"`
+	// PagingRate is the rate, between 0 and 1, of the IDLE UEs paged by the AMF every second
+	PagingRate float64 `mapstructure:"pagingrate"`
+	// FailureRate is the rate, between 0 and 1, of the UE connections rejected by the AMF
+	FailureRate float64 `mapstructure:"failurerate"`
 }
 
 // Controller E2T endpoint information
@@ -76,6 +152,27 @@ type Controller struct {
 	ID      string `mapstructure:"id"`
 	Address string `mapstructure:"address"`
 	Port    int    `mapstructure:"port"`
+	// Transport is the transport of the E2 interface to the controller: "sctp" (default) or "tcp"; over TCP, each
+	// E2AP message is framed by a 4-byte length header private to the simulator, which the controller must implement
+	Transport string     `mapstructure:"transport"`
+	SCTP      SCTPParams `mapstructure:"sctp"`
+}
+
+// SCTPParams are the parameters of the SCTP associations of the nodes with a controller; zero values select the
+// defaults of the kernel
+type SCTPParams struct {
+	// Addresses are the additional IP addresses of the controller the associations are multi-homed to
+	Addresses []string `mapstructure:"addresses"`
+	// LocalAddresses are the IP addresses the associations are bound to on the node side
+	LocalAddresses []string `mapstructure:"localaddresses"`
+	OutStreams     uint16   `mapstructure:"outstreams"`
+	MaxInStreams   uint16   `mapstructure:"maxinstreams"`
+	// MaxInitAttempts is the maximum number of retransmissions of the INIT chunk
+	MaxInitAttempts   uint16        `mapstructure:"maxinitattempts"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeatinterval"`
+	RtoInitial        time.Duration `mapstructure:"rtoinitial"`
+	RtoMin            time.Duration `mapstructure:"rtomin"`
+	RtoMax            time.Duration `mapstructure:"rtomax"`
 }
 
 // MeasurementParams has measurement parameters
@@ -112,11 +209,46 @@ type Cell struct {
 	MeasurementParams MeasurementParams `mapstructure:"measurementParams"`
 	PCI               uint32            `mapstructure:"pci"`
 	Earfcn            uint32            `mapstructure:"earfcn"`
+	Bandwidth         uint32            `mapstructure:"bandwidth"` // channel bandwidth in MHz
+	Tilt              float64           `mapstructure:"tilt"`      // electrical antenna downtilt in degrees
+	Barred            bool              `mapstructure:"barred"`
 	CellType          types.CellType    `mapstructure:"cellType"`
+	RrmPolicyRatios   []RrmPolicyRatio  `mapstructure:"rrmPolicyRatios"`
+	FrequencyBand     uint32            `mapstructure:"frequencyband"`     // NR operating band
+	SulFrequencyBand  uint32            `mapstructure:"sulfrequencyband"`  // NR supplementary uplink band
+	SubcarrierSpacing uint32            `mapstructure:"subcarrierspacing"` // subcarrier spacing in kHz
+	TAC               uint32            `mapstructure:"tac"`
+	ServedPlmns       []ServedPlmn      `mapstructure:"servedplmns"`
 	RrcIdleCount      uint32
 	RrcConnectedCount uint32
 }
 
+// RrmPolicyRatio is the share of the cell PRBs allocated to a slice
+type RrmPolicyRatio struct {
+	Sst               uint32 `mapstructure:"sst"`
+	Sd                uint32 `mapstructure:"sd"`
+	MinPrbRatio       int32  `mapstructure:"minPrbRatio"`
+	MaxPrbRatio       int32  `mapstructure:"maxPrbRatio"`
+	DedicatedPrbRatio int32  `mapstructure:"dedicatedPrbRatio"`
+}
+
+// ServedPlmn is a PLMN served by a cell with the slices it supports
+type ServedPlmn struct {
+	Plmn   string  `mapstructure:"plmnid"`
+	Slices []Slice `mapstructure:"slices"`
+}
+
+// PlmnID returns the numeric PLMN ID of the served PLMN
+func (p ServedPlmn) PlmnID() types.PlmnID {
+	return types.PlmnIDFromString(p.Plmn)
+}
+
+// Slice is a network slice identified by its S-NSSAI; the slice differentiator is not set if zero
+type Slice struct {
+	Sst uint32 `mapstructure:"sst"`
+	Sd  uint32 `mapstructure:"sd"`
+}
+
 // UEType represents type of user-equipment
 type UEType string
 
@@ -127,15 +259,25 @@ type UECell struct {
 	Strength float64
 }
 
+// GnbCuUeF1apID is the gNB-CU UE F1AP ID of a UE
+type GnbCuUeF1apID uint32
+
+// RanUeID is the RAN UE ID of a UE
+type RanUeID uint64
+
 // UE represents user-equipment, i.e. phone, IoT device, etc.
 type UE struct {
-	IMSI        types.IMSI
-	AmfUeNgapID types.AmfUENgapID
-	Type        UEType
-	RrcState    e2sm_mho.Rrcstatus
-	Location    Coordinate
-	Heading     uint32
-	FiveQi      int
+	IMSI types.IMSI
+	// AmfUeNgapID and Guami are assigned by the AMF when the UE registers; the UE is not registered until then
+	AmfUeNgapID   types.AmfUENgapID
+	Guami         Guami
+	GnbCuUeF1apID GnbCuUeF1apID
+	RanUeID       RanUeID
+	Type          UEType
+	RrcState      e2sm_mho.Rrcstatus
+	Location      Coordinate
+	Heading       uint32
+	FiveQi        int
 
 	Cell  *UECell
 	CRNTI types.CRNTI
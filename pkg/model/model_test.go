@@ -7,6 +7,7 @@ package model
 import (
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,6 +22,12 @@ func TestModel(t *testing.T) {
 	assert.Equal(t, 4, len(model.Cells))
 	assert.Equal(t, 36421, model.Controllers["controller1"].Port)
 	assert.Equal(t, 36421, model.Controllers["controller2"].Port)
+	assert.Equal(t, "", model.Controllers["controller1"].Transport)
+	assert.Equal(t, "sctp", model.Controllers["controller2"].Transport)
+	assert.Equal(t, []string{"10.0.0.2"}, model.Controllers["controller2"].SCTP.Addresses)
+	assert.Equal(t, uint16(2), model.Controllers["controller2"].SCTP.OutStreams)
+	assert.Equal(t, 5*time.Second, model.Controllers["controller2"].SCTP.HeartbeatInterval)
+	assert.Equal(t, 500*time.Millisecond, model.Controllers["controller2"].SCTP.RtoMin)
 	assert.Equal(t, "1.0.0", model.ServiceModels["kpm"].Version)
 	assert.Equal(t, 3, model.ServiceModels["rc"].ID)
 	assert.Equal(t, 2, model.ServiceModels["ni"].ID)
@@ -28,8 +35,22 @@ func TestModel(t *testing.T) {
 	assert.Equal(t, "314628", model.Plmn)
 	assert.Equal(t, types.PlmnID(0x314628), model.PlmnID)
 
+	assert.Equal(t, 5*time.Millisecond, model.AMF.Latency)
+	assert.Equal(t, 0.5, model.AMF.PagingRate)
+	assert.Equal(t, 0.01, model.AMF.FailureRate)
+
 	assert.Equal(t, types.NCGI(84325717761), model.Cells["cell3"].NCGI)
 	assert.Equal(t, 2, len(model.Nodes["node1"].Cells))
+	assert.Equal(t, uint64(5), model.Nodes["node2"].SetupRetry.MaxAttempts)
+	assert.Equal(t, time.Second, model.Nodes["node2"].SetupRetry.BaseDelay)
+	assert.Equal(t, 30*time.Second, model.Nodes["node2"].SetupRetry.MaxDelay)
+	assert.Equal(t, uint64(42), model.Nodes["node2"].GnbDUID)
+	assert.Equal(t, uint32(7), model.Nodes["node2"].TAC)
+	assert.Equal(t, uint8(202), model.Nodes["node2"].AmfRegionID)
+	assert.Equal(t, types.PlmnID(0x314628), model.Nodes["node2"].ServedPlmns[0].PlmnID())
+	assert.Equal(t, []Slice{{Sst: 1, Sd: 1}, {Sst: 2}}, model.Nodes["node2"].ServedPlmns[0].Slices)
+	assert.Equal(t, uint32(78), model.Cells["cell1"].FrequencyBand)
+	assert.Equal(t, uint32(30), model.Cells["cell1"].SubcarrierSpacing)
 	assert.Equal(t, 44.0, model.Cells["cell3"].Sector.Center.Lat)
 
 	assert.Equal(t, true, model.MapLayout.FadeMap)
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"math"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// The link model is a rough approximation of an NR carrier with 15 kHz sub-carrier spacing: one slot
+// per millisecond, 12 sub-carriers per PRB and the CQI table 1 of 3GPP TS 38.214.
+
+const (
+	// noiseFloorDBm is the noise power seen by the UEs
+	noiseFloorDBm = -100.0
+	// minSINR and maxSINR bound the SINR of the UEs in dB
+	minSINR = -10.0
+	maxSINR = 30.0
+	// fadingStdDev is the standard deviation of the SINR of a slot around the mean SINR in dB
+	fadingStdDev = 2.0
+	// resourceElementsPerPRB is the number of resource elements of a PRB carrying data in a slot
+	resourceElementsPerPRB = 144
+	// defaultBandwidth is the channel bandwidth of the cells that do not define one, in MHz
+	defaultBandwidth = 20
+)
+
+// spectralEfficiency is the spectral efficiency of each CQI in bits per resource element
+var spectralEfficiency = [...]float64{0, 0.1523, 0.2344, 0.3770, 0.6016, 0.8770, 1.1758, 1.4766,
+	1.9141, 2.4063, 2.7305, 3.3223, 3.9023, 4.5234, 5.1152, 5.5547}
+
+// mcsIndex is the MCS index of the MCS table 1 of 3GPP TS 38.214 used for each CQI
+var mcsIndex = [...]int{0, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28}
+
+// prbsPerBandwidth is the number of PRBs of each channel bandwidth in MHz
+var prbsPerBandwidth = map[uint32]int{5: 25, 10: 52, 15: 79, 20: 106, 25: 133, 30: 160, 40: 216, 50: 270}
+
+// numberOfPRBs returns the number of PRBs of the carrier of the cell
+func numberOfPRBs(cell *model.Cell) int {
+	bandwidth := cell.Bandwidth
+	if bandwidth == 0 {
+		bandwidth = defaultBandwidth
+	}
+	if prbs, ok := prbsPerBandwidth[bandwidth]; ok {
+		return prbs
+	}
+	// 15 kHz sub-carrier spacing with 10% guard band
+	return int(float64(bandwidth) * 1000 * 0.9 / 180)
+}
+
+// sinr returns the mean SINR of the UE in dB; the cells the UE measures other than its serving cell interfere
+func sinr(ue *model.UE) float64 {
+	if ue.Cell == nil {
+		return minSINR
+	}
+	interference := math.Pow(10, noiseFloorDBm/10)
+	for _, cell := range ue.Cells {
+		if cell == nil || cell.NCGI == ue.Cell.NCGI {
+			continue
+		}
+		interference += math.Pow(10, cell.Strength/10)
+	}
+	return math.Max(minSINR, math.Min(maxSINR, ue.Cell.Strength-10*math.Log10(interference)))
+}
+
+// cqiThreshold returns the lowest SINR in dB at which a CQI is decoded with a BLER of about 10%
+func cqiThreshold(cqi int) float64 {
+	return -6 + 2*float64(cqi-1)
+}
+
+// cqi returns the highest CQI whose threshold the SINR reaches; zero if the UE is out of range
+func cqi(sinr float64) int {
+	for c := len(spectralEfficiency) - 1; c > 0; c-- {
+		if sinr >= cqiThreshold(c) {
+			return c
+		}
+	}
+	return 0
+}
+
+// bitsPerPRB returns the number of bits a PRB carries in a slot with the specified CQI
+func bitsPerPRB(cqi int) int {
+	return int(spectralEfficiency[cqi] * resourceElementsPerPRB)
+}
+
+// errorProbability returns the probability that a transport block sent with the specified CQI is not
+// decoded at the specified SINR of the slot; each retransmission halves the probability
+func errorProbability(cqi int, slotSINR float64, transmission int) float64 {
+	margin := slotSINR - cqiThreshold(cqi)
+	p := 1 / (1 + math.Exp(2*margin+1))
+	return p / math.Pow(2, float64(transmission-1))
+}
+
+// offeredLoad returns the traffic the UE generates in bits per slot according to its 5QI
+func offeredLoad(fiveQi int) int {
+	switch fiveQi {
+	case 1, 65, 66:
+		// conversational voice, 64 kbps
+		return 64
+	case 2, 67:
+		// conversational video, 2 Mbps
+		return 2000
+	case 5, 69, 70, 79:
+		// signalling and low latency, 100 kbps
+		return 100
+	case 3, 4, 80, 82, 83, 84, 85:
+		// real time gaming and streaming, 5 Mbps
+		return 5000
+	default:
+		// best effort, 10 Mbps
+		return 10000
+	}
+}
@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = logging.GetLogger()
+
+const (
+	// slotDuration is the duration of a slot
+	slotDuration = time.Millisecond
+	// slotsPerTick is the number of slots scheduled at once
+	slotsPerTick = 10
+	// ticksPerUERefresh is the number of ticks between two reads of the UEs of the cells
+	ticksPerUERefresh = 10
+	// MaxWeight is the highest scheduling weight of a UE or a slice
+	MaxWeight = 100.0
+	// DefaultWeight is the scheduling weight of the UEs and slices whose weight is not set
+	DefaultWeight = 1.0
+)
+
+// DefaultSlice is the slice of the UEs of the cells that have no RRM policy ratios
+var DefaultSlice = Slice{Sst: 1, Sd: 0x012345}
+
+// Scheduler is the MAC scheduler of the gNB-DUs: it allocates the PRBs of each slot of the cells to the
+// connected UEs using proportional fair scheduling within the RRM policy ratios of the slices, and
+// retransmits the transport blocks that are not acknowledged using HARQ
+type Scheduler interface {
+	// Start starts scheduling the slots of all cells
+	Start(ctx context.Context)
+
+	// Stop stops scheduling
+	Stop()
+
+	// Stats returns the cumulative scheduling statistics of the cell
+	Stats(ctx context.Context, ncgi types.NCGI) (*CellStats, error)
+
+	// SetUEWeight sets the scheduling weight of the UE in all cells
+	SetUEWeight(ctx context.Context, imsi types.IMSI, weight float64) error
+
+	// SetSliceWeight sets the scheduling weight of the slice in the cell
+	SetSliceWeight(ctx context.Context, ncgi types.NCGI, slice Slice, weight float64) error
+}
+
+type scheduler struct {
+	cellStore    cells.Store
+	ueStore      ues.Store
+	mu           sync.Mutex
+	cells        map[types.NCGI]*cellScheduler
+	ueWeights    map[types.IMSI]float64
+	sliceWeights map[types.NCGI]map[Slice]float64
+	rand         *rand.Rand
+	ticks        uint64
+	cancel       context.CancelFunc
+}
+
+// NewScheduler returns a scheduler of the slots of the cells of the cell store
+func NewScheduler(cellStore cells.Store, ueStore ues.Store) Scheduler {
+	return &scheduler{
+		cellStore:    cellStore,
+		ueStore:      ueStore,
+		cells:        make(map[types.NCGI]*cellScheduler),
+		ueWeights:    make(map[types.IMSI]float64),
+		sliceWeights: make(map[types.NCGI]map[Slice]float64),
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *scheduler) Start(ctx context.Context) {
+	log.Info("Scheduler starting")
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+	go func() {
+		ticker := time.NewTicker(slotsPerTick * slotDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.tick(ctx)
+			case <-ctx.Done():
+				log.Info("Scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// tick schedules the slots elapsed since the previous tick
+func (s *scheduler) tick(ctx context.Context) {
+	cellList, err := s.cellStore.List(ctx)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	var uesPerCell map[types.NCGI][]*model.UE
+	if s.ticks%ticksPerUERefresh == 0 {
+		uesPerCell = make(map[types.NCGI][]*model.UE)
+		for _, ue := range s.ueStore.ListAllUEs(ctx) {
+			if ue.Cell != nil && ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+				uesPerCell[ue.Cell.NCGI] = append(uesPerCell[ue.Cell.NCGI], ue)
+			}
+		}
+	}
+	s.ticks++
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scheduled := make(map[types.NCGI]bool)
+	for _, cell := range cellList {
+		cs, ok := s.cells[cell.NCGI]
+		if !ok {
+			cs = newCellScheduler(cell.NCGI)
+			s.cells[cell.NCGI] = cs
+		}
+		if uesPerCell != nil {
+			cs.updateUEs(cell, uesPerCell[cell.NCGI])
+		}
+		for i := 0; i < slotsPerTick; i++ {
+			cs.schedule(cell, s.weight, s.rand)
+		}
+		scheduled[cell.NCGI] = true
+	}
+	for ncgi := range s.cells {
+		if !scheduled[ncgi] {
+			delete(s.cells, ncgi)
+			delete(s.sliceWeights, ncgi)
+		}
+	}
+}
+
+// weight returns the scheduling weight of the UE of the slice in the cell
+func (s *scheduler) weight(ncgi types.NCGI, imsi types.IMSI, slice Slice) float64 {
+	return s.ueWeight(imsi) * s.sliceWeight(ncgi, slice)
+}
+
+func (s *scheduler) ueWeight(imsi types.IMSI) float64 {
+	if weight, ok := s.ueWeights[imsi]; ok {
+		return weight
+	}
+	return DefaultWeight
+}
+
+func (s *scheduler) sliceWeight(ncgi types.NCGI, slice Slice) float64 {
+	if weight, ok := s.sliceWeights[ncgi][slice]; ok {
+		return weight
+	}
+	return DefaultWeight
+}
+
+func (s *scheduler) Stats(ctx context.Context, ncgi types.NCGI) (*CellStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs, ok := s.cells[ncgi]
+	if !ok {
+		return nil, errors.NewNotFound("cell %v is not scheduled", ncgi)
+	}
+	stats := cs.stats.clone()
+	for imsi, ue := range stats.UEs {
+		ue.Weight = s.ueWeight(imsi)
+	}
+	for slice, sliceStats := range stats.Slices {
+		sliceStats.Weight = s.sliceWeight(ncgi, slice)
+	}
+	return stats, nil
+}
+
+func (s *scheduler) SetUEWeight(ctx context.Context, imsi types.IMSI, weight float64) error {
+	if err := checkWeight(weight); err != nil {
+		return err
+	}
+	if _, err := s.ueStore.Get(ctx, imsi); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ueWeights[imsi] = weight
+	log.Infof("Scheduling weight of UE %d is set to %v", imsi, weight)
+	return nil
+}
+
+func (s *scheduler) SetSliceWeight(ctx context.Context, ncgi types.NCGI, slice Slice, weight float64) error {
+	if err := checkWeight(weight); err != nil {
+		return err
+	}
+	if _, err := s.cellStore.Get(ctx, ncgi); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sliceWeights[ncgi]; !ok {
+		s.sliceWeights[ncgi] = make(map[Slice]float64)
+	}
+	s.sliceWeights[ncgi][slice] = weight
+	log.Infof("Scheduling weight of slice %+v in the cell (%v) is set to %v", slice, ncgi, weight)
+	return nil
+}
+
+func checkWeight(weight float64) error {
+	if weight <= 0 || weight > MaxWeight {
+		return errors.NewInvalid("scheduling weight %v is not in (0, %v]", weight, MaxWeight)
+	}
+	return nil
+}
+
+// sliceOf returns the slice of the UE in the cell: the UEs are spread over the slices of the RRM policy ratios
+func sliceOf(cell *model.Cell, imsi types.IMSI) Slice {
+	if len(cell.RrmPolicyRatios) == 0 {
+		return DefaultSlice
+	}
+	ratio := cell.RrmPolicyRatios[uint64(imsi)%uint64(len(cell.RrmPolicyRatios))]
+	return Slice{Sst: ratio.Sst, Sd: ratio.Sd}
+}
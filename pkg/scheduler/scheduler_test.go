@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNCGI = types.NCGI(84325717761)
+
+func testUE(imsi types.IMSI, strength float64) *model.UE {
+	return &model.UE{IMSI: imsi, FiveQi: 9, Cell: &model.UECell{NCGI: testNCGI, Strength: strength}}
+}
+
+func run(cs *cellScheduler, cell *model.Cell, slots int, weights map[types.IMSI]float64) {
+	rnd := rand.New(rand.NewSource(1))
+	weight := func(ncgi types.NCGI, imsi types.IMSI, slice Slice) float64 {
+		if w, ok := weights[imsi]; ok {
+			return w
+		}
+		return DefaultWeight
+	}
+	for i := 0; i < slots; i++ {
+		cs.schedule(cell, weight, rnd)
+	}
+}
+
+func TestLinkModel(t *testing.T) {
+	assert.Equal(t, 106, numberOfPRBs(&model.Cell{}))
+	assert.Equal(t, 25, numberOfPRBs(&model.Cell{Bandwidth: 5}))
+	assert.Equal(t, 15, cqi(maxSINR))
+	assert.Equal(t, 0, cqi(minSINR))
+	assert.Equal(t, 1, cqi(-6))
+	assert.Greater(t, errorProbability(10, cqiThreshold(10)-2, 1), errorProbability(10, cqiThreshold(10)+2, 1))
+	assert.Greater(t, errorProbability(10, cqiThreshold(10), 1), errorProbability(10, cqiThreshold(10), 2))
+	assert.Equal(t, 30.0, sinr(testUE(1, -60)))
+}
+
+func TestSchedule(t *testing.T) {
+	cell := &model.Cell{NCGI: testNCGI, Bandwidth: 20}
+	cs := newCellScheduler(testNCGI)
+	cs.updateUEs(cell, []*model.UE{testUE(1, -60), testUE(2, -85)})
+	run(cs, cell, 1000, nil)
+
+	stats := cs.stats
+	assert.Equal(t, uint64(1000), stats.Slots)
+	assert.Equal(t, 106, stats.PRBsPerSlot)
+	assert.Greater(t, stats.PRBUtilization(), 0.0)
+	assert.LessOrEqual(t, stats.PRBUtilization(), 1.0)
+	assert.Greater(t, stats.Retransmissions, uint64(0))
+	assert.Less(t, stats.BLER(), 0.3)
+
+	// both UEs get their offered load of 10 Mbps
+	for _, ue := range stats.UEs {
+		assert.InDelta(t, 10000*1000, float64(ue.Bits), 10000*100)
+		assert.Equal(t, DefaultSlice, ue.Slice)
+	}
+	// the UE with the lower SINR uses a lower MCS and more PRBs
+	assert.Greater(t, stats.UEs[1].AverageMCS(), stats.UEs[2].AverageMCS())
+	assert.Less(t, stats.UEs[1].PRBs, stats.UEs[2].PRBs)
+
+	prev := stats.clone()
+	run(cs, cell, 100, nil)
+	window := cs.stats.Since(prev)
+	assert.Equal(t, uint64(100), window.Slots)
+	assert.Equal(t, cs.stats.PRBs-prev.PRBs, window.PRBs)
+
+	// the HARQ processes are dropped when the UE leaves the cell
+	cs.updateUEs(cell, []*model.UE{testUE(1, -60)})
+	assert.Len(t, cs.ues, 1)
+	assert.Len(t, cs.stats.UEs, 1)
+}
+
+func TestWeights(t *testing.T) {
+	// a 5 MHz carrier cannot carry the offered load of both UEs
+	cell := &model.Cell{NCGI: testNCGI, Bandwidth: 5}
+	cs := newCellScheduler(testNCGI)
+	cs.updateUEs(cell, []*model.UE{testUE(1, -92), testUE(2, -92)})
+	run(cs, cell, 2000, map[types.IMSI]float64{1: 4})
+	assert.Greater(t, cs.stats.UEs[1].Bits, 2*cs.stats.UEs[2].Bits)
+
+	assert.True(t, errors.IsInvalid(checkWeight(0)))
+	assert.True(t, errors.IsInvalid(checkWeight(MaxWeight+1)))
+	assert.NoError(t, checkWeight(MaxWeight))
+}
+
+func TestSliceRatios(t *testing.T) {
+	cell := &model.Cell{NCGI: testNCGI, Bandwidth: 20, RrmPolicyRatios: []model.RrmPolicyRatio{
+		{Sst: 1, Sd: 1, MaxPrbRatio: 20},
+		{Sst: 1, Sd: 2, MinPrbRatio: 50},
+	}}
+	ues := make([]*model.UE, 0)
+	for imsi := types.IMSI(1); imsi <= 10; imsi++ {
+		ues = append(ues, testUE(imsi, -95))
+	}
+	cs := newCellScheduler(testNCGI)
+	cs.updateUEs(cell, ues)
+	run(cs, cell, 1000, nil)
+
+	capped := cs.stats.Slices[Slice{Sst: 1, Sd: 1}]
+	assert.NotNil(t, capped)
+	assert.LessOrEqual(t, capped.PRBs, uint64(21*1000))
+	assert.Greater(t, cs.stats.Slices[Slice{Sst: 1, Sd: 2}].PRBs, capped.PRBs)
+	for _, allocation := range cs.stats.LastSlot {
+		assert.LessOrEqual(t, allocation.FirstPRB+allocation.PRBs, 106)
+	}
+}
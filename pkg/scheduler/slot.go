@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+const (
+	// harqProcesses is the number of HARQ processes of a UE
+	harqProcesses = 8
+	// maxTransmissions is the maximum number of transmissions of a transport block
+	maxTransmissions = 4
+	// harqRTT is the number of slots between a transmission and its retransmission
+	harqRTT = 4
+	// throughputSmoothing is the weight of a slot in the average throughput of the proportional fair metric
+	throughputSmoothing = 0.01
+	// maxBufferedSlots is the number of slots of traffic the buffer of a UE holds
+	maxBufferedSlots = 1000
+)
+
+// harqProcess is a transport block waiting to be retransmitted
+type harqProcess struct {
+	bits         int
+	prbs         int
+	cqi          int
+	transmission int
+	readySlot    uint64
+}
+
+// ueState is the scheduling state of a UE in a cell
+type ueState struct {
+	imsi          types.IMSI
+	slice         Slice
+	fiveQi        int
+	sinr          float64
+	cqi           int
+	buffer        int
+	avgThroughput float64
+	harq          []*harqProcess
+}
+
+// cellScheduler schedules the slots of a cell
+type cellScheduler struct {
+	slot  uint64
+	ues   map[types.IMSI]*ueState
+	stats *CellStats
+}
+
+func newCellScheduler(ncgi types.NCGI) *cellScheduler {
+	return &cellScheduler{
+		ues: make(map[types.IMSI]*ueState),
+		stats: &CellStats{
+			NCGI:   ncgi,
+			UEs:    make(map[types.IMSI]*UEStats),
+			Slices: make(map[Slice]*SliceStats),
+		},
+	}
+}
+
+// updateUEs updates the UEs connected to the cell; the HARQ processes of the UEs that left the cell are dropped
+func (cs *cellScheduler) updateUEs(cell *model.Cell, ues []*model.UE) {
+	connected := make(map[types.IMSI]bool)
+	for _, ue := range ues {
+		connected[ue.IMSI] = true
+		state, ok := cs.ues[ue.IMSI]
+		if !ok {
+			state = &ueState{imsi: ue.IMSI}
+			cs.ues[ue.IMSI] = state
+		}
+		state.slice = sliceOf(cell, ue.IMSI)
+		state.fiveQi = ue.FiveQi
+		state.sinr = sinr(ue)
+		state.cqi = cqi(state.sinr)
+
+		ueStats, ok := cs.stats.UEs[ue.IMSI]
+		if !ok {
+			ueStats = &UEStats{IMSI: ue.IMSI}
+			cs.stats.UEs[ue.IMSI] = ueStats
+		}
+		ueStats.CRNTI = ue.CRNTI
+		ueStats.Slice = state.slice
+		ueStats.CQI = state.cqi
+		if _, ok := cs.stats.Slices[state.slice]; !ok {
+			cs.stats.Slices[state.slice] = &SliceStats{Slice: state.slice}
+		}
+	}
+	for imsi := range cs.ues {
+		if !connected[imsi] {
+			delete(cs.ues, imsi)
+		}
+	}
+	for imsi := range cs.stats.UEs {
+		if !connected[imsi] {
+			delete(cs.stats.UEs, imsi)
+		}
+	}
+}
+
+// candidate is a UE competing for the PRBs of a slot
+type candidate struct {
+	ue     *ueState
+	metric float64
+}
+
+// schedule schedules the next slot of the cell: the transport blocks due for retransmission are
+// retransmitted first, then the PRBs are allocated to new transmissions by proportional fair metric,
+// first up to the minimum ratio of each slice and then up to the maximum ratio of each slice
+func (cs *cellScheduler) schedule(cell *model.Cell, weight func(types.NCGI, types.IMSI, Slice) float64, rnd *rand.Rand) {
+	cs.slot++
+	totalPRBs := numberOfPRBs(cell)
+	freePRBs := totalPRBs
+	allocations := make([]Allocation, 0)
+	delivered := make(map[types.IMSI]int)
+	slicePRBs := make(map[Slice]int)
+
+	imsis := make([]types.IMSI, 0, len(cs.ues))
+	for imsi, ue := range cs.ues {
+		imsis = append(imsis, imsi)
+		// new traffic
+		ue.buffer += offeredLoad(ue.fiveQi)
+		if maxBuffer := maxBufferedSlots * offeredLoad(ue.fiveQi); ue.buffer > maxBuffer {
+			ue.buffer = maxBuffer
+		}
+	}
+	sort.Slice(imsis, func(i, j int) bool { return imsis[i] < imsis[j] })
+
+	allocate := func(ue *ueState, prbs int, process *harqProcess) {
+		allocation := Allocation{
+			IMSI:           ue.imsi,
+			FirstPRB:       totalPRBs - freePRBs,
+			PRBs:           prbs,
+			MCS:            mcsIndex[process.cqi],
+			Retransmission: process.transmission > 1,
+		}
+		freePRBs -= prbs
+		slicePRBs[ue.slice] += prbs
+		slotSINR := ue.sinr + rnd.NormFloat64()*fadingStdDev
+		allocation.Acknowledged = rnd.Float64() >= errorProbability(process.cqi, slotSINR, process.transmission)
+		harqFailure := !allocation.Acknowledged && process.transmission >= maxTransmissions
+		cs.record(ue, allocation, process.bits, harqFailure)
+		if allocation.Acknowledged {
+			delivered[ue.imsi] += process.bits
+		} else if !harqFailure {
+			process.transmission++
+			process.readySlot = cs.slot + harqRTT
+			ue.harq = append(ue.harq, process)
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	// retransmissions
+	scheduled := make(map[types.IMSI]bool)
+	for _, imsi := range imsis {
+		ue := cs.ues[imsi]
+		for i, process := range ue.harq {
+			if process.readySlot > cs.slot || process.prbs > freePRBs {
+				continue
+			}
+			ue.harq = append(ue.harq[:i], ue.harq[i+1:]...)
+			allocate(ue, process.prbs, process)
+			scheduled[imsi] = true
+			break
+		}
+	}
+
+	// new transmissions
+	candidates := make([]*candidate, 0)
+	for _, imsi := range imsis {
+		ue := cs.ues[imsi]
+		if scheduled[imsi] || ue.buffer == 0 || ue.cqi == 0 || len(ue.harq) >= harqProcesses {
+			continue
+		}
+		rate := float64(bitsPerPRB(ue.cqi))
+		candidates = append(candidates, &candidate{
+			ue:     ue,
+			metric: weight(cell.NCGI, imsi, ue.slice) * rate / math.Max(ue.avgThroughput, 1),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].metric > candidates[j].metric })
+
+	ratios := make(map[Slice]model.RrmPolicyRatio)
+	for _, ratio := range cell.RrmPolicyRatios {
+		ratios[Slice{Sst: ratio.Sst, Sd: ratio.Sd}] = ratio
+	}
+	prbsOfRatio := func(ratio int32) int {
+		return int(ratio) * totalPRBs / 100
+	}
+	newTransmission := func(c *candidate, maxPRBs int) {
+		bpp := bitsPerPRB(c.ue.cqi)
+		prbs := (c.ue.buffer + bpp - 1) / bpp
+		if prbs > maxPRBs {
+			prbs = maxPRBs
+		}
+		if prbs <= 0 {
+			return
+		}
+		bits := prbs * bpp
+		if bits > c.ue.buffer {
+			bits = c.ue.buffer
+		}
+		c.ue.buffer -= bits
+		scheduled[c.ue.imsi] = true
+		allocate(c.ue, prbs, &harqProcess{bits: bits, prbs: prbs, cqi: c.ue.cqi, transmission: 1})
+	}
+
+	// minimum and dedicated ratios of the slices
+	for _, c := range candidates {
+		ratio, ok := ratios[c.ue.slice]
+		if !ok {
+			continue
+		}
+		guaranteed := prbsOfRatio(ratio.MinPrbRatio)
+		if dedicated := prbsOfRatio(ratio.DedicatedPrbRatio); dedicated > guaranteed {
+			guaranteed = dedicated
+		}
+		newTransmission(c, minInt(freePRBs, guaranteed-slicePRBs[c.ue.slice]))
+	}
+
+	// remaining PRBs up to the maximum ratios of the slices; the unused dedicated PRBs of the other slices
+	// are not shared
+	for _, c := range candidates {
+		if scheduled[c.ue.imsi] {
+			continue
+		}
+		maxPRBs := freePRBs
+		for slice, ratio := range ratios {
+			if slice != c.ue.slice {
+				maxPRBs -= maxInt(0, prbsOfRatio(ratio.DedicatedPrbRatio)-slicePRBs[slice])
+			}
+		}
+		if ratio, ok := ratios[c.ue.slice]; ok && ratio.MaxPrbRatio > 0 {
+			maxPRBs = minInt(maxPRBs, prbsOfRatio(ratio.MaxPrbRatio)-slicePRBs[c.ue.slice])
+		}
+		newTransmission(c, maxPRBs)
+	}
+
+	for imsi, ue := range cs.ues {
+		ue.avgThroughput = (1-throughputSmoothing)*ue.avgThroughput + throughputSmoothing*float64(delivered[imsi])
+	}
+	cs.stats.Slots++
+	cs.stats.PRBsPerSlot = totalPRBs
+	if len(allocations) > 0 {
+		cs.stats.ScheduledSlots++
+	}
+	for slice := range slicePRBs {
+		if sliceStats, ok := cs.stats.Slices[slice]; ok {
+			sliceStats.ScheduledSlots++
+		}
+	}
+	cs.stats.LastSlot = allocations
+}
+
+// record counts a transmission in the statistics of the UE, its slice and the cell
+func (cs *cellScheduler) record(ue *ueState, allocation Allocation, bits int, harqFailure bool) {
+	count := func(stats *TransmissionStats) {
+		stats.PRBs += uint64(allocation.PRBs)
+		stats.MCSSum += uint64(allocation.MCS)
+		if allocation.Retransmission {
+			stats.Retransmissions++
+		} else {
+			stats.InitialTransmissions++
+		}
+		if allocation.Acknowledged {
+			stats.Bits += uint64(bits)
+		} else {
+			stats.Nacks++
+		}
+		if harqFailure {
+			stats.HarqFailures++
+		}
+	}
+	count(&cs.stats.TransmissionStats)
+	if ueStats, ok := cs.stats.UEs[ue.imsi]; ok {
+		count(&ueStats.TransmissionStats)
+		ueStats.ScheduledSlots++
+	}
+	if sliceStats, ok := cs.stats.Slices[ue.slice]; ok {
+		count(&sliceStats.TransmissionStats)
+	}
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
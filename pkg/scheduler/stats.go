@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// Slice identifies a network slice by its SST and SD
+type Slice struct {
+	Sst uint32
+	Sd  uint32
+}
+
+// TransmissionStats counts the transmissions of the transport blocks of a UE, a slice or a cell
+type TransmissionStats struct {
+	// ScheduledSlots is the number of slots with at least one PRB allocated
+	ScheduledSlots uint64
+	// PRBs is the number of PRBs allocated over all slots
+	PRBs uint64
+	// Bits is the number of bits of the transport blocks acknowledged
+	Bits                 uint64
+	InitialTransmissions uint64
+	Retransmissions      uint64
+	Nacks                uint64
+	// HarqFailures is the number of transport blocks dropped after the maximum number of transmissions
+	HarqFailures uint64
+	// MCSSum is the sum of the MCS indexes of all transmissions
+	MCSSum uint64
+}
+
+// Transmissions returns the number of initial transmissions and retransmissions
+func (s TransmissionStats) Transmissions() uint64 {
+	return s.InitialTransmissions + s.Retransmissions
+}
+
+// BLER returns the ratio of the transmissions that are not acknowledged
+func (s TransmissionStats) BLER() float64 {
+	if s.Transmissions() == 0 {
+		return 0
+	}
+	return float64(s.Nacks) / float64(s.Transmissions())
+}
+
+// AverageMCS returns the average MCS index of the transmissions
+func (s TransmissionStats) AverageMCS() float64 {
+	if s.Transmissions() == 0 {
+		return 0
+	}
+	return float64(s.MCSSum) / float64(s.Transmissions())
+}
+
+func (s TransmissionStats) since(prev TransmissionStats) TransmissionStats {
+	return TransmissionStats{
+		ScheduledSlots:       diff(s.ScheduledSlots, prev.ScheduledSlots),
+		PRBs:                 diff(s.PRBs, prev.PRBs),
+		Bits:                 diff(s.Bits, prev.Bits),
+		InitialTransmissions: diff(s.InitialTransmissions, prev.InitialTransmissions),
+		Retransmissions:      diff(s.Retransmissions, prev.Retransmissions),
+		Nacks:                diff(s.Nacks, prev.Nacks),
+		HarqFailures:         diff(s.HarqFailures, prev.HarqFailures),
+		MCSSum:               diff(s.MCSSum, prev.MCSSum),
+	}
+}
+
+// diff returns the increase of a counter; zero if the counter restarted
+func diff(value uint64, prev uint64) uint64 {
+	if value < prev {
+		return 0
+	}
+	return value - prev
+}
+
+// UEStats are the scheduling statistics of a UE in a cell
+type UEStats struct {
+	IMSI  types.IMSI
+	CRNTI types.CRNTI
+	Slice Slice
+	// Weight is the scheduling weight of the UE
+	Weight float64
+	// CQI is the CQI most recently reported by the UE
+	CQI int
+	TransmissionStats
+}
+
+// SliceStats are the scheduling statistics of a slice in a cell
+type SliceStats struct {
+	Slice Slice
+	// Weight is the scheduling weight of the slice
+	Weight float64
+	TransmissionStats
+}
+
+// Allocation is the allocation of contiguous PRBs of a slot to a UE
+type Allocation struct {
+	IMSI           types.IMSI
+	FirstPRB       int
+	PRBs           int
+	MCS            int
+	Retransmission bool
+	Acknowledged   bool
+}
+
+// CellStats are the cumulative scheduling statistics of a cell
+type CellStats struct {
+	NCGI types.NCGI
+	// Slots is the number of slots scheduled
+	Slots uint64
+	// PRBsPerSlot is the number of PRBs of the carrier of the cell
+	PRBsPerSlot int
+	TransmissionStats
+	UEs    map[types.IMSI]*UEStats
+	Slices map[Slice]*SliceStats
+	// LastSlot is the PRB grid of the most recent slot
+	LastSlot []Allocation
+}
+
+// PRBUtilization returns the ratio of the PRBs allocated
+func (s *CellStats) PRBUtilization() float64 {
+	if s.Slots == 0 || s.PRBsPerSlot == 0 {
+		return 0
+	}
+	return float64(s.PRBs) / float64(s.Slots*uint64(s.PRBsPerSlot))
+}
+
+// Since returns the statistics of the slots scheduled after the previous statistics were taken; the
+// statistics of a cell or a UE whose counters restarted since are returned as they are
+func (s *CellStats) Since(prev *CellStats) *CellStats {
+	if prev == nil || prev.Slots > s.Slots {
+		return s
+	}
+	stats := &CellStats{
+		NCGI:              s.NCGI,
+		Slots:             s.Slots - prev.Slots,
+		PRBsPerSlot:       s.PRBsPerSlot,
+		TransmissionStats: s.TransmissionStats.since(prev.TransmissionStats),
+		UEs:               make(map[types.IMSI]*UEStats),
+		Slices:            make(map[Slice]*SliceStats),
+		LastSlot:          s.LastSlot,
+	}
+	for imsi, ue := range s.UEs {
+		ueStats := *ue
+		if prevUE, ok := prev.UEs[imsi]; ok && prevUE.Transmissions() <= ue.Transmissions() {
+			ueStats.TransmissionStats = ue.TransmissionStats.since(prevUE.TransmissionStats)
+		}
+		stats.UEs[imsi] = &ueStats
+	}
+	for slice, sliceStats := range s.Slices {
+		stats.Slices[slice] = &SliceStats{Slice: slice, Weight: sliceStats.Weight, TransmissionStats: sliceStats.TransmissionStats}
+		if prevSlice, ok := prev.Slices[slice]; ok {
+			stats.Slices[slice].TransmissionStats = sliceStats.TransmissionStats.since(prevSlice.TransmissionStats)
+		}
+	}
+	return stats
+}
+
+func (s *CellStats) clone() *CellStats {
+	stats := *s
+	stats.UEs = make(map[types.IMSI]*UEStats, len(s.UEs))
+	for imsi, ue := range s.UEs {
+		ueStats := *ue
+		stats.UEs[imsi] = &ueStats
+	}
+	stats.Slices = make(map[Slice]*SliceStats, len(s.Slices))
+	for slice, sliceStats := range s.Slices {
+		clone := *sliceStats
+		stats.Slices[slice] = &clone
+	}
+	stats.LastSlot = append([]Allocation{}, s.LastSlot...)
+	return &stats
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"math"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// nodeAttribute is an attribute of a node-level configuration structure; node-level attributes are read-only
+type nodeAttribute struct {
+	name string
+	get  func(node *model.Node) interface{}
+}
+
+// cellAttribute is an attribute of a cell-level configuration structure
+type cellAttribute struct {
+	name string
+	get  func(cell *model.Cell) interface{}
+	// set validates the value and applies it to the cell; nil if the attribute cannot be controlled
+	set func(cell *model.Cell, value interface{}) error
+}
+
+var nodeConfigurationStructures = map[string][]nodeAttribute{
+	GNBDUFunctionStructure: {
+		{
+			name: GNBIDAttribute,
+			get:  func(node *model.Node) interface{} { return uint64(node.GnbID) },
+		},
+		{
+			name: GNBIDLengthAttribute,
+			get:  func(node *model.Node) interface{} { return uint64(gnbIDLength) },
+		},
+	},
+}
+
+var cellConfigurationStructures = map[string][]cellAttribute{
+	NRCellDUStructure: {
+		{
+			name: NRPCIAttribute,
+			get:  func(cell *model.Cell) interface{} { return uint64(cell.PCI) },
+			set: func(cell *model.Cell, value interface{}) error {
+				pci, err := uintValue(value, 0, 1007)
+				if err != nil {
+					return err
+				}
+				cell.PCI = uint32(pci)
+				return nil
+			},
+		},
+		{
+			name: ARFCNDLAttribute,
+			get:  func(cell *model.Cell) interface{} { return uint64(cell.Earfcn) },
+			set: func(cell *model.Cell, value interface{}) error {
+				arfcn, err := uintValue(value, 0, 3279165)
+				if err != nil {
+					return err
+				}
+				cell.Earfcn = uint32(arfcn)
+				return nil
+			},
+		},
+		{
+			name: ChannelBandwidthDLAttribute,
+			get:  func(cell *model.Cell) interface{} { return uint64(cell.Bandwidth) },
+			set: func(cell *model.Cell, value interface{}) error {
+				bandwidth, err := uintValue(value, 5, 400)
+				if err != nil {
+					return err
+				}
+				if !isChannelBandwidth(bandwidth) {
+					return errors.NewInvalid("%d MHz is not an NR channel bandwidth", bandwidth)
+				}
+				cell.Bandwidth = uint32(bandwidth)
+				return nil
+			},
+		},
+		{
+			name: CellBarredAttribute,
+			get: func(cell *model.Cell) interface{} {
+				if cell.Barred {
+					return CellBarred
+				}
+				return CellNotBarred
+			},
+			set: func(cell *model.Cell, value interface{}) error {
+				switch value {
+				case CellBarred:
+					cell.Barred = true
+				case CellNotBarred:
+					cell.Barred = false
+				default:
+					return errors.NewInvalid("cell barred must be %s or %s", CellBarred, CellNotBarred)
+				}
+				return nil
+			},
+		},
+	},
+	NRSectorCarrierStructure: {
+		{
+			name: ConfiguredMaxTxPowerAttribute,
+			get:  func(cell *model.Cell) interface{} { return cell.TxPowerDB },
+			set: func(cell *model.Cell, value interface{}) error {
+				txPower, err := floatValue(value, -30, 60)
+				if err != nil {
+					return err
+				}
+				cell.TxPowerDB = txPower
+				return nil
+			},
+		},
+		{
+			name: ElectricalAntennaTiltAttribute,
+			get:  func(cell *model.Cell) interface{} { return cell.Tilt },
+			set: func(cell *model.Cell, value interface{}) error {
+				tilt, err := floatValue(value, -90, 90)
+				if err != nil {
+					return err
+				}
+				cell.Tilt = tilt
+				return nil
+			},
+		},
+	},
+}
+
+var channelBandwidths = []uint64{5, 10, 15, 20, 25, 30, 40, 50, 60, 70, 80, 90, 100, 200, 400}
+
+func isChannelBandwidth(bandwidth uint64) bool {
+	for _, b := range channelBandwidths {
+		if b == bandwidth {
+			return true
+		}
+	}
+	return false
+}
+
+// numericValue converts an attribute value decoded from JSON or read from the model to a float
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func uintValue(value interface{}, min uint64, max uint64) (uint64, error) {
+	v, ok := numericValue(value)
+	if !ok || v != math.Trunc(v) {
+		return 0, errors.NewInvalid("%v is not an integer", value)
+	}
+	if v < float64(min) || v > float64(max) {
+		return 0, errors.NewInvalid("%v is out of range [%d, %d]", value, min, max)
+	}
+	return uint64(v), nil
+}
+
+func floatValue(value interface{}, min float64, max float64) (float64, error) {
+	v, ok := numericValue(value)
+	if !ok {
+		return 0, errors.NewInvalid("%v is not a number", value)
+	}
+	if v < min || v > max {
+		return 0, errors.NewInvalid("%v is out of range [%v, %v]", value, min, max)
+	}
+	return v, nil
+}
+
+// equalValues compares attribute values regardless of whether they were decoded from JSON or read from the model
+func equalValues(a interface{}, b interface{}) bool {
+	x, xNumeric := numericValue(a)
+	y, yNumeric := numericValue(b)
+	if xNumeric && yNumeric {
+		return x == y
+	}
+	return a == b
+}
+
+// selectAttributes returns the names of the attributes of a structure referred to; all attributes if none are listed
+func selectAttributes(ref ConfigurationStructureReference, all []string) ([]string, error) {
+	if len(ref.ListOfAttributes) == 0 {
+		return all, nil
+	}
+	for _, name := range ref.ListOfAttributes {
+		found := false
+		for _, attribute := range all {
+			if attribute == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NewNotSupported("attribute %s of %s is not supported", name, ref.RanConfigurationStructureName)
+		}
+	}
+	return ref.ListOfAttributes, nil
+}
+
+func nodeAttributeNames(structure string) ([]string, error) {
+	attributes, ok := nodeConfigurationStructures[structure]
+	if !ok {
+		return nil, errors.NewNotSupported("node-level configuration structure %s is not supported", structure)
+	}
+	names := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		names = append(names, attribute.name)
+	}
+	return names, nil
+}
+
+func cellAttributeNames(structure string) ([]string, error) {
+	attributes, ok := cellConfigurationStructures[structure]
+	if !ok {
+		return nil, errors.NewNotSupported("cell-level configuration structure %s is not supported", structure)
+	}
+	names := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		names = append(names, attribute.name)
+	}
+	return names, nil
+}
+
+// nodeValues returns the current values of the given attributes of a node-level structure
+func nodeValues(node *model.Node, structure string, names []string) AttributeValues {
+	values := make(AttributeValues)
+	for _, attribute := range nodeConfigurationStructures[structure] {
+		for _, name := range names {
+			if attribute.name == name {
+				values[name] = attribute.get(node)
+			}
+		}
+	}
+	return values
+}
+
+// cellValues returns the current values of the given attributes of a cell-level structure
+func cellValues(cell *model.Cell, structure string, names []string) AttributeValues {
+	values := make(AttributeValues)
+	for _, attribute := range cellConfigurationStructures[structure] {
+		for _, name := range names {
+			if attribute.name == name {
+				values[name] = attribute.get(cell)
+			}
+		}
+	}
+	return values
+}
+
+// changedValues returns true if any of the current values differs from the previous ones
+func changedValues(previous AttributeValues, current AttributeValues) bool {
+	for name, value := range current {
+		if !equalValues(previous[name], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// controlCell applies the new values of a cell-level structure to the cell. All values are validated before
+// any is applied, so that the structure is either changed as a whole or not at all; the cause is returned on failure.
+func controlCell(cell *model.Cell, control ConfigurationStructureControl) (string, error) {
+	attributes, ok := cellConfigurationStructures[control.RanConfigurationStructureName]
+	if !ok {
+		return CauseNotSupported, errors.NewNotSupported("cell-level configuration structure %s is not supported", control.RanConfigurationStructureName)
+	}
+	if len(control.NewValuesOfAttributes) == 0 {
+		return CauseSemanticError, errors.NewInvalid("no new values are given for %s", control.RanConfigurationStructureName)
+	}
+	for name, old := range control.OldValuesOfAttributes {
+		current := cellValues(cell, control.RanConfigurationStructureName, []string{name})
+		value, ok := current[name]
+		if !ok {
+			return CauseNotSupported, errors.NewNotSupported("attribute %s of %s is not supported", name, control.RanConfigurationStructureName)
+		}
+		if !equalValues(old, value) {
+			return CauseIncompatibleState, errors.NewConflict("attribute %s of %s is %v rather than %v", name, control.RanConfigurationStructureName, value, old)
+		}
+	}
+
+	// validate the values on a copy of the cell before applying them
+	updated := *cell
+	for name, value := range control.NewValuesOfAttributes {
+		var attribute *cellAttribute
+		for i := range attributes {
+			if attributes[i].name == name {
+				attribute = &attributes[i]
+				break
+			}
+		}
+		if attribute == nil || attribute.set == nil {
+			return CauseNotSupported, errors.NewNotSupported("attribute %s of %s cannot be controlled", name, control.RanConfigurationStructureName)
+		}
+		if err := attribute.set(&updated, value); err != nil {
+			return CauseSemanticError, err
+		}
+	}
+	for name, value := range control.NewValuesOfAttributes {
+		for _, attribute := range attributes {
+			if attribute.name == name {
+				_ = attribute.set(cell, value)
+			}
+		}
+	}
+	return "", nil
+}
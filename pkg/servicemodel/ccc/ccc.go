@@ -0,0 +1,721 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+)
+
+var _ servicemodel.Client = &Client{}
+
+var _ servicemodel.CellTargeter = &Client{}
+
+var _ servicemodel.CellDescriber = &Client{}
+
+var log = logging.GetLogger()
+
+// Client ccc service model client
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	reports      map[subscriptions.ID]context.CancelFunc
+	mu           sync.Mutex
+}
+
+// report is the list of configuration structures requested by a REPORT action
+type report struct {
+	style int32
+	items []*reportItem
+}
+
+// reportItem is a configuration structure of the node or of a cell to be reported
+type reportItem struct {
+	// ncgi is the cell of a cell-level structure; zero for node-level structures
+	ncgi       ransimtypes.NCGI
+	structure  string
+	attributes []string
+	// values are the values most recently reported; nil once the cell is deleted
+	values AttributeValues
+	// old and deleted describe the change of a structure reported upon change
+	old     AttributeValues
+	deleted bool
+}
+
+// reported returns the reported configuration structure of the item
+func (item *reportItem) reported() ConfigurationStructureReported {
+	changeType := ChangeTypeNone
+	if item.deleted {
+		changeType = ChangeTypeDeletion
+	} else if item.old != nil {
+		changeType = ChangeTypeModification
+	}
+	values := item.values
+	if values == nil {
+		values = make(AttributeValues)
+	}
+	return ConfigurationStructureReported{
+		ChangeType:                    changeType,
+		RanConfigurationStructureName: item.structure,
+		ValuesOfAttributes:            values,
+		OldValuesOfAttributes:         item.old,
+	}
+}
+
+// NewServiceModel creates a new service model
+func NewServiceModel(node model.Node, model *model.Model,
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store,
+	ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store) (registry.ServiceModel, error) {
+	cccSm := registry.ServiceModel{
+		RanFunctionID: registry.Ccc,
+		ModelName:     e2smtypes.ShortName(modelFullName),
+		Revision:      1,
+		OID:           modelOID,
+		Version:       version,
+		Node:          node,
+		Model:         model,
+		Subscriptions: subStore,
+		Nodes:         nodeStore,
+		UEs:           ueStore,
+		CellStore:     cellStore,
+		MetricStore:   metricStore,
+	}
+
+	cccClient := &Client{
+		ServiceModel: &cccSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
+	}
+	cccSm.Client = cccClient
+
+	ranFunctionDefinition, err := cccClient.RanFunctionDescription(context.Background(), node)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	cccSm.Description = ranFunctionDefinition
+	return cccSm, nil
+}
+
+// RanFunctionDescription returns the RAN function definition of the service model, which lists the cells of the node
+// and their configuration structures
+func (sm *Client) RanFunctionDescription(ctx context.Context, node model.Node) ([]byte, error) {
+	ranFunctionDefinition, err := json.Marshal(sm.createRanFunctionDefinition(ctx, node.Cells))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return ranFunctionDefinition, nil
+}
+
+// E2ConnectionUpdate implements connection update handler
+func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
+	return nil, nil, errors.NewNotSupported("E2 connection update is not supported")
+}
+
+// RICControl implements control handler for ccc service model
+func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
+	log.Infof("Control Request is received for service model %v and e2 node ID: %d", sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	receivedTimestamp := time.Now().Format(time.RFC3339Nano)
+	reqID, err := controlutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	controlFailure := func(cause e2apies.CauseRicrequest, outcome []byte) (*e2appducontents.RiccontrolFailure, error) {
+		return controlutils.NewControl(
+			controlutils.WithRanFuncID(*ranFuncID),
+			controlutils.WithRequestID(*reqID),
+			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			}),
+			controlutils.WithRicControlOutcome(outcome)).BuildControlFailure()
+	}
+
+	controlHeader, err := sm.getControlHeader(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+	controlMessage, err := sm.getControlMessage(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+
+	var outcome *ControlOutcome
+	var accepted int
+	style := controlHeader.ControlHeaderFormat.ControlHeaderFormat1.RicStyleType
+	format := controlMessage.ControlMessageFormat
+	switch {
+	case style == NodeConfigurationStyle && format.ControlMessageFormat1 != nil:
+		outcome = sm.controlNode(format.ControlMessageFormat1, receivedTimestamp)
+	case style == CellConfigurationStyle && format.ControlMessageFormat2 != nil:
+		outcome, accepted = sm.controlCells(ctx, format.ControlMessageFormat2, receivedTimestamp)
+	default:
+		log.Warnf("Control message format does not match RIC style %d", style)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+
+	outcomeBytes, err := json.Marshal(outcome)
+	if err != nil {
+		return nil, nil, err
+	}
+	if accepted == 0 {
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE, outcomeBytes)
+		return nil, failure, err
+	}
+	response, err = controlutils.NewControl(
+		controlutils.WithRanFuncID(*ranFuncID),
+		controlutils.WithRequestID(*reqID),
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithRicControlOutcome(outcomeBytes)).BuildControlAcknowledge()
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// controlNode rejects the control of node-level configuration structures, which are read-only
+func (sm *Client) controlNode(message *ControlMessageFormat1, receivedTimestamp string) *ControlOutcome {
+	outcome := &ControlOutcomeFormat1{
+		ReceivedTimestamp:                     receivedTimestamp,
+		ListOfConfigurationStructuresAccepted: make([]ConfigurationStructureAccepted, 0),
+		ListOfConfigurationStructuresFailed:   make([]ConfigurationStructureFailed, 0),
+	}
+	for _, control := range message.ListOfConfigurationStructures {
+		outcome.ListOfConfigurationStructuresFailed = append(outcome.ListOfConfigurationStructuresFailed, ConfigurationStructureFailed{
+			RanConfigurationStructureName: control.RanConfigurationStructureName,
+			OldValuesOfAttributes:         control.OldValuesOfAttributes,
+			RequestedValuesOfAttributes:   control.NewValuesOfAttributes,
+			Cause:                         CauseNotSupported,
+		})
+	}
+	return &ControlOutcome{ControlOutcomeFormat: ControlOutcomeFormat{ControlOutcomeFormat1: outcome}}
+}
+
+// controlCells applies the cell-level configuration structures and returns the outcome and the number of structures applied
+func (sm *Client) controlCells(ctx context.Context, message *ControlMessageFormat2, receivedTimestamp string) (*ControlOutcome, int) {
+	outcome := &ControlOutcomeFormat2{
+		ReceivedTimestamp:     receivedTimestamp,
+		ListOfCellsControlled: make([]CellOutcome, 0),
+	}
+	accepted := 0
+	for _, cellControl := range message.ListOfCellsControlled {
+		cellOutcome := CellOutcome{
+			CellGlobalID:                          cellControl.CellGlobalID,
+			ListOfConfigurationStructuresAccepted: make([]ConfigurationStructureAccepted, 0),
+			ListOfConfigurationStructuresFailed:   make([]ConfigurationStructureFailed, 0),
+		}
+		var cell *model.Cell
+		ncgi, err := sm.getNodeCell(cellControl.CellGlobalID)
+		if err == nil {
+			cell, err = sm.ServiceModel.CellStore.Get(ctx, ncgi)
+		}
+		if err != nil {
+			log.Warn(err)
+			for _, control := range cellControl.ListOfConfigurationStructures {
+				cellOutcome.ListOfConfigurationStructuresFailed = append(cellOutcome.ListOfConfigurationStructuresFailed, ConfigurationStructureFailed{
+					RanConfigurationStructureName: control.RanConfigurationStructureName,
+					OldValuesOfAttributes:         control.OldValuesOfAttributes,
+					RequestedValuesOfAttributes:   control.NewValuesOfAttributes,
+					Cause:                         CauseNotAvailable,
+				})
+			}
+			outcome.ListOfCellsControlled = append(outcome.ListOfCellsControlled, cellOutcome)
+			continue
+		}
+
+		for _, control := range cellControl.ListOfConfigurationStructures {
+			names, _ := cellAttributeNames(control.RanConfigurationStructureName)
+			oldValues := cellValues(cell, control.RanConfigurationStructureName, names)
+			cause, err := controlCell(cell, control)
+			if err != nil {
+				log.Warnf("Failed to control %s of the cell (%v): %v", control.RanConfigurationStructureName, ncgi, err)
+				cellOutcome.ListOfConfigurationStructuresFailed = append(cellOutcome.ListOfConfigurationStructuresFailed, ConfigurationStructureFailed{
+					RanConfigurationStructureName: control.RanConfigurationStructureName,
+					OldValuesOfAttributes:         oldValues,
+					RequestedValuesOfAttributes:   control.NewValuesOfAttributes,
+					Cause:                         cause,
+				})
+				continue
+			}
+			log.Infof("%s of the cell (%v) is set to %v", control.RanConfigurationStructureName, ncgi, control.NewValuesOfAttributes)
+			cellOutcome.ListOfConfigurationStructuresAccepted = append(cellOutcome.ListOfConfigurationStructuresAccepted, ConfigurationStructureAccepted{
+				RanConfigurationStructureName: control.RanConfigurationStructureName,
+				OldValuesOfAttributes:         oldValues,
+				CurrentValuesOfAttributes:     cellValues(cell, control.RanConfigurationStructureName, names),
+				AppliedTimestamp:              time.Now().Format(time.RFC3339Nano),
+			})
+		}
+
+		if len(cellOutcome.ListOfConfigurationStructuresAccepted) > 0 {
+			if err := sm.ServiceModel.CellStore.Update(ctx, cell); err != nil {
+				log.Warn(err)
+				for _, structure := range cellOutcome.ListOfConfigurationStructuresAccepted {
+					cellOutcome.ListOfConfigurationStructuresFailed = append(cellOutcome.ListOfConfigurationStructuresFailed, ConfigurationStructureFailed{
+						RanConfigurationStructureName: structure.RanConfigurationStructureName,
+						OldValuesOfAttributes:         structure.OldValuesOfAttributes,
+						RequestedValuesOfAttributes:   structure.CurrentValuesOfAttributes,
+						Cause:                         CauseUnspecified,
+					})
+				}
+				cellOutcome.ListOfConfigurationStructuresAccepted = make([]ConfigurationStructureAccepted, 0)
+			}
+		}
+		accepted += len(cellOutcome.ListOfConfigurationStructuresAccepted)
+		outcome.ListOfCellsControlled = append(outcome.ListOfCellsControlled, cellOutcome)
+	}
+	return &ControlOutcome{ControlOutcomeFormat: ControlOutcomeFormat{ControlOutcomeFormat2: outcome}}, accepted
+}
+
+// RICSubscription implements subscription handler for ccc service model
+func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
+	log.Infof("RIC Subscription request received for e2 node %d and service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	var ricActionsAccepted []*e2aptypes.RicActionID
+	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
+	actionList := subutils.GetRicActionToBeSetupList(request)
+	reqID, err := subutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionFailure := func(cause e2apies.CauseRicrequest) (*e2appducontents.RicsubscriptionFailure, error) {
+		return subutils.NewSubscription(
+			subutils.WithRequestID(*reqID),
+			subutils.WithRanFuncID(*ranFuncID),
+			subutils.WithRicInstanceID(*ricInstanceID),
+			subutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			})).BuildSubscriptionFailure()
+	}
+
+	eventTrigger, err := sm.getEventTriggerDefinition(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	reports := make([]*report, 0)
+	for _, action := range actionList {
+		actionID := e2aptypes.RicActionID(action.GetValue().GetRicactionToBeSetupItem().GetRicActionId().GetValue())
+		actionType := action.GetValue().GetRicactionToBeSetupItem().GetRicActionType()
+		// ccc service model supports report actions only
+		if actionType != e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		r, err := sm.getReport(action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue())
+		if err != nil {
+			log.Warn(err)
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		ricActionsAccepted = append(ricActionsAccepted, &actionID)
+		reports = append(reports, r)
+	}
+
+	// At least one required action must be accepted otherwise sends a subscription failure response
+	if len(ricActionsAccepted) == 0 {
+		log.Warn("no action is accepted")
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED)
+		return nil, failure, err
+	}
+
+	subscription := subutils.NewSubscription(
+		subutils.WithRequestID(*reqID),
+		subutils.WithRanFuncID(*ranFuncID),
+		subutils.WithRicInstanceID(*ricInstanceID),
+		subutils.WithActionsAccepted(ricActionsAccepted),
+		subutils.WithActionsNotAdmitted(ricActionsNotAdmitted))
+	response, err = subscription.BuildSubscriptionResponse()
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	reportCtx, cancel := context.WithCancel(context.Background())
+	sm.mu.Lock()
+	sm.reports[subID] = cancel
+	sm.mu.Unlock()
+	go func() {
+		defer sm.stopReport(subID)
+		err := sm.reportIndication(reportCtx, subscription, eventTrigger, reports)
+		if err != nil {
+			log.Warn(err)
+		}
+	}()
+	return response, nil, nil
+}
+
+// Targets returns whether the subscription reports on the cell: its actions report on the cell-level configuration
+// structures of the cell or of all the cells of the node
+func (sm *Client) Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	for _, action := range sub.Details.GetRicActionToBeSetupList().GetValue() {
+		actionDefinition := &ActionDefinition{}
+		if err := decode(action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue(), actionDefinition); err != nil {
+			log.Warn(err)
+			continue
+		}
+		format2 := actionDefinition.ActionDefinitionFormat.ActionDefinitionFormat2
+		if format2 == nil {
+			continue
+		}
+		for _, cellConfigurations := range format2.ListOfCellConfigurationsToBeReportedForADF {
+			if cellConfigurations.CellGlobalID == nil {
+				return true
+			}
+			if target, err := cellConfigurations.CellGlobalID.NCGI(); err == nil && target == ncgi {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RICSubscriptionDelete implements subscription delete handler for ccc service model
+func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	log.Infof("RIC subscription delete request is received for e2 node %d and  service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	reqID, err := subdeleteutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subdeleteutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subdeleteutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	_, err = sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
+		subdeleteutils.WithRequestID(*reqID),
+		subdeleteutils.WithRanFuncID(*ranFuncID),
+		subdeleteutils.WithRicInstanceID(*ricInstanceID))
+	response, err = subscriptionDelete.BuildSubscriptionDeleteResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stops the goroutine sending the indication messages
+	sm.stopReport(subID)
+	return response, nil, nil
+}
+
+func (sm *Client) stopReport(subID subscriptions.ID) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cancel, ok := sm.reports[subID]; ok {
+		cancel()
+		delete(sm.reports, subID)
+	}
+}
+
+// reportIndication sends the configuration structures requested by the REPORT actions of a subscription,
+// either periodically or upon subscription and whenever they change
+func (sm *Client) reportIndication(ctx context.Context, subscription *subutils.Subscription, eventTrigger *EventTriggerDefinition, reports []*report) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	if periodic := eventTrigger.EventTriggerDefinitionFormat.EventTriggerFormat3; periodic != nil {
+		log.Debugf("Starting periodic report with interval %d ms", periodic.Period)
+		ticker := time.NewTicker(time.Duration(periodic.Period) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, r := range reports {
+					sm.updateValues(ctx, r)
+					if err := sm.sendRicIndication(ctx, sub, subscription, IndicationReasonPeriodic, r, r.items); err != nil {
+						log.Warn(err)
+					}
+				}
+			case <-ctx.Done():
+				return nil
+			case <-sub.E2Channel.Context().Done():
+				return nil
+			}
+		}
+	}
+
+	for _, r := range reports {
+		sm.updateValues(ctx, r)
+		if err := sm.sendRicIndication(ctx, sub, subscription, IndicationReasonUponSubscription, r, r.items); err != nil {
+			return err
+		}
+	}
+	cellTrigger := eventTrigger.EventTriggerDefinitionFormat.EventTriggerFormat2
+	if cellTrigger == nil {
+		// node-level configuration structures do not change for the lifetime of the node
+		<-ctx.Done()
+		return nil
+	}
+
+	ch := make(chan event.Event)
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = sm.ServiceModel.CellStore.Watch(watchCtx, ch)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case cellEvent, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			cell := cellEvent.Value.(*model.Cell)
+			if !isTriggeredCell(cellTrigger, cell.NCGI) {
+				continue
+			}
+			switch cellEvent.Type.(cells.CellEvent) {
+			case cells.Updated:
+				for _, r := range reports {
+					changed := sm.changedItems(r, cellTrigger, cell)
+					if len(changed) == 0 {
+						continue
+					}
+					if err := sm.sendRicIndication(ctx, sub, subscription, IndicationReasonUponChange, r, changed); err != nil {
+						log.Warn(err)
+					}
+				}
+			case cells.Deleted:
+				for _, r := range reports {
+					deleted := deletedItems(r, cell.NCGI)
+					if len(deleted) == 0 {
+						continue
+					}
+					if err := sm.sendRicIndication(ctx, sub, subscription, IndicationReasonUponChange, r, deleted); err != nil {
+						log.Warn(err)
+					}
+				}
+			}
+		case <-sub.E2Channel.Context().Done():
+			log.Debug("E2 channel context is done")
+			return nil
+		}
+	}
+}
+
+// updateValues reads the current values of all configuration structures of the report
+func (sm *Client) updateValues(ctx context.Context, r *report) {
+	for _, item := range r.items {
+		if item.ncgi == 0 {
+			item.values = nodeValues(&sm.ServiceModel.Node, item.structure, item.attributes)
+			continue
+		}
+		cell, err := sm.ServiceModel.CellStore.Get(ctx, item.ncgi)
+		if err != nil {
+			item.values = nil
+			continue
+		}
+		item.values = cellValues(cell, item.structure, item.attributes)
+	}
+}
+
+func isTriggeredCell(trigger *EventTriggerFormat2, ncgi ransimtypes.NCGI) bool {
+	if trigger.CellGlobalID == nil {
+		return true
+	}
+	triggerNCGI, err := trigger.CellGlobalID.NCGI()
+	return err == nil && triggerNCGI == ncgi
+}
+
+func isTriggeredStructure(trigger *EventTriggerFormat2, item *reportItem, previous AttributeValues, current AttributeValues) bool {
+	if len(trigger.ListOfCellLevelConfigurationStructuresForEventTrigger) == 0 {
+		return true
+	}
+	for _, ref := range trigger.ListOfCellLevelConfigurationStructuresForEventTrigger {
+		if ref.RanConfigurationStructureName != item.structure {
+			continue
+		}
+		if len(ref.ListOfAttributes) == 0 {
+			return true
+		}
+		for _, name := range ref.ListOfAttributes {
+			if !equalValues(previous[name], current[name]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// changedItems returns the configuration structures of the cell whose values changed since they were last reported.
+// The reported values are updated even if the change does not trigger a report, so that the old values of the next
+// report are the values just before that change.
+func (sm *Client) changedItems(r *report, trigger *EventTriggerFormat2, cell *model.Cell) []*reportItem {
+	changed := make([]*reportItem, 0)
+	for _, item := range r.items {
+		if item.ncgi != cell.NCGI {
+			continue
+		}
+		current := cellValues(cell, item.structure, item.attributes)
+		if item.values != nil && !changedValues(item.values, current) {
+			continue
+		}
+		previous := item.values
+		item.values = current
+		if previous != nil && !isTriggeredStructure(trigger, item, previous, current) {
+			continue
+		}
+		changed = append(changed, &reportItem{
+			ncgi:      item.ncgi,
+			structure: item.structure,
+			values:    current,
+			old:       previous,
+		})
+	}
+	return changed
+}
+
+// deletedItems returns the configuration structures of the deleted cell
+func deletedItems(r *report, ncgi ransimtypes.NCGI) []*reportItem {
+	deleted := make([]*reportItem, 0)
+	for _, item := range r.items {
+		if item.ncgi != ncgi || item.values == nil {
+			continue
+		}
+		deleted = append(deleted, &reportItem{
+			ncgi:      item.ncgi,
+			structure: item.structure,
+			old:       item.values,
+			deleted:   true,
+		})
+		item.values = nil
+	}
+	return deleted
+}
+
+func (sm *Client) sendRicIndication(ctx context.Context, sub *subscriptions.Subscription, subscription *subutils.Subscription, reason string, r *report, items []*reportItem) error {
+	indicationHeader := &IndicationHeader{
+		IndicationHeaderFormat: IndicationHeaderFormat{
+			IndicationHeaderFormat1: &IndicationHeaderFormat1{
+				IndicationReason: reason,
+				EventTime:        time.Now().Format(time.RFC3339Nano),
+			},
+		},
+	}
+	indicationMessage := &IndicationMessage{}
+	if r.style == NodeConfigurationStyle {
+		format1 := &IndicationMessageFormat1{
+			ListOfConfigurationStructuresReported: make([]ConfigurationStructureReported, 0),
+		}
+		for _, item := range items {
+			format1.ListOfConfigurationStructuresReported = append(format1.ListOfConfigurationStructuresReported, item.reported())
+		}
+		indicationMessage.IndicationMessageFormat.IndicationMessageFormat1 = format1
+	} else {
+		format2 := &IndicationMessageFormat2{
+			ListOfCellsReported: make([]CellReported, 0),
+		}
+		for _, item := range items {
+			if item.values == nil && !item.deleted {
+				// the cell does not exist
+				continue
+			}
+			var cellReported *CellReported
+			for i := range format2.ListOfCellsReported {
+				if ncgi, _ := format2.ListOfCellsReported[i].CellGlobalID.NCGI(); ncgi == item.ncgi {
+					cellReported = &format2.ListOfCellsReported[i]
+					break
+				}
+			}
+			if cellReported == nil {
+				format2.ListOfCellsReported = append(format2.ListOfCellsReported, CellReported{
+					CellGlobalID:                          NewCellGlobalID(item.ncgi),
+					ListOfConfigurationStructuresReported: make([]ConfigurationStructureReported, 0),
+				})
+				cellReported = &format2.ListOfCellsReported[len(format2.ListOfCellsReported)-1]
+			}
+			cellReported.ListOfConfigurationStructuresReported = append(cellReported.ListOfConfigurationStructuresReported, item.reported())
+		}
+		if len(format2.ListOfCellsReported) == 0 {
+			return nil
+		}
+		indicationMessage.IndicationMessageFormat.IndicationMessageFormat2 = format2
+	}
+
+	indicationHeaderBytes, err := json.Marshal(indicationHeader)
+	if err != nil {
+		return err
+	}
+	indicationMessageBytes, err := json.Marshal(indicationMessage)
+	if err != nil {
+		return err
+	}
+	indication := e2apIndicationUtils.NewIndication(
+		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+	ricIndication, err := indication.Build()
+	if err != nil {
+		return err
+	}
+	log.Debugf("Sending %s indication for subscription %v", reason, sub.ID)
+	return sub.E2Channel.RICIndication(ctx, ricIndication)
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"encoding/json"
+	"testing"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNCGI = ransimtypes.NCGI(84325717761)
+
+func TestCellGlobalID(t *testing.T) {
+	cellGlobalID := NewCellGlobalID(testNCGI)
+	ncgi, err := cellGlobalID.NCGI()
+	assert.NoError(t, err)
+	assert.Equal(t, testNCGI, ncgi)
+
+	_, err = CellGlobalID{NRCGI: NRCGI{PlmnIdentity: "xyz", NRCellIdentity: "1"}}.NCGI()
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestControlCell(t *testing.T) {
+	cell := &model.Cell{NCGI: testNCGI, PCI: 10, Earfcn: 100, Bandwidth: 20, TxPowerDB: 11}
+
+	cause, err := controlCell(cell, ConfigurationStructureControl{
+		RanConfigurationStructureName: NRCellDUStructure,
+		NewValuesOfAttributes:         AttributeValues{NRPCIAttribute: float64(42), CellBarredAttribute: CellBarred},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", cause)
+	assert.Equal(t, uint32(42), cell.PCI)
+	assert.True(t, cell.Barred)
+
+	// an invalid value leaves the whole structure unchanged
+	cause, err = controlCell(cell, ConfigurationStructureControl{
+		RanConfigurationStructureName: NRCellDUStructure,
+		NewValuesOfAttributes:         AttributeValues{NRPCIAttribute: float64(7), ChannelBandwidthDLAttribute: float64(35)},
+	})
+	assert.True(t, errors.IsInvalid(err))
+	assert.Equal(t, CauseSemanticError, cause)
+	assert.Equal(t, uint32(42), cell.PCI)
+	assert.Equal(t, uint32(20), cell.Bandwidth)
+
+	// old values must match the current values
+	cause, err = controlCell(cell, ConfigurationStructureControl{
+		RanConfigurationStructureName: NRSectorCarrierStructure,
+		OldValuesOfAttributes:         AttributeValues{ConfiguredMaxTxPowerAttribute: float64(10)},
+		NewValuesOfAttributes:         AttributeValues{ConfiguredMaxTxPowerAttribute: float64(20)},
+	})
+	assert.True(t, errors.IsConflict(err))
+	assert.Equal(t, CauseIncompatibleState, cause)
+	assert.Equal(t, 11.0, cell.TxPowerDB)
+
+	cause, err = controlCell(cell, ConfigurationStructureControl{
+		RanConfigurationStructureName: NRSectorCarrierStructure,
+		OldValuesOfAttributes:         AttributeValues{ConfiguredMaxTxPowerAttribute: float64(11)},
+		NewValuesOfAttributes:         AttributeValues{ConfiguredMaxTxPowerAttribute: float64(20), ElectricalAntennaTiltAttribute: float64(6)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", cause)
+	assert.Equal(t, 20.0, cell.TxPowerDB)
+	assert.Equal(t, 6.0, cell.Tilt)
+
+	_, err = controlCell(cell, ConfigurationStructureControl{
+		RanConfigurationStructureName: GNBDUFunctionStructure,
+		NewValuesOfAttributes:         AttributeValues{GNBIDAttribute: float64(1)},
+	})
+	assert.True(t, errors.IsNotSupported(err))
+}
+
+func TestGetReport(t *testing.T) {
+	sm := &Client{
+		ServiceModel: &registry.ServiceModel{
+			Node: model.Node{GnbID: 5153, Cells: []ransimtypes.NCGI{testNCGI}},
+		},
+	}
+
+	actionDefinition := &ActionDefinition{
+		RicStyleType: CellConfigurationStyle,
+		ActionDefinitionFormat: ActionDefinitionFormat{
+			ActionDefinitionFormat2: &ActionDefinitionFormat2{
+				ListOfCellConfigurationsToBeReportedForADF: []CellConfigurationsToBeReported{
+					{
+						ListOfCellLevelRANConfigurationStructuresForADF: []ConfigurationStructureReference{
+							{
+								RanConfigurationStructureName: NRSectorCarrierStructure,
+								ListOfAttributes:              []string{ElectricalAntennaTiltAttribute},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	bytes, err := json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	r, err := sm.getReport(bytes)
+	assert.NoError(t, err)
+	assert.Len(t, r.items, 1)
+	assert.Equal(t, testNCGI, r.items[0].ncgi)
+	assert.Equal(t, []string{ElectricalAntennaTiltAttribute}, r.items[0].attributes)
+
+	actionDefinition.ActionDefinitionFormat.ActionDefinitionFormat2.ListOfCellConfigurationsToBeReportedForADF[0].ListOfCellLevelRANConfigurationStructuresForADF[0].ListOfAttributes = []string{"unknown"}
+	bytes, err = json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	_, err = sm.getReport(bytes)
+	assert.True(t, errors.IsNotSupported(err))
+
+	_, err = sm.getReport([]byte("not json"))
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestTargets(t *testing.T) {
+	sm := &Client{}
+	cellConfigurations := func(cellGlobalID *CellGlobalID) *ActionDefinition {
+		return &ActionDefinition{
+			RicStyleType: CellConfigurationStyle,
+			ActionDefinitionFormat: ActionDefinitionFormat{
+				ActionDefinitionFormat2: &ActionDefinitionFormat2{
+					ListOfCellConfigurationsToBeReportedForADF: []CellConfigurationsToBeReported{{CellGlobalID: cellGlobalID}},
+				},
+			},
+		}
+	}
+	cellGlobalID := NewCellGlobalID(testNCGI)
+
+	// the subscription reports on the cell or on all the cells of the node
+	assert.True(t, sm.Targets(testSubscription(t, cellConfigurations(&cellGlobalID)), testNCGI))
+	assert.True(t, sm.Targets(testSubscription(t, cellConfigurations(nil)), testNCGI))
+
+	// the subscription reports on another cell or on the node only
+	assert.False(t, sm.Targets(testSubscription(t, cellConfigurations(&cellGlobalID)), testNCGI+1))
+	assert.False(t, sm.Targets(testSubscription(t, &ActionDefinition{
+		RicStyleType: NodeConfigurationStyle,
+		ActionDefinitionFormat: ActionDefinitionFormat{
+			ActionDefinitionFormat1: &ActionDefinitionFormat1{},
+		},
+	}), testNCGI))
+}
+
+// testSubscription returns a subscription with a REPORT action of the given definition
+func testSubscription(t *testing.T, actionDefinition interface{}) *subscriptions.Subscription {
+	bytes, err := json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	return &subscriptions.Subscription{
+		Details: &e2appducontents.RicsubscriptionDetails{
+			RicActionToBeSetupList: &e2appducontents.RicactionsToBeSetupList{
+				Value: []*e2appducontents.RicactionToBeSetupItemIes{
+					{
+						Value: &e2appducontents.RicactionToBeSetupItemIe{
+							RicactionToBeSetupItemIe: &e2appducontents.RicactionToBeSetupItemIe_RicactionToBeSetupItem{
+								RicactionToBeSetupItem: &e2appducontents.RicactionToBeSetupItem{
+									RicActionId:         &e2apies.RicactionId{Value: 1},
+									RicActionType:       e2apies.RicactionType_RICACTION_TYPE_REPORT,
+									RicActionDefinition: &e2apcommondatatypes.RicactionDefinition{Value: bytes},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+const (
+	modelFullName = "ORAN-E2SM-CCC"
+	version       = "v1"
+	modelOID      = "1.3.6.1.4.1.53148.1.1.2.4"
+	description   = "Cell Configuration and Control"
+)
+
+// RIC styles; the same style numbers are used for REPORT and CONTROL
+const (
+	// NodeConfigurationStyle reports and controls node-level configuration structures
+	NodeConfigurationStyle = 1
+	// CellConfigurationStyle reports and controls cell-level configuration structures
+	CellConfigurationStyle = 2
+)
+
+// RAN configuration structures
+const (
+	// GNBDUFunctionStructure node-level structure of the gNB-DU
+	GNBDUFunctionStructure = "O-GNBDUFunction"
+	// NRCellDUStructure cell-level structure of the NR cell
+	NRCellDUStructure = "O-NRCellDU"
+	// NRSectorCarrierStructure cell-level structure of the carrier and antenna serving the NR cell
+	NRSectorCarrierStructure = "O-NRSectorCarrier"
+)
+
+// RAN configuration attributes
+const (
+	// GNBIDAttribute gNB ID
+	GNBIDAttribute = "gNBId"
+	// GNBIDLengthAttribute gNB ID length in bits
+	GNBIDLengthAttribute = "gNBIdLength"
+	// NRPCIAttribute physical cell ID
+	NRPCIAttribute = "nRPCI"
+	// ARFCNDLAttribute downlink ARFCN
+	ARFCNDLAttribute = "arfcnDL"
+	// ChannelBandwidthDLAttribute downlink channel bandwidth in MHz
+	ChannelBandwidthDLAttribute = "bSChannelBwDL"
+	// CellBarredAttribute cell barring; one of CellBarred and CellNotBarred
+	CellBarredAttribute = "cellBarred"
+	// ConfiguredMaxTxPowerAttribute transmission power in dBm
+	ConfiguredMaxTxPowerAttribute = "configuredMaxTxPower"
+	// ElectricalAntennaTiltAttribute electrical antenna downtilt in degrees
+	ElectricalAntennaTiltAttribute = "electricalAntennaTilt"
+)
+
+// Values of the cell barred attribute
+const (
+	CellBarred    = "BARRED"
+	CellNotBarred = "NOT_BARRED"
+)
+
+// Indication reasons
+const (
+	IndicationReasonUponSubscription = "uponSubscription"
+	IndicationReasonUponChange       = "uponChange"
+	IndicationReasonPeriodic         = "periodic"
+)
+
+// Change types of a reported configuration structure
+const (
+	ChangeTypeNone         = "none"
+	ChangeTypeModification = "modification"
+	ChangeTypeDeletion     = "deletion"
+)
+
+// Causes of a failed configuration structure control
+const (
+	CauseNotSupported      = "NOT_SUPPORTED"
+	CauseNotAvailable      = "NOT_AVAILABLE"
+	CauseIncompatibleState = "INCOMPATIBLE_STATE"
+	CauseSemanticError     = "SEMANTIC_ERROR"
+	CauseUnspecified       = "UNSPECIFIED"
+)
+
+const gnbIDLength = 22
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+)
+
+// E2SM-CCC messages are JSON encoded, as specified by O-RAN; the types below cover the node-level and cell-level
+// configuration structures the simulator reports and controls.
+
+// AttributeValues are the values of RAN configuration attributes keyed by attribute name
+type AttributeValues map[string]interface{}
+
+// RanFunctionDefinition RAN function definition
+type RanFunctionDefinition struct {
+	RanFunctionName                                 servicemodel.RanFunctionName      `json:"ranFunctionName"`
+	ListOfSupportedNodeLevelConfigurationStructures []SupportedConfigurationStructure `json:"listOfSupportedNodeLevelConfigurationStructures,omitempty"`
+	ListOfCellsForRANFunctionDefinition             []CellForRANFunctionDefinition    `json:"listOfCellsForRANFunctionDefinition,omitempty"`
+}
+
+// CellForRANFunctionDefinition cell-level configuration structures supported by a cell
+type CellForRANFunctionDefinition struct {
+	CellGlobalID                                       CellGlobalID                      `json:"cellGlobalId"`
+	ListOfSupportedCellLevelRANConfigurationStructures []SupportedConfigurationStructure `json:"listOfSupportedCellLevelRANConfigurationStructures"`
+}
+
+// SupportedConfigurationStructure configuration structure supported by the node or a cell
+type SupportedConfigurationStructure struct {
+	RanConfigurationStructureName string               `json:"ranConfigurationStructureName"`
+	ListOfSupportedAttributes     []SupportedAttribute `json:"listOfSupportedAttributes"`
+}
+
+// SupportedAttribute attribute supported by a configuration structure
+type SupportedAttribute struct {
+	AttributeName     string            `json:"attributeName"`
+	SupportedServices SupportedServices `json:"supportedServices"`
+}
+
+// SupportedServices RIC services supported for an attribute
+type SupportedServices struct {
+	ReportService  *ServiceStyles `json:"reportService,omitempty"`
+	ControlService *ServiceStyles `json:"controlService,omitempty"`
+}
+
+// ServiceStyles RIC styles of a service
+type ServiceStyles struct {
+	RicStyleTypes []int32 `json:"ricStyleTypes"`
+}
+
+// CellGlobalID NR cell global ID
+type CellGlobalID struct {
+	NRCGI NRCGI `json:"nRCGI"`
+}
+
+// NRCGI NR CGI; the PLMN identity and NR cell identity are hex strings
+type NRCGI struct {
+	PlmnIdentity   string `json:"pLMNIdentity"`
+	NRCellIdentity string `json:"nRCellIdentity"`
+}
+
+// EventTriggerDefinition event trigger definition
+type EventTriggerDefinition struct {
+	EventTriggerDefinitionFormat EventTriggerDefinitionFormat `json:"eventTriggerDefinitionFormat"`
+}
+
+// EventTriggerDefinitionFormat event trigger definition formats
+type EventTriggerDefinitionFormat struct {
+	// EventTriggerFormat1 reports upon change of node-level configuration
+	EventTriggerFormat1 *EventTriggerFormat1 `json:"eventTriggerFormat1,omitempty"`
+	// EventTriggerFormat2 reports upon change of cell-level configuration
+	EventTriggerFormat2 *EventTriggerFormat2 `json:"eventTriggerFormat2,omitempty"`
+	// EventTriggerFormat3 reports periodically
+	EventTriggerFormat3 *EventTriggerFormat3 `json:"eventTriggerFormat3,omitempty"`
+}
+
+// EventTriggerFormat1 node-level configuration change event trigger
+type EventTriggerFormat1 struct {
+	ListOfNodeLevelConfigurationStructuresForEventTrigger []ConfigurationStructureReference `json:"listOfNodeLevelConfigurationStructuresForEventTrigger,omitempty"`
+}
+
+// EventTriggerFormat2 cell-level configuration change event trigger
+type EventTriggerFormat2 struct {
+	CellGlobalID                                          *CellGlobalID                     `json:"cellGlobalId,omitempty"`
+	ListOfCellLevelConfigurationStructuresForEventTrigger []ConfigurationStructureReference `json:"listOfCellLevelConfigurationStructuresForEventTrigger,omitempty"`
+}
+
+// EventTriggerFormat3 periodic event trigger
+type EventTriggerFormat3 struct {
+	// Period reporting period in milliseconds
+	Period int64 `json:"period"`
+}
+
+// ConfigurationStructureReference refers to a configuration structure, optionally restricted to some of its attributes
+type ConfigurationStructureReference struct {
+	RanConfigurationStructureName string   `json:"ranConfigurationStructureName"`
+	ListOfAttributes              []string `json:"listOfAttributes,omitempty"`
+}
+
+// ActionDefinition action definition
+type ActionDefinition struct {
+	RicStyleType           int32                  `json:"ricStyleType"`
+	ActionDefinitionFormat ActionDefinitionFormat `json:"actionDefinitionFormat"`
+}
+
+// ActionDefinitionFormat action definition formats
+type ActionDefinitionFormat struct {
+	// ActionDefinitionFormat1 reports node-level configuration structures
+	ActionDefinitionFormat1 *ActionDefinitionFormat1 `json:"actionDefinitionFormat1,omitempty"`
+	// ActionDefinitionFormat2 reports cell-level configuration structures
+	ActionDefinitionFormat2 *ActionDefinitionFormat2 `json:"actionDefinitionFormat2,omitempty"`
+}
+
+// ActionDefinitionFormat1 node-level configuration structures to be reported
+type ActionDefinitionFormat1 struct {
+	ListOfNodeLevelRANConfigurationStructuresForADF []ConfigurationStructureReference `json:"listOfNodeLevelRANConfigurationStructuresForADF"`
+}
+
+// ActionDefinitionFormat2 cell-level configuration structures to be reported
+type ActionDefinitionFormat2 struct {
+	ListOfCellConfigurationsToBeReportedForADF []CellConfigurationsToBeReported `json:"listOfCellConfigurationsToBeReportedForADF"`
+}
+
+// CellConfigurationsToBeReported configuration structures to be reported for a cell; all cells of the node if the cell is omitted
+type CellConfigurationsToBeReported struct {
+	CellGlobalID                                    *CellGlobalID                     `json:"cellGlobalId,omitempty"`
+	ListOfCellLevelRANConfigurationStructuresForADF []ConfigurationStructureReference `json:"listOfCellLevelRANConfigurationStructuresForADF"`
+}
+
+// IndicationHeader indication header
+type IndicationHeader struct {
+	IndicationHeaderFormat IndicationHeaderFormat `json:"indicationHeaderFormat"`
+}
+
+// IndicationHeaderFormat indication header formats
+type IndicationHeaderFormat struct {
+	IndicationHeaderFormat1 *IndicationHeaderFormat1 `json:"indicationHeaderFormat1,omitempty"`
+}
+
+// IndicationHeaderFormat1 indication header format 1
+type IndicationHeaderFormat1 struct {
+	IndicationReason string `json:"indicationReason"`
+	EventTime        string `json:"eventTime"`
+}
+
+// IndicationMessage indication message
+type IndicationMessage struct {
+	IndicationMessageFormat IndicationMessageFormat `json:"indicationMessageFormat"`
+}
+
+// IndicationMessageFormat indication message formats
+type IndicationMessageFormat struct {
+	// IndicationMessageFormat1 reports node-level configuration structures
+	IndicationMessageFormat1 *IndicationMessageFormat1 `json:"indicationMessageFormat1,omitempty"`
+	// IndicationMessageFormat2 reports cell-level configuration structures
+	IndicationMessageFormat2 *IndicationMessageFormat2 `json:"indicationMessageFormat2,omitempty"`
+}
+
+// IndicationMessageFormat1 node-level configuration structures reported
+type IndicationMessageFormat1 struct {
+	ListOfConfigurationStructuresReported []ConfigurationStructureReported `json:"listOfConfigurationStructuresReported"`
+}
+
+// IndicationMessageFormat2 cell-level configuration structures reported
+type IndicationMessageFormat2 struct {
+	ListOfCellsReported []CellReported `json:"listOfCellsReported"`
+}
+
+// CellReported configuration structures reported for a cell
+type CellReported struct {
+	CellGlobalID                          CellGlobalID                     `json:"cellGlobalId"`
+	ListOfConfigurationStructuresReported []ConfigurationStructureReported `json:"listOfConfigurationStructuresReported"`
+}
+
+// ConfigurationStructureReported reported configuration structure
+type ConfigurationStructureReported struct {
+	ChangeType                    string          `json:"changeType"`
+	RanConfigurationStructureName string          `json:"ranConfigurationStructureName"`
+	ValuesOfAttributes            AttributeValues `json:"valuesOfAttributes"`
+	OldValuesOfAttributes         AttributeValues `json:"oldValuesOfAttributes,omitempty"`
+}
+
+// ControlHeader control header
+type ControlHeader struct {
+	ControlHeaderFormat ControlHeaderFormat `json:"controlHeaderFormat"`
+}
+
+// ControlHeaderFormat control header formats
+type ControlHeaderFormat struct {
+	ControlHeaderFormat1 *ControlHeaderFormat1 `json:"controlHeaderFormat1,omitempty"`
+}
+
+// ControlHeaderFormat1 control header format 1
+type ControlHeaderFormat1 struct {
+	RicStyleType int32 `json:"ricStyleType"`
+}
+
+// ControlMessage control message
+type ControlMessage struct {
+	ControlMessageFormat ControlMessageFormat `json:"controlMessageFormat"`
+}
+
+// ControlMessageFormat control message formats
+type ControlMessageFormat struct {
+	// ControlMessageFormat1 controls node-level configuration structures
+	ControlMessageFormat1 *ControlMessageFormat1 `json:"controlMessageFormat1,omitempty"`
+	// ControlMessageFormat2 controls cell-level configuration structures
+	ControlMessageFormat2 *ControlMessageFormat2 `json:"controlMessageFormat2,omitempty"`
+}
+
+// ControlMessageFormat1 node-level configuration structures to be controlled
+type ControlMessageFormat1 struct {
+	ListOfConfigurationStructures []ConfigurationStructureControl `json:"listOfConfigurationStructures"`
+}
+
+// ControlMessageFormat2 cell-level configuration structures to be controlled
+type ControlMessageFormat2 struct {
+	ListOfCellsControlled []CellControl `json:"listOfCellsControlled"`
+}
+
+// CellControl configuration structures to be controlled in a cell
+type CellControl struct {
+	CellGlobalID                  CellGlobalID                    `json:"cellGlobalId"`
+	ListOfConfigurationStructures []ConfigurationStructureControl `json:"listOfConfigurationStructures"`
+}
+
+// ConfigurationStructureControl new values of the attributes of a configuration structure; if the old
+// values are given, the control is only executed if they match the current values
+type ConfigurationStructureControl struct {
+	RanConfigurationStructureName string          `json:"ranConfigurationStructureName"`
+	OldValuesOfAttributes         AttributeValues `json:"oldValuesOfAttributes,omitempty"`
+	NewValuesOfAttributes         AttributeValues `json:"newValuesOfAttributes"`
+}
+
+// ControlOutcome control outcome
+type ControlOutcome struct {
+	ControlOutcomeFormat ControlOutcomeFormat `json:"controlOutcomeFormat"`
+}
+
+// ControlOutcomeFormat control outcome formats
+type ControlOutcomeFormat struct {
+	ControlOutcomeFormat1 *ControlOutcomeFormat1 `json:"controlOutcomeFormat1,omitempty"`
+	ControlOutcomeFormat2 *ControlOutcomeFormat2 `json:"controlOutcomeFormat2,omitempty"`
+}
+
+// ControlOutcomeFormat1 outcome of a node-level control
+type ControlOutcomeFormat1 struct {
+	ReceivedTimestamp                     string                           `json:"receivedTimestamp"`
+	ListOfConfigurationStructuresAccepted []ConfigurationStructureAccepted `json:"listOfConfigurationStructuresAccepted"`
+	ListOfConfigurationStructuresFailed   []ConfigurationStructureFailed   `json:"listOfConfigurationStructuresFailed"`
+}
+
+// ControlOutcomeFormat2 outcome of a cell-level control
+type ControlOutcomeFormat2 struct {
+	ReceivedTimestamp     string        `json:"receivedTimestamp"`
+	ListOfCellsControlled []CellOutcome `json:"listOfCellsControlled"`
+}
+
+// CellOutcome outcome of the control of a cell
+type CellOutcome struct {
+	CellGlobalID                          CellGlobalID                     `json:"cellGlobalId"`
+	ListOfConfigurationStructuresAccepted []ConfigurationStructureAccepted `json:"listOfConfigurationStructuresAccepted"`
+	ListOfConfigurationStructuresFailed   []ConfigurationStructureFailed   `json:"listOfConfigurationStructuresFailed"`
+}
+
+// ConfigurationStructureAccepted configuration structure applied by a control
+type ConfigurationStructureAccepted struct {
+	RanConfigurationStructureName string          `json:"ranConfigurationStructureName"`
+	OldValuesOfAttributes         AttributeValues `json:"oldValuesOfAttributes"`
+	CurrentValuesOfAttributes     AttributeValues `json:"currentValuesOfAttributes"`
+	AppliedTimestamp              string          `json:"appliedTimestamp"`
+}
+
+// ConfigurationStructureFailed configuration structure rejected by a control
+type ConfigurationStructureFailed struct {
+	RanConfigurationStructureName string          `json:"ranConfigurationStructureName"`
+	OldValuesOfAttributes         AttributeValues `json:"oldValuesOfAttributes,omitempty"`
+	RequestedValuesOfAttributes   AttributeValues `json:"requestedValuesOfAttributes"`
+	Cause                         string          `json:"cause"`
+}
+
+// NewCellGlobalID returns the cell global ID of the specified NCGI
+func NewCellGlobalID(ncgi ransimtypes.NCGI) CellGlobalID {
+	return CellGlobalID{
+		NRCGI: NRCGI{
+			PlmnIdentity:   fmt.Sprintf("%06x", ransimtypes.GetPlmnID(uint64(ncgi))),
+			NRCellIdentity: fmt.Sprintf("%09x", ransimtypes.GetNCI(ncgi)),
+		},
+	}
+}
+
+// NCGI returns the NCGI of the cell global ID
+func (c CellGlobalID) NCGI() (ransimtypes.NCGI, error) {
+	plmnID, err := strconv.ParseUint(c.NRCGI.PlmnIdentity, 16, 24)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid PLMN identity %s", c.NRCGI.PlmnIdentity)
+	}
+	nci, err := strconv.ParseUint(c.NRCGI.NRCellIdentity, 16, 36)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid NR cell identity %s", c.NRCGI.NRCellIdentity)
+	}
+	return ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci)), nil
+}
+
+func decode(bytes []byte, message interface{}) error {
+	err := json.Unmarshal(bytes, message)
+	if err != nil {
+		return errors.NewInvalid("invalid %s message: %v", modelFullName, err)
+	}
+	return nil
+}
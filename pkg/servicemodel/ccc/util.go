@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"context"
+	"sort"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+)
+
+func (sm *Client) getEventTriggerDefinition(request *e2appducontents.RicsubscriptionRequest) (*EventTriggerDefinition, error) {
+	var eventTriggerBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
+			eventTriggerBytes = v.GetValue().GetRicsubscriptionDetails().GetRicEventTriggerDefinition().GetValue()
+			break
+		}
+	}
+	eventTrigger := &EventTriggerDefinition{}
+	if err := decode(eventTriggerBytes, eventTrigger); err != nil {
+		return nil, err
+	}
+	format := eventTrigger.EventTriggerDefinitionFormat
+	switch {
+	case format.EventTriggerFormat1 != nil:
+		for _, ref := range format.EventTriggerFormat1.ListOfNodeLevelConfigurationStructuresForEventTrigger {
+			if _, err := nodeAttributeNames(ref.RanConfigurationStructureName); err != nil {
+				return nil, err
+			}
+		}
+	case format.EventTriggerFormat2 != nil:
+		if cellGlobalID := format.EventTriggerFormat2.CellGlobalID; cellGlobalID != nil {
+			if _, err := sm.getNodeCell(*cellGlobalID); err != nil {
+				return nil, err
+			}
+		}
+		for _, ref := range format.EventTriggerFormat2.ListOfCellLevelConfigurationStructuresForEventTrigger {
+			if _, err := cellAttributeNames(ref.RanConfigurationStructureName); err != nil {
+				return nil, err
+			}
+		}
+	case format.EventTriggerFormat3 != nil:
+		if format.EventTriggerFormat3.Period <= 0 {
+			return nil, errors.NewInvalid("reporting period must be positive")
+		}
+	default:
+		return nil, errors.NewInvalid("event trigger format is missing")
+	}
+	return eventTrigger, nil
+}
+
+// getReport parses the action definition of a REPORT action into the configuration structures to be reported
+func (sm *Client) getReport(actionDefinitionBytes []byte) (*report, error) {
+	actionDefinition := &ActionDefinition{}
+	if err := decode(actionDefinitionBytes, actionDefinition); err != nil {
+		return nil, err
+	}
+	r := &report{
+		style: actionDefinition.RicStyleType,
+		items: make([]*reportItem, 0),
+	}
+	format := actionDefinition.ActionDefinitionFormat
+	switch {
+	case actionDefinition.RicStyleType == NodeConfigurationStyle && format.ActionDefinitionFormat1 != nil:
+		for _, ref := range format.ActionDefinitionFormat1.ListOfNodeLevelRANConfigurationStructuresForADF {
+			all, err := nodeAttributeNames(ref.RanConfigurationStructureName)
+			if err != nil {
+				return nil, err
+			}
+			attributes, err := selectAttributes(ref, all)
+			if err != nil {
+				return nil, err
+			}
+			r.items = append(r.items, &reportItem{
+				structure:  ref.RanConfigurationStructureName,
+				attributes: attributes,
+			})
+		}
+	case actionDefinition.RicStyleType == CellConfigurationStyle && format.ActionDefinitionFormat2 != nil:
+		for _, cellConfigurations := range format.ActionDefinitionFormat2.ListOfCellConfigurationsToBeReportedForADF {
+			cells := sm.ServiceModel.Node.Cells
+			if cellConfigurations.CellGlobalID != nil {
+				ncgi, err := sm.getNodeCell(*cellConfigurations.CellGlobalID)
+				if err != nil {
+					return nil, err
+				}
+				cells = []ransimtypes.NCGI{ncgi}
+			}
+			for _, ref := range cellConfigurations.ListOfCellLevelRANConfigurationStructuresForADF {
+				all, err := cellAttributeNames(ref.RanConfigurationStructureName)
+				if err != nil {
+					return nil, err
+				}
+				attributes, err := selectAttributes(ref, all)
+				if err != nil {
+					return nil, err
+				}
+				for _, ncgi := range cells {
+					r.items = append(r.items, &reportItem{
+						ncgi:       ncgi,
+						structure:  ref.RanConfigurationStructureName,
+						attributes: attributes,
+					})
+				}
+			}
+		}
+	default:
+		return nil, errors.NewNotSupported("action definition format is not supported for RIC style %d", actionDefinition.RicStyleType)
+	}
+	if len(r.items) == 0 {
+		return nil, errors.NewInvalid("no configuration structure to be reported")
+	}
+	return r, nil
+}
+
+func (sm *Client) getControlHeader(request *e2appducontents.RiccontrolRequest) (*ControlHeader, error) {
+	var controlHeaderBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolHeader) {
+			controlHeaderBytes = v.GetValue().GetRiccontrolHeader().GetValue()
+			break
+		}
+	}
+	controlHeader := &ControlHeader{}
+	if err := decode(controlHeaderBytes, controlHeader); err != nil {
+		return nil, err
+	}
+	if controlHeader.ControlHeaderFormat.ControlHeaderFormat1 == nil {
+		return nil, errors.NewInvalid("control header format is missing")
+	}
+	return controlHeader, nil
+}
+
+func (sm *Client) getControlMessage(request *e2appducontents.RiccontrolRequest) (*ControlMessage, error) {
+	var controlMessageBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolMessage) {
+			controlMessageBytes = v.GetValue().GetRiccontrolMessage().GetValue()
+			break
+		}
+	}
+	controlMessage := &ControlMessage{}
+	if err := decode(controlMessageBytes, controlMessage); err != nil {
+		return nil, err
+	}
+	return controlMessage, nil
+}
+
+// getNodeCell returns the NCGI of the cell global ID if the cell belongs to the node
+func (sm *Client) getNodeCell(cellGlobalID CellGlobalID) (ransimtypes.NCGI, error) {
+	ncgi, err := cellGlobalID.NCGI()
+	if err != nil {
+		return 0, err
+	}
+	for _, cell := range sm.ServiceModel.Node.Cells {
+		if cell == ncgi {
+			return ncgi, nil
+		}
+	}
+	return 0, errors.NewNotFound("cell %v does not belong to the node %v", ncgi, sm.ServiceModel.Node.GnbID)
+}
+
+func nodeStructureNames() []string {
+	names := make([]string, 0, len(nodeConfigurationStructures))
+	for name := range nodeConfigurationStructures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func cellStructureNames() []string {
+	names := make([]string, 0, len(cellConfigurationStructures))
+	for name := range cellConfigurationStructures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (sm *Client) createRanFunctionDefinition(ctx context.Context, cells []ransimtypes.NCGI) *RanFunctionDefinition {
+	definition := &RanFunctionDefinition{
+		RanFunctionName: servicemodel.RanFunctionName{
+			RanFunctionShortName:   modelFullName,
+			RanFunctionE2SmOid:     modelOID,
+			RanFunctionDescription: description,
+		},
+	}
+	reportNodeStyle := &ServiceStyles{RicStyleTypes: []int32{NodeConfigurationStyle}}
+	reportCellStyle := &ServiceStyles{RicStyleTypes: []int32{CellConfigurationStyle}}
+	controlCellStyle := &ServiceStyles{RicStyleTypes: []int32{CellConfigurationStyle}}
+
+	for _, name := range nodeStructureNames() {
+		structure := SupportedConfigurationStructure{RanConfigurationStructureName: name}
+		for _, attribute := range nodeConfigurationStructures[name] {
+			structure.ListOfSupportedAttributes = append(structure.ListOfSupportedAttributes, SupportedAttribute{
+				AttributeName:     attribute.name,
+				SupportedServices: SupportedServices{ReportService: reportNodeStyle},
+			})
+		}
+		definition.ListOfSupportedNodeLevelConfigurationStructures = append(definition.ListOfSupportedNodeLevelConfigurationStructures, structure)
+	}
+
+	for _, ncgi := range cells {
+		if _, err := sm.ServiceModel.CellStore.Get(ctx, ncgi); err != nil {
+			log.Warnf("NCGI (%v) is not in cell store", ncgi)
+			continue
+		}
+		cell := CellForRANFunctionDefinition{CellGlobalID: NewCellGlobalID(ncgi)}
+		for _, name := range cellStructureNames() {
+			structure := SupportedConfigurationStructure{RanConfigurationStructureName: name}
+			for _, attribute := range cellConfigurationStructures[name] {
+				services := SupportedServices{ReportService: reportCellStyle}
+				if attribute.set != nil {
+					services.ControlService = controlCellStyle
+				}
+				structure.ListOfSupportedAttributes = append(structure.ListOfSupportedAttributes, SupportedAttribute{
+					AttributeName:     attribute.name,
+					SupportedServices: services,
+				})
+			}
+			cell.ListOfSupportedCellLevelRANConfigurationStructures = append(cell.ListOfSupportedCellLevelRANConfigurationStructures, structure)
+		}
+		definition.ListOfCellsForRANFunctionDefinition = append(definition.ListOfCellsForRANFunctionDefinition, cell)
+	}
+	return definition
+}
@@ -41,6 +41,10 @@ const (
 	RRCConnAvg
 	// RRCConnMax  the max number of users in RRC connected mode during each granularity period.
 	RRCConnMax
+	// RRCConnEstabLatencyMean the mean time, in milliseconds, taken by the RRC connection establishments through the AMF
+	RRCConnEstabLatencyMean
+	// PagingReceivedNbrCnInitiated total number of pagings received from the AMF
+	PagingReceivedNbrCnInitiated
 )
 
 func (m MeasTypeName) String() string {
@@ -61,7 +65,9 @@ func (m MeasTypeName) String() string {
 		"RRC.ConnReEstabAtt.HOFail",
 		"RRC.ConnReEstabAtt.Other",
 		"RRC.Conn.Avg",
-		"RRC.Conn.Max"}[m]
+		"RRC.Conn.Max",
+		"RRC.ConnEstabLatency.Mean",
+		"PAG.ReceivedNbrCnInitiated"}[m]
 }
 
 // MeasType meas type
@@ -139,4 +145,12 @@ var measTypes = []MeasType{
 		measTypeName: RRCConnMax,
 		measTypeID:   17,
 	},
+	{
+		measTypeName: RRCConnEstabLatencyMean,
+		measTypeID:   18,
+	},
+	{
+		measTypeName: PagingReceivedNbrCnInitiated,
+		measTypeID:   19,
+	},
 }
@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
@@ -43,9 +44,11 @@ import (
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/amf"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
@@ -57,6 +60,10 @@ import (
 
 var _ servicemodel.Client = &Client{}
 
+var _ servicemodel.CellTargeter = &Client{}
+
+var _ servicemodel.CellDescriber = &Client{}
+
 var log = logging.GetLogger()
 
 const (
@@ -87,6 +94,9 @@ var (
 // Client kpm service model client
 type Client struct {
 	ServiceModel *registry.ServiceModel
+	// reports cancels the periodic reports of the subscriptions
+	reports map[subscriptions.ID]context.CancelFunc
+	mu      sync.Mutex
 }
 
 // E2ConnectionUpdate implements connection update procedure
@@ -96,7 +106,7 @@ func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appduconten
 
 // NewServiceModel creates a new service model
 func NewServiceModel(node model.Node, model *model.Model,
-	subStore *subscriptions.Subscriptions, nodeStore nodes.Store, ueStore ues.Store) (registry.ServiceModel, error) {
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store, ueStore ues.Store, metricStore metrics.Store) (registry.ServiceModel, error) {
 	kpmSm := registry.ServiceModel{
 		RanFunctionID: registry.Kpm2,
 		ModelName:     ranFunctionShortName,
@@ -108,18 +118,30 @@ func NewServiceModel(node model.Node, model *model.Model,
 		Subscriptions: subStore,
 		Nodes:         nodeStore,
 		UEs:           ueStore,
+		MetricStore:   metricStore,
 	}
 	kpmClient := &Client{
 		ServiceModel: &kpmSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
 	}
 
 	kpmSm.Client = kpmClient
 
-	plmnID := ransimtypes.NewUint24(uint32(kpmSm.Model.PlmnID))
+	description, err := kpmClient.RanFunctionDescription(context.Background(), node)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	kpmSm.Description = description
+	return kpmSm, nil
+}
+
+// RanFunctionDescription returns the RAN function definition of the service model, which lists the cells of the node
+// as measurement objects
+func (sm *Client) RanFunctionDescription(ctx context.Context, node model.Node) ([]byte, error) {
+	plmnID := ransimtypes.NewUint24(uint32(sm.ServiceModel.Model.PlmnID))
 
-	cells := node.Cells
 	cellMeasObjectItems := make([]*e2smkpmv2.CellMeasurementObjectItem, 0)
-	for _, cellNcgi := range cells {
+	for _, cellNcgi := range node.Cells {
 		nci := ransimtypes.GetNCI(cellNcgi)
 		ncibs := &asn1.BitString{
 			Value: utils.Uint64ToBitString(uint64(nci), 36),
@@ -130,7 +152,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 				cellglobalid.WithNRCellID(ncibs)).
 			Build()
 		if err != nil {
-			return registry.ServiceModel{}, err
+			return nil, err
 		}
 
 		cellMeasObjItem := measobjectitem.NewCellMeasObjectItem(
@@ -152,7 +174,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 		kpm2gNBID.WithGNBIDChoice(gNBID)).Build()
 	if err != nil {
 		log.Error(err)
-		return registry.ServiceModel{}, err
+		return nil, err
 	}
 
 	kpmNodeItem := nodeitem.NewNodeItem(
@@ -206,23 +228,22 @@ func NewServiceModel(node model.Node, model *model.Model,
 
 	if err != nil {
 		log.Error(err)
-		return registry.ServiceModel{}, err
+		return nil, err
 	}
 
 	protoBytes, err := proto.Marshal(ranFuncDescPdu)
 	if err != nil {
 		log.Error(err)
-		return registry.ServiceModel{}, err
+		return nil, err
 	}
 
 	var kpm2ServiceModel e2smkpmv2sm.Kpm2ServiceModel
 	ranFuncDescBytes, err := kpm2ServiceModel.RanFuncDescriptionProtoToASN1(protoBytes)
 	if err != nil {
 		log.Error(err)
-		return registry.ServiceModel{}, err
+		return nil, err
 	}
-	kpmSm.Description = ranFuncDescBytes
-	return kpmSm, nil
+	return ranFuncDescBytes, nil
 }
 
 func float_encoder(data float32) int64 {
@@ -307,6 +328,17 @@ func (sm *Client) collect(ctx context.Context,
 						measurments.WithIntegerValue(int64(sm.ServiceModel.UEs.LenPerCell(ctx, uint64(cellNCGI))))).
 						Build()
 					measRecord.Value = append(measRecord.Value, measRecordInteger)
+				case RRCConnEstabAttSum, RRCConnEstabSuccSum, RRCConnEstabLatencyMean, PagingReceivedNbrCnInitiated:
+					value, ok := sm.amfMetric(ctx, cellNCGI, measType.measTypeName)
+					if !ok {
+						measRecord.Value = append(measRecord.Value, measurments.NewMeasurementRecordItemNoValue())
+						continue
+					}
+					log.Debugf("%v for Cell %v: %v", measType.measTypeName, cellNCGI, value)
+					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
+						measurments.WithIntegerValue(value)).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordInteger)
 				default:
 					measRecordNoValue := measurments.NewMeasurementRecordItemNoValue()
 					measRecord.Value = append(measRecord.Value, measRecordNoValue)
@@ -324,6 +356,27 @@ func (sm *Client) collect(ctx context.Context,
 	return measDataItem, err
 }
 
+// amfMetrics are the metrics of the cells published by the AMF backing the measurements
+var amfMetrics = map[MeasTypeName]string{
+	RRCConnEstabAttSum:           amf.ConnEstabAttemptsMetric,
+	RRCConnEstabSuccSum:          amf.ConnEstabSuccessesMetric,
+	RRCConnEstabLatencyMean:      amf.ConnEstabLatencyMetric,
+	PagingReceivedNbrCnInitiated: amf.PagingReceivedMetric,
+}
+
+// amfMetric returns the value of the cell metric published by the AMF for the measurement, if any
+func (sm *Client) amfMetric(ctx context.Context, cellNCGI ransimtypes.NCGI, measTypeName MeasTypeName) (int64, bool) {
+	if sm.ServiceModel.MetricStore == nil {
+		return 0, false
+	}
+	value, ok := sm.ServiceModel.MetricStore.Get(ctx, uint64(cellNCGI), amfMetrics[measTypeName])
+	if !ok {
+		return 0, false
+	}
+	v, ok := value.(uint64)
+	return int64(v), ok
+}
+
 func (sm *Client) createIndicationMsgFormat1(ctx context.Context,
 	cellNCGI ransimtypes.NCGI, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition, interval int64) ([]byte, error) {
 	log.Debug("Create Indication message format 1 based on action defs for cell:", cellNCGI)
@@ -512,7 +565,9 @@ func (sm *Client) reportIndication(ctx context.Context, interval int64, subscrip
 			log.Debug("E2 channel context is done")
 			sub.Ticker.Stop()
 			return nil
-
+		case <-ctx.Done():
+			sub.Ticker.Stop()
+			return nil
 		}
 	}
 }
@@ -650,10 +705,11 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		}
 		return nil, subscriptionFailure, nil
 	}
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	reportCtx := sm.startReport(subID)
 	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-		err := sm.reportIndication(ctx, reportInterval, subscription, actionDefinitions)
+		defer sm.stopReport(subID)
+		err := sm.reportIndication(reportCtx, reportInterval, subscription, actionDefinitions)
 		if err != nil {
 			return
 		}
@@ -694,5 +750,25 @@ func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducon
 	if sub.Ticker != nil {
 		sub.Ticker.Stop()
 	}
+	sm.stopReport(subID)
 	return subDeleteResponse, nil, nil
 }
+
+// startReport returns the context of the report of the subscription; the context is done once the subscription
+// is deleted
+func (sm *Client) startReport(subID subscriptions.ID) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sm.mu.Lock()
+	sm.reports[subID] = cancel
+	sm.mu.Unlock()
+	return ctx
+}
+
+func (sm *Client) stopReport(subID subscriptions.ID) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cancel, ok := sm.reports[subID]; ok {
+		cancel()
+		delete(sm.reports, subID)
+	}
+}
@@ -5,11 +5,15 @@
 package kpm2
 
 import (
+	"strconv"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2smkpmv2sm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
 	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -42,6 +46,29 @@ func (sm *Client) getActionDefinition(actionList []*e2appducontents.RicactionToB
 	return actionDefinitions, nil
 }
 
+// Targets returns whether the subscription reports on the cell, which is the cell object of one of its actions
+func (sm *Client) Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	cellObjectID := strconv.FormatUint(uint64(ncgi), 16)
+	var kpm2ServiceModel e2smkpmv2sm.Kpm2ServiceModel
+	for _, action := range sub.Details.GetRicActionToBeSetupList().GetValue() {
+		actionDefinitionBytes := action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue()
+		actionDefinitionProtoBytes, err := kpm2ServiceModel.ActionDefinitionASN1toProto(actionDefinitionBytes)
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		actionDefinition := &e2smkpmv2.E2SmKpmActionDefinition{}
+		if err := proto.Unmarshal(actionDefinitionProtoBytes, actionDefinition); err != nil {
+			log.Warn(err)
+			continue
+		}
+		if actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1().GetCellObjId().GetValue() == cellObjectID {
+			return true
+		}
+	}
+	return false
+}
+
 // getReportPeriod extracts report period
 func (sm *Client) getReportPeriod(request *e2appducontents.RicsubscriptionRequest) (int64, error) {
 	var eventTriggerAsnBytes []byte
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llc
+
+// The OID and the description of the RAN function tell the JSON encoding private to the simulator apart from the
+// ASN.1 encoding of E2SM-LLC
+const (
+	modelFullName = "ORAN-E2SM-LLC"
+	version       = "v1"
+	modelOID      = "1.3.6.1.4.1.53148.1.1.2.105"
+	description   = "Lower Layers Control (ran-simulator JSON encoding)"
+)
+
+// RIC styles
+const (
+	// SchedulingReportStyle reports the scheduling outcomes of the slots of the cells aggregated over the reporting period
+	SchedulingReportStyle = 1
+	// SchedulingWeightsControlStyle controls the scheduling weights of UEs and slices
+	SchedulingWeightsControlStyle = 1
+)
+
+// minReportingPeriod is the shortest reporting period in milliseconds
+const minReportingPeriod = 10
+
+// Causes of a failed scheduling weight control
+const (
+	CauseNotAvailable  = "NOT_AVAILABLE"
+	CauseSemanticError = "SEMANTIC_ERROR"
+	CauseUnspecified   = "UNSPECIFIED"
+)
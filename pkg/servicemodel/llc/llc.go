@@ -0,0 +1,503 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+)
+
+var _ servicemodel.Client = &Client{}
+
+var _ servicemodel.CellTargeter = &Client{}
+
+var _ servicemodel.CellDescriber = &Client{}
+
+var log = logging.GetLogger()
+
+// Client llc service model client
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	reports      map[subscriptions.ID]context.CancelFunc
+	mu           sync.Mutex
+}
+
+// NewServiceModel creates a new service model
+func NewServiceModel(node model.Node, model *model.Model,
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store,
+	ueStore ues.Store, cellStore cells.Store, scheduler scheduler.Scheduler) (registry.ServiceModel, error) {
+	llcSm := registry.ServiceModel{
+		RanFunctionID: registry.Llc,
+		ModelName:     e2smtypes.ShortName(modelFullName),
+		Revision:      1,
+		OID:           modelOID,
+		Version:       version,
+		Node:          node,
+		Model:         model,
+		Subscriptions: subStore,
+		Nodes:         nodeStore,
+		UEs:           ueStore,
+		CellStore:     cellStore,
+		Scheduler:     scheduler,
+	}
+
+	llcClient := &Client{
+		ServiceModel: &llcSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
+	}
+	llcSm.Client = llcClient
+
+	ranFunctionDefinition, err := llcClient.RanFunctionDescription(context.Background(), node)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	llcSm.Description = ranFunctionDefinition
+	return llcSm, nil
+}
+
+// RanFunctionDescription returns the RAN function definition of the service model, which lists the cells of the node
+func (sm *Client) RanFunctionDescription(ctx context.Context, node model.Node) ([]byte, error) {
+	ranFunctionDefinition, err := json.Marshal(sm.createRanFunctionDefinition(ctx, node.Cells))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return ranFunctionDefinition, nil
+}
+
+// E2ConnectionUpdate implements connection update handler
+func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
+	return nil, nil, errors.NewNotSupported("E2 connection update is not supported")
+}
+
+// RICControl implements control handler for llc service model
+func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
+	log.Infof("Control Request is received for service model %v and e2 node ID: %d", sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	receivedTimestamp := time.Now().Format(time.RFC3339Nano)
+	reqID, err := controlutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	controlFailure := func(cause e2apies.CauseRicrequest, outcome []byte) (*e2appducontents.RiccontrolFailure, error) {
+		return controlutils.NewControl(
+			controlutils.WithRanFuncID(*ranFuncID),
+			controlutils.WithRequestID(*reqID),
+			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			}),
+			controlutils.WithRicControlOutcome(outcome)).BuildControlFailure()
+	}
+
+	if sm.ServiceModel.Scheduler == nil {
+		log.Warn("slots are not scheduled")
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE, nil)
+		return nil, failure, err
+	}
+	controlHeader, err := sm.getControlHeader(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+	controlMessage, err := sm.getControlMessage(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+	style := controlHeader.ControlHeaderFormat.ControlHeaderFormat1.RicStyleType
+	message := controlMessage.ControlMessageFormat.ControlMessageFormat1
+	if style != SchedulingWeightsControlStyle || message == nil {
+		log.Warnf("Control message format does not match RIC style %d", style)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID, nil)
+		return nil, failure, err
+	}
+
+	outcome, accepted := sm.controlWeights(ctx, message, receivedTimestamp)
+	outcomeBytes, err := json.Marshal(outcome)
+	if err != nil {
+		return nil, nil, err
+	}
+	if accepted == 0 {
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE, outcomeBytes)
+		return nil, failure, err
+	}
+	response, err = controlutils.NewControl(
+		controlutils.WithRanFuncID(*ranFuncID),
+		controlutils.WithRequestID(*reqID),
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithRicControlOutcome(outcomeBytes)).BuildControlAcknowledge()
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// controlWeights sets the scheduling weights of the UEs served by the node and of the slices of the cells of the node
+func (sm *Client) controlWeights(ctx context.Context, message *ControlMessageFormat1, receivedTimestamp string) (*ControlOutcome, int) {
+	outcome := &ControlOutcomeFormat1{
+		ReceivedTimestamp:          receivedTimestamp,
+		ListOfUEWeightsAccepted:    make([]UEWeight, 0),
+		ListOfUEWeightsFailed:      make([]UEWeightFailed, 0),
+		ListOfSliceWeightsAccepted: make([]SliceWeight, 0),
+		ListOfSliceWeightsFailed:   make([]SliceWeightFailed, 0),
+	}
+
+	for _, ueWeight := range message.ListOfUEWeights {
+		imsi := ransimtypes.IMSI(ueWeight.UEID.IMSI)
+		ue, err := sm.ServiceModel.UEs.Get(ctx, imsi)
+		if err == nil && (ue.Cell == nil || !sm.isNodeCell(ue.Cell.NCGI)) {
+			err = errors.NewNotFound("UE %d is not served by the node %v", imsi, sm.ServiceModel.Node.GnbID)
+		}
+		if err == nil {
+			err = sm.ServiceModel.Scheduler.SetUEWeight(ctx, imsi, ueWeight.Weight)
+		}
+		if err != nil {
+			log.Warnf("Failed to set the scheduling weight of UE %d: %v", imsi, err)
+			outcome.ListOfUEWeightsFailed = append(outcome.ListOfUEWeightsFailed, UEWeightFailed{UEWeight: ueWeight, Cause: cause(err)})
+			continue
+		}
+		outcome.ListOfUEWeightsAccepted = append(outcome.ListOfUEWeightsAccepted, ueWeight)
+	}
+
+	for _, sliceWeight := range message.ListOfSliceWeights {
+		ncgis := sm.ServiceModel.Node.Cells
+		if sliceWeight.CellGlobalID != nil {
+			ncgi, err := sm.getNodeCell(*sliceWeight.CellGlobalID)
+			if err != nil {
+				log.Warn(err)
+				outcome.ListOfSliceWeightsFailed = append(outcome.ListOfSliceWeightsFailed, SliceWeightFailed{SliceWeight: sliceWeight, Cause: cause(err)})
+				continue
+			}
+			ncgis = []ransimtypes.NCGI{ncgi}
+		}
+		slice := scheduler.Slice{Sst: sliceWeight.Slice.Sst, Sd: sliceWeight.Slice.Sd}
+		for _, ncgi := range ncgis {
+			cellGlobalID := NewCellGlobalID(ncgi)
+			cellWeight := SliceWeight{CellGlobalID: &cellGlobalID, Slice: sliceWeight.Slice, Weight: sliceWeight.Weight}
+			if err := sm.ServiceModel.Scheduler.SetSliceWeight(ctx, ncgi, slice, sliceWeight.Weight); err != nil {
+				log.Warnf("Failed to set the scheduling weight of slice %+v in the cell (%v): %v", slice, ncgi, err)
+				outcome.ListOfSliceWeightsFailed = append(outcome.ListOfSliceWeightsFailed, SliceWeightFailed{SliceWeight: cellWeight, Cause: cause(err)})
+				continue
+			}
+			outcome.ListOfSliceWeightsAccepted = append(outcome.ListOfSliceWeightsAccepted, cellWeight)
+		}
+	}
+	accepted := len(outcome.ListOfUEWeightsAccepted) + len(outcome.ListOfSliceWeightsAccepted)
+	return &ControlOutcome{ControlOutcomeFormat: ControlOutcomeFormat{ControlOutcomeFormat1: outcome}}, accepted
+}
+
+// cause returns the cause of a failed scheduling weight control
+func cause(err error) string {
+	switch {
+	case errors.IsNotFound(err):
+		return CauseNotAvailable
+	case errors.IsInvalid(err):
+		return CauseSemanticError
+	default:
+		return CauseUnspecified
+	}
+}
+
+// RICSubscription implements subscription handler for llc service model
+func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
+	log.Infof("RIC Subscription request received for e2 node %d and service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	var ricActionsAccepted []*e2aptypes.RicActionID
+	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
+	actionList := subutils.GetRicActionToBeSetupList(request)
+	reqID, err := subutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionFailure := func(cause e2apies.CauseRicrequest) (*e2appducontents.RicsubscriptionFailure, error) {
+		return subutils.NewSubscription(
+			subutils.WithRequestID(*reqID),
+			subutils.WithRanFuncID(*ranFuncID),
+			subutils.WithRicInstanceID(*ricInstanceID),
+			subutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			})).BuildSubscriptionFailure()
+	}
+
+	if sm.ServiceModel.Scheduler == nil {
+		log.Warn("slots are not scheduled")
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+	period, err := sm.getReportingPeriod(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	reports := make([]*report, 0)
+	for _, action := range actionList {
+		actionID := e2aptypes.RicActionID(action.GetValue().GetRicactionToBeSetupItem().GetRicActionId().GetValue())
+		actionType := action.GetValue().GetRicactionToBeSetupItem().GetRicActionType()
+		// llc service model supports report actions only; the scheduling weights are set by control requests
+		if actionType != e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		r, err := sm.getReport(action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue())
+		if err != nil {
+			log.Warn(err)
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		reports = append(reports, r)
+		ricActionsAccepted = append(ricActionsAccepted, &actionID)
+	}
+
+	// At least one required action must be accepted otherwise sends a subscription failure response
+	if len(ricActionsAccepted) == 0 {
+		log.Warn("no action is accepted")
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED)
+		return nil, failure, err
+	}
+
+	subscription := subutils.NewSubscription(
+		subutils.WithRequestID(*reqID),
+		subutils.WithRanFuncID(*ranFuncID),
+		subutils.WithRicInstanceID(*ricInstanceID),
+		subutils.WithActionsAccepted(ricActionsAccepted),
+		subutils.WithActionsNotAdmitted(ricActionsNotAdmitted))
+	response, err = subscription.BuildSubscriptionResponse()
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	reportCtx, cancel := context.WithCancel(context.Background())
+	sm.mu.Lock()
+	sm.reports[subID] = cancel
+	sm.mu.Unlock()
+	go func() {
+		defer sm.stopReport(subID)
+		err := sm.reportIndication(reportCtx, subscription, period, reports)
+		if err != nil {
+			log.Warn(err)
+		}
+	}()
+	return response, nil, nil
+}
+
+// Targets returns whether the subscription reports on the cell: its actions report on the listed cells or on all
+// the cells of the node
+func (sm *Client) Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	for _, action := range sub.Details.GetRicActionToBeSetupList().GetValue() {
+		actionDefinition := &ActionDefinition{}
+		if err := decode(action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue(), actionDefinition); err != nil {
+			log.Warn(err)
+			continue
+		}
+		format1 := actionDefinition.ActionDefinitionFormat.ActionDefinitionFormat1
+		if format1 == nil {
+			continue
+		}
+		if len(format1.ListOfCells) == 0 {
+			return true
+		}
+		for _, cellGlobalID := range format1.ListOfCells {
+			if target, err := cellGlobalID.NCGI(); err == nil && target == ncgi {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RICSubscriptionDelete implements subscription delete handler for llc service model
+func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	log.Infof("RIC subscription delete request is received for e2 node %d and  service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	reqID, err := subdeleteutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subdeleteutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subdeleteutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	_, err = sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
+		subdeleteutils.WithRequestID(*reqID),
+		subdeleteutils.WithRanFuncID(*ranFuncID),
+		subdeleteutils.WithRicInstanceID(*ricInstanceID))
+	response, err = subscriptionDelete.BuildSubscriptionDeleteResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stops the goroutine sending the indication messages
+	sm.stopReport(subID)
+	return response, nil, nil
+}
+
+func (sm *Client) stopReport(subID subscriptions.ID) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cancel, ok := sm.reports[subID]; ok {
+		cancel()
+		delete(sm.reports, subID)
+	}
+}
+
+// reportIndication reports the scheduling outcomes of the cells aggregated over each reporting period
+func (sm *Client) reportIndication(ctx context.Context, subscription *subutils.Subscription, period time.Duration, reports []*report) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	// the statistics taken at the start of the subscription are the baseline of the first window
+	start := time.Now()
+	for _, r := range reports {
+		sm.collect(ctx, r)
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			end := time.Now()
+			for _, r := range reports {
+				cellsReported := sm.collect(ctx, r)
+				if len(cellsReported) == 0 {
+					continue
+				}
+				if err := sm.sendRicIndication(ctx, sub, subscription, start, end, cellsReported); err != nil {
+					log.Warn(err)
+				}
+			}
+			start = end
+		case <-sub.E2Channel.Context().Done():
+			log.Debug("E2 channel context is done")
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// collect returns the scheduling outcomes of the cells of the report since the previous collection; a cell
+// scheduled for the first time is reported from the next collection on
+func (sm *Client) collect(ctx context.Context, r *report) []CellReported {
+	ncgis := r.cells
+	if len(ncgis) == 0 {
+		ncgis = sm.ServiceModel.Node.Cells
+	}
+	cellsReported := make([]CellReported, 0, len(ncgis))
+	for _, ncgi := range ncgis {
+		stats, err := sm.ServiceModel.Scheduler.Stats(ctx, ncgi)
+		if err != nil {
+			log.Debug(err)
+			delete(r.lastStats, ncgi)
+			continue
+		}
+		prev, ok := r.lastStats[ncgi]
+		r.lastStats[ncgi] = stats
+		if !ok {
+			continue
+		}
+		cellsReported = append(cellsReported, newCellReported(stats.Since(prev), r))
+	}
+	return cellsReported
+}
+
+func (sm *Client) sendRicIndication(ctx context.Context, sub *subscriptions.Subscription, subscription *subutils.Subscription,
+	start time.Time, end time.Time, cellsReported []CellReported) error {
+	indicationHeaderBytes, err := json.Marshal(&IndicationHeader{
+		IndicationHeaderFormat: IndicationHeaderFormat{
+			IndicationHeaderFormat1: &IndicationHeaderFormat1{
+				CollectionStartTime: start.Format(time.RFC3339Nano),
+				CollectionEndTime:   end.Format(time.RFC3339Nano),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	indicationMessageBytes, err := json.Marshal(&IndicationMessage{
+		IndicationMessageFormat: IndicationMessageFormat{
+			IndicationMessageFormat1: &IndicationMessageFormat1{ListOfCellsReported: cellsReported},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	indication := e2apIndicationUtils.NewIndication(
+		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+	ricIndication, err := indication.Build()
+	if err != nil {
+		return err
+	}
+	return sub.E2Channel.RICIndication(ctx, ricIndication)
+}
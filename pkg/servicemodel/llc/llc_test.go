@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNCGI = ransimtypes.NCGI(84325717761)
+
+func testClient() *Client {
+	node := model.Node{GnbID: 5153, Cells: []ransimtypes.NCGI{testNCGI}}
+	nodeStore := nodes.NewNodeRegistry(map[string]model.Node{"node1": node})
+	cellStore := cells.NewCellRegistry(map[string]model.Cell{"cell1": {NCGI: testNCGI}}, nodeStore)
+	return &Client{
+		ServiceModel: &registry.ServiceModel{
+			Node:      node,
+			CellStore: cellStore,
+			Scheduler: scheduler.NewScheduler(cellStore, nil),
+		},
+	}
+}
+
+func TestReportingPeriod(t *testing.T) {
+	period, err := reportingPeriod(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{ReportingPeriod: 100},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, period)
+
+	_, err = reportingPeriod(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{ReportingPeriod: 1},
+		},
+	})
+	assert.True(t, errors.IsInvalid(err))
+
+	_, err = reportingPeriod(&EventTriggerDefinition{})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestGetReport(t *testing.T) {
+	sm := testClient()
+	actionDefinition := &ActionDefinition{
+		RicStyleType: SchedulingReportStyle,
+		ActionDefinitionFormat: ActionDefinitionFormat{
+			ActionDefinitionFormat1: &ActionDefinitionFormat1{
+				ListOfCells: []CellGlobalID{NewCellGlobalID(testNCGI)},
+				ReportUEs:   true,
+			},
+		},
+	}
+	bytes, err := json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	r, err := sm.getReport(bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, []ransimtypes.NCGI{testNCGI}, r.cells)
+	assert.True(t, r.ues)
+	assert.False(t, r.slices)
+
+	actionDefinition.ActionDefinitionFormat.ActionDefinitionFormat1.ListOfCells = []CellGlobalID{NewCellGlobalID(testNCGI + 1)}
+	bytes, err = json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	_, err = sm.getReport(bytes)
+	assert.True(t, errors.IsNotFound(err))
+
+	_, err = sm.getReport([]byte(`{"ricStyleType":2}`))
+	assert.True(t, errors.IsNotSupported(err))
+}
+
+func TestCellReported(t *testing.T) {
+	stats := &scheduler.CellStats{
+		NCGI:              testNCGI,
+		Slots:             100,
+		PRBsPerSlot:       10,
+		TransmissionStats: scheduler.TransmissionStats{PRBs: 500, Bits: 20000, InitialTransmissions: 90, Retransmissions: 10, Nacks: 10},
+		UEs: map[ransimtypes.IMSI]*scheduler.UEStats{
+			2: {IMSI: 2, Slice: scheduler.DefaultSlice, Weight: 1},
+			1: {IMSI: 1, Slice: scheduler.DefaultSlice, Weight: 2},
+		},
+		Slices: map[scheduler.Slice]*scheduler.SliceStats{
+			scheduler.DefaultSlice: {Slice: scheduler.DefaultSlice, Weight: 1},
+		},
+		LastSlot: []scheduler.Allocation{{IMSI: 1, PRBs: 5}},
+	}
+	cell := newCellReported(stats, &report{ues: true})
+	assert.Equal(t, NewCellGlobalID(testNCGI), cell.CellGlobalID)
+	assert.Equal(t, 0.5, cell.PRBUtilization)
+	assert.Equal(t, 200.0, cell.ThroughputKbps)
+	assert.Equal(t, 0.1, cell.BLER)
+	assert.Len(t, cell.ListOfUEs, 2)
+	assert.Equal(t, uint64(1), cell.ListOfUEs[0].UEID.IMSI)
+	assert.Equal(t, 2.0, cell.ListOfUEs[0].Weight)
+	assert.Empty(t, cell.ListOfSlices)
+	assert.Empty(t, cell.LastSlotAllocations)
+}
+
+func TestControlSliceWeights(t *testing.T) {
+	sm := testClient()
+	other := NewCellGlobalID(testNCGI + 1)
+	outcome, accepted := sm.controlWeights(context.Background(), &ControlMessageFormat1{
+		ListOfSliceWeights: []SliceWeight{
+			{Slice: SliceID{Sst: 1, Sd: 1}, Weight: 4},
+			{CellGlobalID: &other, Slice: SliceID{Sst: 1, Sd: 1}, Weight: 4},
+			{Slice: SliceID{Sst: 1, Sd: 2}, Weight: scheduler.MaxWeight + 1},
+		},
+	}, "")
+	assert.Equal(t, 1, accepted)
+	format1 := outcome.ControlOutcomeFormat.ControlOutcomeFormat1
+	assert.Equal(t, NewCellGlobalID(testNCGI), *format1.ListOfSliceWeightsAccepted[0].CellGlobalID)
+	assert.Len(t, format1.ListOfSliceWeightsFailed, 2)
+	assert.Equal(t, CauseNotAvailable, format1.ListOfSliceWeightsFailed[0].Cause)
+	assert.Equal(t, CauseSemanticError, format1.ListOfSliceWeightsFailed[1].Cause)
+}
+
+func TestTargets(t *testing.T) {
+	sm := testClient()
+	schedulingReport := func(cells ...CellGlobalID) *ActionDefinition {
+		return &ActionDefinition{
+			RicStyleType: SchedulingReportStyle,
+			ActionDefinitionFormat: ActionDefinitionFormat{
+				ActionDefinitionFormat1: &ActionDefinitionFormat1{ListOfCells: cells},
+			},
+		}
+	}
+
+	// the subscription reports on the listed cells or on all the cells of the node
+	assert.True(t, sm.Targets(testSubscription(t, schedulingReport(NewCellGlobalID(testNCGI))), testNCGI))
+	assert.True(t, sm.Targets(testSubscription(t, schedulingReport()), testNCGI))
+	assert.False(t, sm.Targets(testSubscription(t, schedulingReport(NewCellGlobalID(testNCGI))), testNCGI+1))
+}
+
+// testSubscription returns a subscription with a REPORT action of the given definition
+func testSubscription(t *testing.T, actionDefinition interface{}) *subscriptions.Subscription {
+	bytes, err := json.Marshal(actionDefinition)
+	assert.NoError(t, err)
+	return &subscriptions.Subscription{
+		Details: &e2appducontents.RicsubscriptionDetails{
+			RicActionToBeSetupList: &e2appducontents.RicactionsToBeSetupList{
+				Value: []*e2appducontents.RicactionToBeSetupItemIes{
+					{
+						Value: &e2appducontents.RicactionToBeSetupItemIe{
+							RicactionToBeSetupItemIe: &e2appducontents.RicactionToBeSetupItemIe_RicactionToBeSetupItem{
+								RicactionToBeSetupItem: &e2appducontents.RicactionToBeSetupItem{
+									RicActionId:         &e2apies.RicactionId{Value: 1},
+									RicActionType:       e2apies.RicactionType_RICACTION_TYPE_REPORT,
+									RicActionDefinition: &e2apcommondatatypes.RicactionDefinition{Value: bytes},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+)
+
+// E2SM-LLC is specified in ASN.1, yet the simulator encodes its messages in JSON: this encoding is private to the
+// simulator, whose RAN function is advertised with its own OID and cannot be decoded by an ASN.1 E2SM-LLC codec.
+// The types below cover the scheduling reports and the scheduling weight controls of the simulator.
+
+// RanFunctionDefinition RAN function definition
+type RanFunctionDefinition struct {
+	RanFunctionName          servicemodel.RanFunctionName `json:"ranFunctionName"`
+	RicEventTriggerStyleList []RicStyle                   `json:"ricEventTriggerStyleList"`
+	RicReportStyleList       []RicStyle                   `json:"ricReportStyleList"`
+	RicControlStyleList      []RicStyle                   `json:"ricControlStyleList"`
+	ListOfCells              []CellGlobalID               `json:"listOfCells"`
+	// MinReportingPeriod is the shortest reporting period in milliseconds
+	MinReportingPeriod int64 `json:"minReportingPeriod"`
+	// MaxSchedulingWeight is the highest scheduling weight of a UE or a slice
+	MaxSchedulingWeight float64 `json:"maxSchedulingWeight"`
+}
+
+// RicStyle RIC style
+type RicStyle struct {
+	RicStyleType  int32  `json:"ricStyleType"`
+	RicStyleName  string `json:"ricStyleName"`
+	RicFormatType int32  `json:"ricFormatType"`
+}
+
+// CellGlobalID NR cell global ID
+type CellGlobalID struct {
+	NRCGI NRCGI `json:"nRCGI"`
+}
+
+// NRCGI NR CGI; the PLMN identity and NR cell identity are hex strings
+type NRCGI struct {
+	PlmnIdentity   string `json:"pLMNIdentity"`
+	NRCellIdentity string `json:"nRCellIdentity"`
+}
+
+// UEID identifies a UE by its IMSI; the C-RNTI is informative
+type UEID struct {
+	IMSI  uint64 `json:"imsi"`
+	CRNTI uint32 `json:"cRNTI,omitempty"`
+}
+
+// SliceID S-NSSAI of a slice
+type SliceID struct {
+	Sst uint32 `json:"sST"`
+	Sd  uint32 `json:"sD"`
+}
+
+// EventTriggerDefinition event trigger definition
+type EventTriggerDefinition struct {
+	EventTriggerDefinitionFormat EventTriggerDefinitionFormat `json:"eventTriggerDefinitionFormat"`
+}
+
+// EventTriggerDefinitionFormat event trigger definition format
+type EventTriggerDefinitionFormat struct {
+	EventTriggerFormat1 *EventTriggerFormat1 `json:"eventTriggerFormat1,omitempty"`
+}
+
+// EventTriggerFormat1 triggers a report at the end of each reporting period
+type EventTriggerFormat1 struct {
+	// ReportingPeriod is the window the scheduling outcomes are aggregated over, in milliseconds
+	ReportingPeriod int64 `json:"reportingPeriod"`
+}
+
+// ActionDefinition action definition
+type ActionDefinition struct {
+	RicStyleType           int32                  `json:"ricStyleType"`
+	ActionDefinitionFormat ActionDefinitionFormat `json:"actionDefinitionFormat"`
+}
+
+// ActionDefinitionFormat action definition format
+type ActionDefinitionFormat struct {
+	ActionDefinitionFormat1 *ActionDefinitionFormat1 `json:"actionDefinitionFormat1,omitempty"`
+}
+
+// ActionDefinitionFormat1 selects the cells and the details of the scheduling report
+type ActionDefinitionFormat1 struct {
+	// ListOfCells are the cells to be reported; all cells of the node if empty
+	ListOfCells    []CellGlobalID `json:"listOfCells,omitempty"`
+	ReportUEs      bool           `json:"reportUEs,omitempty"`
+	ReportSlices   bool           `json:"reportSlices,omitempty"`
+	ReportLastSlot bool           `json:"reportLastSlot,omitempty"`
+}
+
+// IndicationHeader indication header
+type IndicationHeader struct {
+	IndicationHeaderFormat IndicationHeaderFormat `json:"indicationHeaderFormat"`
+}
+
+// IndicationHeaderFormat indication header format
+type IndicationHeaderFormat struct {
+	IndicationHeaderFormat1 *IndicationHeaderFormat1 `json:"indicationHeaderFormat1,omitempty"`
+}
+
+// IndicationHeaderFormat1 is the collection window of the report
+type IndicationHeaderFormat1 struct {
+	CollectionStartTime string `json:"collectionStartTime"`
+	CollectionEndTime   string `json:"collectionEndTime"`
+}
+
+// IndicationMessage indication message
+type IndicationMessage struct {
+	IndicationMessageFormat IndicationMessageFormat `json:"indicationMessageFormat"`
+}
+
+// IndicationMessageFormat indication message format
+type IndicationMessageFormat struct {
+	IndicationMessageFormat1 *IndicationMessageFormat1 `json:"indicationMessageFormat1,omitempty"`
+}
+
+// IndicationMessageFormat1 scheduling outcomes of the cells
+type IndicationMessageFormat1 struct {
+	ListOfCellsReported []CellReported `json:"listOfCellsReported"`
+}
+
+// CellReported scheduling outcomes of a cell over the collection window
+type CellReported struct {
+	CellGlobalID   CellGlobalID `json:"cellGlobalId"`
+	NumberOfSlots  uint64       `json:"numberOfSlots"`
+	PRBsPerSlot    int          `json:"prbsPerSlot"`
+	PRBUtilization float64      `json:"prbUtilization"`
+	SchedulingOutcome
+	ListOfUEs           []UEReported     `json:"listOfUEs,omitempty"`
+	ListOfSlices        []SliceReported  `json:"listOfSlices,omitempty"`
+	LastSlotAllocations []SlotAllocation `json:"lastSlotAllocations,omitempty"`
+}
+
+// SchedulingOutcome aggregated scheduling outcome of a cell, a UE or a slice
+type SchedulingOutcome struct {
+	ScheduledSlots       uint64  `json:"scheduledSlots"`
+	PRBs                 uint64  `json:"prbs"`
+	Bits                 uint64  `json:"bits"`
+	ThroughputKbps       float64 `json:"throughputKbps"`
+	InitialTransmissions uint64  `json:"initialTransmissions"`
+	Retransmissions      uint64  `json:"retransmissions"`
+	Nacks                uint64  `json:"nacks"`
+	HarqFailures         uint64  `json:"harqFailures"`
+	BLER                 float64 `json:"bler"`
+	AverageMCS           float64 `json:"averageMcs"`
+}
+
+// UEReported scheduling outcome of a UE
+type UEReported struct {
+	UEID   UEID    `json:"ueId"`
+	Slice  SliceID `json:"slice"`
+	Weight float64 `json:"weight"`
+	CQI    int     `json:"cqi"`
+	SchedulingOutcome
+}
+
+// SliceReported scheduling outcome of a slice
+type SliceReported struct {
+	Slice  SliceID `json:"slice"`
+	Weight float64 `json:"weight"`
+	SchedulingOutcome
+}
+
+// SlotAllocation allocation of contiguous PRBs of the last slot to a UE
+type SlotAllocation struct {
+	UEID           UEID `json:"ueId"`
+	FirstPRB       int  `json:"firstPrb"`
+	NumberOfPRBs   int  `json:"numberOfPrbs"`
+	MCS            int  `json:"mcs"`
+	Retransmission bool `json:"retransmission"`
+	Acknowledged   bool `json:"acknowledged"`
+}
+
+// ControlHeader control header
+type ControlHeader struct {
+	ControlHeaderFormat ControlHeaderFormat `json:"controlHeaderFormat"`
+}
+
+// ControlHeaderFormat control header format
+type ControlHeaderFormat struct {
+	ControlHeaderFormat1 *ControlHeaderFormat1 `json:"controlHeaderFormat1,omitempty"`
+}
+
+// ControlHeaderFormat1 control header format 1
+type ControlHeaderFormat1 struct {
+	RicStyleType int32 `json:"ricStyleType"`
+}
+
+// ControlMessage control message
+type ControlMessage struct {
+	ControlMessageFormat ControlMessageFormat `json:"controlMessageFormat"`
+}
+
+// ControlMessageFormat control message format
+type ControlMessageFormat struct {
+	ControlMessageFormat1 *ControlMessageFormat1 `json:"controlMessageFormat1,omitempty"`
+}
+
+// ControlMessageFormat1 scheduling weights to be set
+type ControlMessageFormat1 struct {
+	ListOfUEWeights    []UEWeight    `json:"listOfUEWeights,omitempty"`
+	ListOfSliceWeights []SliceWeight `json:"listOfSliceWeights,omitempty"`
+}
+
+// UEWeight scheduling weight of a UE; the weight applies in all cells the UE is served by
+type UEWeight struct {
+	UEID   UEID    `json:"ueId"`
+	Weight float64 `json:"weight"`
+}
+
+// SliceWeight scheduling weight of a slice in a cell
+type SliceWeight struct {
+	// CellGlobalID is the cell the weight applies to; all cells of the node if not set
+	CellGlobalID *CellGlobalID `json:"cellGlobalId,omitempty"`
+	Slice        SliceID       `json:"slice"`
+	Weight       float64       `json:"weight"`
+}
+
+// ControlOutcome control outcome
+type ControlOutcome struct {
+	ControlOutcomeFormat ControlOutcomeFormat `json:"controlOutcomeFormat"`
+}
+
+// ControlOutcomeFormat control outcome format
+type ControlOutcomeFormat struct {
+	ControlOutcomeFormat1 *ControlOutcomeFormat1 `json:"controlOutcomeFormat1,omitempty"`
+}
+
+// ControlOutcomeFormat1 scheduling weights applied and rejected
+type ControlOutcomeFormat1 struct {
+	ReceivedTimestamp          string              `json:"receivedTimestamp"`
+	ListOfUEWeightsAccepted    []UEWeight          `json:"listOfUEWeightsAccepted"`
+	ListOfUEWeightsFailed      []UEWeightFailed    `json:"listOfUEWeightsFailed"`
+	ListOfSliceWeightsAccepted []SliceWeight       `json:"listOfSliceWeightsAccepted"`
+	ListOfSliceWeightsFailed   []SliceWeightFailed `json:"listOfSliceWeightsFailed"`
+}
+
+// UEWeightFailed scheduling weight of a UE that is not applied
+type UEWeightFailed struct {
+	UEWeight
+	Cause string `json:"cause"`
+}
+
+// SliceWeightFailed scheduling weight of a slice that is not applied
+type SliceWeightFailed struct {
+	SliceWeight
+	Cause string `json:"cause"`
+}
+
+// NewCellGlobalID returns the cell global ID of the specified NCGI
+func NewCellGlobalID(ncgi ransimtypes.NCGI) CellGlobalID {
+	return CellGlobalID{
+		NRCGI: NRCGI{
+			PlmnIdentity:   fmt.Sprintf("%06x", ransimtypes.GetPlmnID(uint64(ncgi))),
+			NRCellIdentity: fmt.Sprintf("%09x", ransimtypes.GetNCI(ncgi)),
+		},
+	}
+}
+
+// NCGI returns the NCGI of the cell global ID
+func (c CellGlobalID) NCGI() (ransimtypes.NCGI, error) {
+	plmnID, err := strconv.ParseUint(c.NRCGI.PlmnIdentity, 16, 24)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid PLMN identity %s", c.NRCGI.PlmnIdentity)
+	}
+	nci, err := strconv.ParseUint(c.NRCGI.NRCellIdentity, 16, 36)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid NR cell identity %s", c.NRCGI.NRCellIdentity)
+	}
+	return ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci)), nil
+}
+
+func decode(bytes []byte, message interface{}) error {
+	err := json.Unmarshal(bytes, message)
+	if err != nil {
+		return errors.NewInvalid("invalid %s message: %v", modelFullName, err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+)
+
+// report is the scheduling report requested by a REPORT action
+type report struct {
+	// cells are the cells to be reported; all cells of the node if empty
+	cells     []ransimtypes.NCGI
+	ues       bool
+	slices    bool
+	lastSlot  bool
+	lastStats map[ransimtypes.NCGI]*scheduler.CellStats
+}
+
+func (sm *Client) getReportingPeriod(request *e2appducontents.RicsubscriptionRequest) (time.Duration, error) {
+	var eventTriggerBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
+			eventTriggerBytes = v.GetValue().GetRicsubscriptionDetails().GetRicEventTriggerDefinition().GetValue()
+			break
+		}
+	}
+	eventTrigger := &EventTriggerDefinition{}
+	if err := decode(eventTriggerBytes, eventTrigger); err != nil {
+		return 0, err
+	}
+	return reportingPeriod(eventTrigger)
+}
+
+func reportingPeriod(eventTrigger *EventTriggerDefinition) (time.Duration, error) {
+	format1 := eventTrigger.EventTriggerDefinitionFormat.EventTriggerFormat1
+	if format1 == nil {
+		return 0, errors.NewInvalid("event trigger format is missing")
+	}
+	if format1.ReportingPeriod < minReportingPeriod {
+		return 0, errors.NewInvalid("reporting period %d ms is shorter than %d ms", format1.ReportingPeriod, minReportingPeriod)
+	}
+	return time.Duration(format1.ReportingPeriod) * time.Millisecond, nil
+}
+
+func (sm *Client) getReport(actionDefinitionBytes []byte) (*report, error) {
+	actionDefinition := &ActionDefinition{}
+	if err := decode(actionDefinitionBytes, actionDefinition); err != nil {
+		return nil, err
+	}
+	if actionDefinition.RicStyleType != SchedulingReportStyle {
+		return nil, errors.NewNotSupported("RIC style %d is not supported", actionDefinition.RicStyleType)
+	}
+	format1 := actionDefinition.ActionDefinitionFormat.ActionDefinitionFormat1
+	if format1 == nil {
+		return nil, errors.NewInvalid("action definition format is missing")
+	}
+	r := &report{
+		ues:       format1.ReportUEs,
+		slices:    format1.ReportSlices,
+		lastSlot:  format1.ReportLastSlot,
+		lastStats: make(map[ransimtypes.NCGI]*scheduler.CellStats),
+	}
+	for _, cellGlobalID := range format1.ListOfCells {
+		ncgi, err := sm.getNodeCell(cellGlobalID)
+		if err != nil {
+			return nil, err
+		}
+		r.cells = append(r.cells, ncgi)
+	}
+	return r, nil
+}
+
+func (sm *Client) getControlHeader(request *e2appducontents.RiccontrolRequest) (*ControlHeader, error) {
+	var controlHeaderBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolHeader) {
+			controlHeaderBytes = v.GetValue().GetRiccontrolHeader().GetValue()
+			break
+		}
+	}
+	controlHeader := &ControlHeader{}
+	if err := decode(controlHeaderBytes, controlHeader); err != nil {
+		return nil, err
+	}
+	if controlHeader.ControlHeaderFormat.ControlHeaderFormat1 == nil {
+		return nil, errors.NewInvalid("control header format is missing")
+	}
+	return controlHeader, nil
+}
+
+func (sm *Client) getControlMessage(request *e2appducontents.RiccontrolRequest) (*ControlMessage, error) {
+	var controlMessageBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolMessage) {
+			controlMessageBytes = v.GetValue().GetRiccontrolMessage().GetValue()
+			break
+		}
+	}
+	controlMessage := &ControlMessage{}
+	if err := decode(controlMessageBytes, controlMessage); err != nil {
+		return nil, err
+	}
+	return controlMessage, nil
+}
+
+// getNodeCell returns the NCGI of the cell global ID if the cell belongs to the node
+func (sm *Client) getNodeCell(cellGlobalID CellGlobalID) (ransimtypes.NCGI, error) {
+	ncgi, err := cellGlobalID.NCGI()
+	if err != nil {
+		return 0, err
+	}
+	if !sm.isNodeCell(ncgi) {
+		return 0, errors.NewNotFound("cell %v does not belong to the node %v", ncgi, sm.ServiceModel.Node.GnbID)
+	}
+	return ncgi, nil
+}
+
+func (sm *Client) isNodeCell(ncgi ransimtypes.NCGI) bool {
+	for _, cell := range sm.ServiceModel.Node.Cells {
+		if cell == ncgi {
+			return true
+		}
+	}
+	return false
+}
+
+func newSchedulingOutcome(stats scheduler.TransmissionStats, slots uint64) SchedulingOutcome {
+	outcome := SchedulingOutcome{
+		ScheduledSlots:       stats.ScheduledSlots,
+		PRBs:                 stats.PRBs,
+		Bits:                 stats.Bits,
+		InitialTransmissions: stats.InitialTransmissions,
+		Retransmissions:      stats.Retransmissions,
+		Nacks:                stats.Nacks,
+		HarqFailures:         stats.HarqFailures,
+		BLER:                 stats.BLER(),
+		AverageMCS:           stats.AverageMCS(),
+	}
+	// a slot lasts one millisecond, hence the bits per slot are kbps
+	if slots > 0 {
+		outcome.ThroughputKbps = float64(stats.Bits) / float64(slots)
+	}
+	return outcome
+}
+
+func newSliceID(slice scheduler.Slice) SliceID {
+	return SliceID{Sst: slice.Sst, Sd: slice.Sd}
+}
+
+// newCellReported returns the report of the scheduling statistics of a cell over a collection window
+func newCellReported(stats *scheduler.CellStats, r *report) CellReported {
+	cell := CellReported{
+		CellGlobalID:      NewCellGlobalID(stats.NCGI),
+		NumberOfSlots:     stats.Slots,
+		PRBsPerSlot:       stats.PRBsPerSlot,
+		PRBUtilization:    stats.PRBUtilization(),
+		SchedulingOutcome: newSchedulingOutcome(stats.TransmissionStats, stats.Slots),
+	}
+	if r.ues {
+		cell.ListOfUEs = make([]UEReported, 0, len(stats.UEs))
+		for _, ue := range stats.UEs {
+			cell.ListOfUEs = append(cell.ListOfUEs, UEReported{
+				UEID:              UEID{IMSI: uint64(ue.IMSI), CRNTI: uint32(ue.CRNTI)},
+				Slice:             newSliceID(ue.Slice),
+				Weight:            ue.Weight,
+				CQI:               ue.CQI,
+				SchedulingOutcome: newSchedulingOutcome(ue.TransmissionStats, stats.Slots),
+			})
+		}
+		sort.Slice(cell.ListOfUEs, func(i, j int) bool { return cell.ListOfUEs[i].UEID.IMSI < cell.ListOfUEs[j].UEID.IMSI })
+	}
+	if r.slices {
+		cell.ListOfSlices = make([]SliceReported, 0, len(stats.Slices))
+		for _, slice := range stats.Slices {
+			cell.ListOfSlices = append(cell.ListOfSlices, SliceReported{
+				Slice:             newSliceID(slice.Slice),
+				Weight:            slice.Weight,
+				SchedulingOutcome: newSchedulingOutcome(slice.TransmissionStats, stats.Slots),
+			})
+		}
+		sort.Slice(cell.ListOfSlices, func(i, j int) bool {
+			a, b := cell.ListOfSlices[i].Slice, cell.ListOfSlices[j].Slice
+			return a.Sst < b.Sst || (a.Sst == b.Sst && a.Sd < b.Sd)
+		})
+	}
+	if r.lastSlot {
+		cell.LastSlotAllocations = make([]SlotAllocation, 0, len(stats.LastSlot))
+		for _, allocation := range stats.LastSlot {
+			slotAllocation := SlotAllocation{
+				UEID:           UEID{IMSI: uint64(allocation.IMSI)},
+				FirstPRB:       allocation.FirstPRB,
+				NumberOfPRBs:   allocation.PRBs,
+				MCS:            allocation.MCS,
+				Retransmission: allocation.Retransmission,
+				Acknowledged:   allocation.Acknowledged,
+			}
+			if ue, ok := stats.UEs[allocation.IMSI]; ok {
+				slotAllocation.UEID.CRNTI = uint32(ue.CRNTI)
+			}
+			cell.LastSlotAllocations = append(cell.LastSlotAllocations, slotAllocation)
+		}
+	}
+	return cell
+}
+
+func (sm *Client) createRanFunctionDefinition(ctx context.Context, cells []ransimtypes.NCGI) *RanFunctionDefinition {
+	definition := &RanFunctionDefinition{
+		RanFunctionName: servicemodel.RanFunctionName{
+			RanFunctionShortName:   modelFullName,
+			RanFunctionE2SmOid:     modelOID,
+			RanFunctionDescription: description,
+		},
+		RicEventTriggerStyleList: []RicStyle{
+			{RicStyleType: SchedulingReportStyle, RicStyleName: "Periodic report", RicFormatType: 1},
+		},
+		RicReportStyleList: []RicStyle{
+			{RicStyleType: SchedulingReportStyle, RicStyleName: "Scheduling outcomes", RicFormatType: 1},
+		},
+		RicControlStyleList: []RicStyle{
+			{RicStyleType: SchedulingWeightsControlStyle, RicStyleName: "Scheduling weights", RicFormatType: 1},
+		},
+		MinReportingPeriod:  minReportingPeriod,
+		MaxSchedulingWeight: scheduler.MaxWeight,
+	}
+	for _, ncgi := range cells {
+		if _, err := sm.ServiceModel.CellStore.Get(ctx, ncgi); err != nil {
+			log.Warnf("NCGI (%v) is not in cell store", ncgi)
+			continue
+		}
+		definition.ListOfCells = append(definition.ListOfCells, NewCellGlobalID(ncgi))
+	}
+	return definition
+}
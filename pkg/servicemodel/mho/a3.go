@@ -6,24 +6,37 @@ package mho
 
 import (
 	"context"
+
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	"github.com/onosproject/rrm-son-lib/pkg/handover"
 	"github.com/onosproject/rrm-son-lib/pkg/model/id"
 )
 
+// processEventA3MeasReport sends an indication for each A3 handover decision of a UE served by the node
+// until the subscription is deleted or its E2 channel is closed
 func (m *Mho) processEventA3MeasReport(ctx context.Context, subscription *subutils.Subscription) {
 	log.Info("Start processing event a3 measurement report")
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	defer m.stopReport(subID)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		log.Error(err)
 		return
 	}
+	ch := make(chan handover.A3HandoverDecision)
+	if err := m.mobilityDriver.GetHoCtrl().Watch(ctx, ch, m.ServiceModel.Node.Cells...); err != nil {
+		log.Error(err)
+		return
+	}
 	for {
 		select {
-		case report := <-m.ServiceModel.A3Chan:
+		case report, ok := <-ch:
+			if !ok {
+				return
+			}
 			log.Debugf("received event a3 measurement report: %v", report)
 			log.Debugf("Send upon-rcv-meas-report indication for cell ecgi:%d, IMSI:%s",
 				report.UE.GetSCell().GetID().GetID().(id.ECGI), report.UE.GetID().String())
@@ -40,7 +53,6 @@ func (m *Mho) processEventA3MeasReport(ctx context.Context, subscription *subuti
 				continue
 			}
 		case <-sub.E2Channel.Context().Done():
-			sub.Ticker.Stop()
 			return
 		}
 	}
@@ -204,7 +204,7 @@ func (m *Mho) createIndicationMsgFormat1(ue *model.UE) ([]byte, error) {
 		})
 	}
 
-	ueID := int64(ue.IMSI)
+	ueID := int64(ue.AmfUeNgapID)
 
 	log.Debugf("MHO measurement report for ueID %s: %v", ueID, measReport)
 
@@ -231,11 +231,11 @@ func (m *Mho) createIndicationMsgFormat2(ue *model.UE) ([]byte, error) {
 	indicationMessage := indMsgFmt2.NewIndicationMessage(
 		indMsgFmt2.WithUeID(ueID),
 		indMsgFmt2.WithRrcStatus(ue.RrcState),
-		indMsgFmt2.WithGuami(uint64(m.ServiceModel.Model.PlmnID), m.ServiceModel.Model.Guami.AmfRegionID,
-			m.ServiceModel.Model.Guami.AmfSetID, m.ServiceModel.Model.Guami.AmfPointer))
+		indMsgFmt2.WithGuami(uint64(m.ServiceModel.Model.PlmnID), ue.Guami.AmfRegionID,
+			ue.Guami.AmfSetID, ue.Guami.AmfPointer))
 
 	log.Debugf("MHO RRC state indication message for ueID amf ue ngap id - %v, plmnid - %v, amf region id - %v,"+
-		"amf set id - %v, amf pointer - %v: %v", ueID, uint64(m.ServiceModel.Model.PlmnID), m.ServiceModel.Model.Guami.AmfRegionID, m.ServiceModel.Model.Guami.AmfSetID, m.ServiceModel.Model.Guami.AmfPointer,
+		"amf set id - %v, amf pointer - %v: %v", ueID, uint64(m.ServiceModel.Model.PlmnID), ue.Guami.AmfRegionID, ue.Guami.AmfSetID, ue.Guami.AmfPointer,
 		indicationMessage)
 
 	indicationMessageBytes, err := indicationMessage.ToAsn1Bytes()
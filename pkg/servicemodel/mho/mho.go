@@ -6,11 +6,11 @@ package mho
 
 import (
 	"context"
+	"sync"
 
 	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
 	e2smmhosm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/servicemodel"
 	"github.com/onosproject/ran-simulator/pkg/utils"
-	"github.com/onosproject/rrm-son-lib/pkg/handover"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
@@ -41,15 +41,18 @@ var log = logging.GetLogger("sm", "mho")
 // Mho represents the MHO service model
 type Mho struct {
 	ServiceModel   *registry.ServiceModel
-	rrcUpdateChan  chan model.UE
 	mobilityDriver mobility.Driver
+	// reports cancels the periodic reports and the processing of the A3 measurement reports and RRC state changes
+	// of the subscriptions
+	reports map[subscriptions.ID]context.CancelFunc
+	mu      sync.Mutex
 }
 
 // NewServiceModel creates a new service model
 func NewServiceModel(node model.Node, model *model.Model,
 	subStore *subscriptions.Subscriptions, nodeStore nodes.Store,
 	ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store,
-	a3Chan chan handover.A3HandoverDecision, mobilityDriver mobility.Driver) (registry.ServiceModel, error) {
+	mobilityDriver mobility.Driver) (registry.ServiceModel, error) {
 	modelName := e2smtypes.ShortName(modelFullName)
 	mhoSm := registry.ServiceModel{
 		RanFunctionID: registry.Mho,
@@ -64,11 +67,11 @@ func NewServiceModel(node model.Node, model *model.Model,
 		UEs:           ueStore,
 		CellStore:     cellStore,
 		MetricStore:   metricStore,
-		A3Chan:        a3Chan,
 	}
 
 	mho := &Mho{
 		ServiceModel: &mhoSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
 	}
 
 	mhoSm.Client = mho
@@ -256,35 +259,28 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 	switch eventTriggerType {
 	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_PERIODIC:
 		log.Infof("Received periodic report subscription request")
+		reportCtx := m.startReport(subscription)
 		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
 			interval, err := m.getReportPeriod(request)
 			if err != nil {
 				log.Error(err)
 				return
 			}
-			m.reportPeriodicIndication(ctx, interval, subscription)
+			m.reportPeriodicIndication(reportCtx, interval, subscription)
 		}()
 	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT:
 		log.Infof("Received MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT subscription request")
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
 		if m.mobilityDriver.GetHoLogic() == "local" {
 			m.mobilityDriver.SetHoLogic("mho")
 		}
 
-		go m.processEventA3MeasReport(ctx, subscription)
+		reportCtx := m.startReport(subscription)
+		go m.processEventA3MeasReport(reportCtx, subscription)
 
 	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS:
 		log.Infof("Received MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS subscription request")
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		m.rrcUpdateChan = make(chan model.UE)
-		go m.processRrcUpdate(ctx, subscription)
-		m.mobilityDriver.AddRrcChan(m.rrcUpdateChan)
+		reportCtx := m.startReport(subscription)
+		go m.processRrcUpdate(reportCtx, subscription)
 
 	default:
 		log.Errorf("MHO subscription failed, invalid event trigger type: %v", eventTriggerType)
@@ -339,7 +335,13 @@ func (m *Mho) RICSubscriptionDelete(ctx context.Context, request *e2appducontent
 	switch eventTriggerType {
 	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_PERIODIC:
 		log.Debug("Stopping the periodic report subscription")
-		sub.Ticker.Stop()
+		if sub.Ticker != nil {
+			sub.Ticker.Stop()
+		}
+		m.stopReport(subID)
+	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT, e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS:
+		log.Debugf("Stopping the %v subscription", eventTriggerType)
+		m.stopReport(subID)
 	}
 
 	return response, nil, nil
@@ -348,57 +350,186 @@ func (m *Mho) RICSubscriptionDelete(ctx context.Context, request *e2appducontent
 // RICControl implements control handler for MHO service model
 func (m *Mho) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
 	log.Infof("Control Request is received for service model %v and e2 node ID: %d", m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
+	reqID, err := controlutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	controlFailure := func(cause e2apies.CauseRicrequest) (*e2appducontents.RiccontrolFailure, error) {
+		return controlutils.NewControl(
+			controlutils.WithRanFuncID(*ranFuncID),
+			controlutils.WithRequestID(*reqID),
+			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			})).BuildControlFailure()
+	}
 
 	controlHeader, err := m.getControlHeader(request)
 	if err != nil {
-		log.Error(err)
-		return nil, nil, err
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
+		return nil, failure, err
 	}
-	// TODO - check MHO command
 	log.Debugf("MHO control header: %v", controlHeader)
+	if command := controlHeader.GetControlHeaderFormat1().GetRcCommand(); controlHeader.GetControlHeaderFormat1() == nil || command != e2sm_mho.MhoCommand_MHO_COMMAND_INITIATE_HANDOVER {
+		log.Warnf("MHO command %v is not supported", command)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
+		return nil, failure, err
+	}
 
 	controlMessage, err := m.getControlMessage(request)
 	if err != nil {
-		log.Error(err)
-		return nil, nil, err
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
+		return nil, failure, err
 	}
 	log.Debugf("MHO control message: %v", controlMessage)
+	format1 := controlMessage.GetControlMessageFormat1()
+	if format1 == nil || format1.GetTargetCgi().GetNRCgi() == nil {
+		log.Warn("MHO control message format 1 with an NR target cell is missing")
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
+		return nil, failure, err
+	}
 
-	go func() {
-
-		// ToDo - should be reconsidered (not locked on GNb and AmfNGap)
-		imsi := controlMessage.GetControlMessageFormat1().GetUedId().GetGNbUeid().GetAmfUeNgapId().GetValue()
-
-		plmnIDBytes := controlMessage.GetControlMessageFormat1().GetTargetCgi().GetNRCgi().GetPLmnidentity().GetValue()
-		plmnID := ransimtypes.Uint24ToUint32(plmnIDBytes)
-		nci := utils.NewNCellIDWithBytes(controlMessage.GetControlMessageFormat1().GetTargetCgi().GetNRCgi().GetNRcellIdentity().GetValue().GetValue())
-		tCellNcgi := ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci.Uint64()))
-		tCell := &model.UECell{
-			ID:   types.GnbID(tCellNcgi),
-			NCGI: tCellNcgi,
-		}
-		m.mobilityDriver.Handover(ctx, types.IMSI(imsi), tCell)
-	}()
-
-	reqID, err := controlutils.GetRequesterID(request)
+	// TODO - the UE ID of E2SM-MHO is a gNB UE ID identified by its AMF UE NGAP ID
+	amfUeNgapID := types.AmfUENgapID(format1.GetUedId().GetGNbUeid().GetAmfUeNgapId().GetValue())
+	plmnIDBytes := format1.GetTargetCgi().GetNRCgi().GetPLmnidentity().GetValue()
+	plmnID := ransimtypes.Uint24ToUint32(plmnIDBytes)
+	nci := utils.NewNCellIDWithBytes(format1.GetTargetCgi().GetNRCgi().GetNRcellIdentity().GetValue().GetValue())
+	tCellNcgi := ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci.Uint64()))
+	tCell := &model.UECell{
+		ID:   types.GnbID(tCellNcgi),
+		NCGI: tCellNcgi,
+	}
+	ue, err := m.ServiceModel.UEs.GetWithAmfUeNgapID(ctx, amfUeNgapID)
+	if err == nil {
+		err = m.checkHandover(ctx, ue, tCellNcgi)
+	}
 	if err != nil {
-		return nil, nil, err
+		log.Warn(err)
+		failure, err = controlFailure(controlCause(err))
+		return nil, failure, err
 	}
-	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	imsi := ue.IMSI
+
+	// the handover is executed before the control request is acknowledged unless no acknowledgement is requested
+	if controlutils.GetRicControlAckRequest(request) == e2apies.RiccontrolAckRequest_RICCONTROL_ACK_REQUEST_NO_ACK {
+		go func() {
+			if err := m.mobilityDriver.Handover(context.Background(), imsi, tCell); err != nil {
+				log.Warn(err)
+			}
+		}()
+		return nil, nil, nil
+	}
+	if err := m.mobilityDriver.Handover(ctx, imsi, tCell); err != nil {
+		log.Warn(err)
+		failure, err = controlFailure(controlCause(err))
+		return nil, failure, err
+	}
+
+	// E2SM-MHO does not define a control outcome, so the outcome is the measurement report of the UE in its new
+	// serving cell
+	ue, err = m.ServiceModel.UEs.Get(ctx, imsi)
 	if err != nil {
+		log.Warn(err)
 		return nil, nil, err
 	}
-	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	outcome, err := m.createIndicationMsgFormat1(ue)
 	if err != nil {
+		log.Warn(err)
 		return nil, nil, err
 	}
+
 	response, err = controlutils.NewControl(
 		controlutils.WithRanFuncID(*ranFuncID),
 		controlutils.WithRequestID(*reqID),
-		controlutils.WithRicInstanceID(*ricInstanceID)).BuildControlAcknowledge()
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithRicControlOutcome(outcome)).BuildControlAcknowledge()
 	if err != nil {
 		log.Error(err)
 		return nil, nil, err
 	}
 	return response, nil, nil
 }
+
+// checkHandover checks that the UE is connected to a cell of the node and that the target cell is an unbarred
+// neighbor of its serving cell
+func (m *Mho) checkHandover(ctx context.Context, ue *model.UE, targetNCGI types.NCGI) error {
+	imsi := ue.IMSI
+	if ue.Cell == nil || !m.isNodeCell(ue.Cell.NCGI) {
+		return errors.NewNotFound("UE %d is not served by the node %v", imsi, m.ServiceModel.Node.GnbID)
+	}
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		return errors.NewConflict("UE %d is not connected", imsi)
+	}
+	servingCell, err := m.ServiceModel.CellStore.Get(ctx, ue.Cell.NCGI)
+	if err != nil {
+		return err
+	}
+	neighbor := false
+	for _, ncgi := range servingCell.Neighbors {
+		if ncgi == targetNCGI {
+			neighbor = true
+			break
+		}
+	}
+	if !neighbor {
+		return errors.NewInvalid("target cell %v is not a neighbor of the serving cell %v of UE %d", targetNCGI, ue.Cell.NCGI, imsi)
+	}
+	targetCell, err := m.ServiceModel.CellStore.Get(ctx, targetNCGI)
+	if err != nil {
+		return err
+	}
+	if targetCell.Barred {
+		return errors.NewUnavailable("target cell %v is barred", targetNCGI)
+	}
+	return nil
+}
+
+// startReport returns the context of the report of the subscription; the context is done once the subscription
+// is deleted
+func (m *Mho) startReport(subscription *subutils.Subscription) context.Context {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.reports[subID] = cancel
+	m.mu.Unlock()
+	return ctx
+}
+
+func (m *Mho) stopReport(subID subscriptions.ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cancel, ok := m.reports[subID]; ok {
+		cancel()
+		delete(m.reports, subID)
+	}
+}
+
+func (m *Mho) isNodeCell(ncgi types.NCGI) bool {
+	for _, cell := range m.ServiceModel.Node.Cells {
+		if cell == ncgi {
+			return true
+		}
+	}
+	return false
+}
+
+// controlCause returns the cause of a failed handover control: a request referring to an unknown UE or cell is
+// invalid, whereas a request that cannot be executed in the current state of the UE or cells fails to execute
+func controlCause(err error) e2apies.CauseRicrequest {
+	if errors.IsNotFound(err) || errors.IsInvalid(err) {
+		return e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID
+	}
+	return e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE
+}
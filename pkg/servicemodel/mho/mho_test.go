@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mho
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/pdubuilder"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	e2sm_v2_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-v2-ies"
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2appdubuilder "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPlmnID      = types.PlmnID(0x138426)
+	testAmfUeNgapID = types.AmfUENgapID(7)
+)
+
+var (
+	servingNCGI   = types.ToNCGI(testPlmnID, 0x1)
+	targetNCGI    = types.ToNCGI(testPlmnID, 0x2)
+	barredNCGI    = types.ToNCGI(testPlmnID, 0x3)
+	distantNCGI   = types.ToNCGI(testPlmnID, 0x4)
+	otherNodeNCGI = types.ToNCGI(testPlmnID, 0x5)
+)
+
+// testDriver is a mobility driver recording the handovers of the UEs
+type testDriver struct {
+	mobility.Driver
+	err       error
+	handovers chan types.NCGI
+}
+
+func (d *testDriver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UECell) error {
+	d.handovers <- tCell.NCGI
+	return d.err
+}
+
+// newTestMho returns the MHO service model of a node serving a connected UE; the serving cell has an unbarred
+// and a barred neighbor
+func newTestMho(t *testing.T, driver mobility.Driver) (*Mho, *model.UE) {
+	ctx := context.Background()
+	node := model.Node{GnbID: 144, Cells: []types.NCGI{servingNCGI, targetNCGI, barredNCGI, distantNCGI}}
+	nodeStore := nodes.NewNodeRegistry(map[string]model.Node{"node1": node})
+	cellStore := cells.NewCellRegistry(map[string]model.Cell{
+		"serving":   {NCGI: servingNCGI, Neighbors: []types.NCGI{targetNCGI, barredNCGI}},
+		"target":    {NCGI: targetNCGI},
+		"barred":    {NCGI: barredNCGI, Barred: true},
+		"distant":   {NCGI: distantNCGI},
+		"otherNode": {NCGI: otherNodeNCGI},
+	}, nodeStore)
+	ueStore := ues.NewUERegistry(1, cellStore, "connected")
+	ue := ueStore.ListAllUEs(ctx)[0]
+	assert.NoError(t, ueStore.UpdateRegistration(ctx, ue.IMSI, testAmfUeNgapID, model.Guami{}))
+	assert.NoError(t, ueStore.UpdateCell(ctx, ue.IMSI, &model.UECell{ID: types.GnbID(servingNCGI), NCGI: servingNCGI}))
+	assert.NoError(t, ueStore.UpdateCells(ctx, ue.IMSI, []*model.UECell{{ID: types.GnbID(targetNCGI), NCGI: targetNCGI, Strength: -10}}))
+	ue.RrcState = e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED
+	return &Mho{
+		ServiceModel: &registry.ServiceModel{
+			RanFunctionID: registry.Mho,
+			Node:          node,
+			Model:         &model.Model{PlmnID: testPlmnID},
+			UEs:           ueStore,
+			CellStore:     cellStore,
+		},
+		mobilityDriver: driver,
+	}, ue
+}
+
+func newTestCgi(t *testing.T, ncgi types.NCGI) *e2sm_v2_ies.Cgi {
+	cgi, err := pdubuilder.CreateCgiNrCGI(types.NewUint24(uint32(testPlmnID)).ToBytes(), &asn1.BitString{
+		Value: utils.NewNCellIDWithUint64(uint64(types.GetNCI(ncgi))).Bytes(),
+		Len:   36,
+	})
+	assert.NoError(t, err)
+	return cgi
+}
+
+// newTestControlRequest returns a request to hand the UE having the AMF UE NGAP ID over to the target cell
+func newTestControlRequest(t *testing.T, amfUeNgapID types.AmfUENgapID, target types.NCGI, ackRequest e2apies.RiccontrolAckRequest) *e2appducontents.RiccontrolRequest {
+	header, err := pdubuilder.CreateE2SmMhoControlHeader(1)
+	assert.NoError(t, err)
+	headerBytes, err := encoder.PerEncodeE2SmMhoControlHeader(header)
+	assert.NoError(t, err)
+	ueID, err := pdubuilder.CreateUeIDGNb(int64(amfUeNgapID), types.NewUint24(uint32(testPlmnID)).ToBytes(), []byte{0xFF}, []byte{0xFF, 0xC0}, []byte{0xFC})
+	assert.NoError(t, err)
+	message, err := pdubuilder.CreateE2SmMhoControlMessage(newTestCgi(t, servingNCGI), ueID, newTestCgi(t, target))
+	assert.NoError(t, err)
+	messageBytes, err := encoder.PerEncodeE2SmMhoControlMessage(message)
+	assert.NoError(t, err)
+	request, err := e2appdubuilder.NewControlRequest(e2aptypes.RicRequest{RequestorID: 1, InstanceID: 2},
+		e2aptypes.RanFunctionID(registry.Mho), headerBytes, messageBytes, nil)
+	assert.NoError(t, err)
+	return request.SetRicControlAckRequest(ackRequest)
+}
+
+func failureCause(failure *e2appducontents.RiccontrolFailure) e2apies.CauseRicrequest {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDCause) {
+			return v.GetValue().GetCause().GetRicRequest()
+		}
+	}
+	return -1
+}
+
+func TestRICControlFailure(t *testing.T) {
+	testCases := []struct {
+		name        string
+		amfUeNgapID types.AmfUENgapID
+		target      types.NCGI
+		update      func(ue *model.UE)
+		cause       e2apies.CauseRicrequest
+	}{
+		{
+			name:        "unknown UE",
+			amfUeNgapID: testAmfUeNgapID + 1,
+			target:      targetNCGI,
+			cause:       e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID,
+		},
+		{
+			name:        "UE served by another node",
+			amfUeNgapID: testAmfUeNgapID,
+			target:      targetNCGI,
+			update: func(ue *model.UE) {
+				ue.Cell = &model.UECell{ID: types.GnbID(otherNodeNCGI), NCGI: otherNodeNCGI}
+			},
+			cause: e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID,
+		},
+		{
+			name:        "idle UE",
+			amfUeNgapID: testAmfUeNgapID,
+			target:      targetNCGI,
+			update: func(ue *model.UE) {
+				ue.RrcState = e2sm_mho.Rrcstatus_RRCSTATUS_IDLE
+			},
+			cause: e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE,
+		},
+		{
+			name:        "target cell not a neighbor",
+			amfUeNgapID: testAmfUeNgapID,
+			target:      distantNCGI,
+			cause:       e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID,
+		},
+		{
+			name:        "barred target cell",
+			amfUeNgapID: testAmfUeNgapID,
+			target:      barredNCGI,
+			cause:       e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver := &testDriver{handovers: make(chan types.NCGI, 1)}
+			m, ue := newTestMho(t, driver)
+			if testCase.update != nil {
+				testCase.update(ue)
+			}
+			request := newTestControlRequest(t, testCase.amfUeNgapID, testCase.target, e2apies.RiccontrolAckRequest_RICCONTROL_ACK_REQUEST_ACK)
+			response, failure, err := m.RICControl(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Nil(t, response)
+			assert.Equal(t, testCase.cause, failureCause(failure))
+			assert.Len(t, driver.handovers, 0)
+		})
+	}
+}
+
+func TestRICControl(t *testing.T) {
+	// the UE is handed over before the control request is acknowledged with the outcome
+	driver := &testDriver{handovers: make(chan types.NCGI, 1)}
+	m, _ := newTestMho(t, driver)
+	request := newTestControlRequest(t, testAmfUeNgapID, targetNCGI, e2apies.RiccontrolAckRequest_RICCONTROL_ACK_REQUEST_ACK)
+	response, failure, err := m.RICControl(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, failure)
+	assert.NotNil(t, response)
+	assert.Equal(t, targetNCGI, <-driver.handovers)
+
+	// the failure of the handover is reported
+	driver.err = errors.NewUnavailable("target cell is full")
+	response, failure, err = m.RICControl(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE, failureCause(failure))
+	<-driver.handovers
+
+	// no acknowledgement is sent when none is requested, the UE is handed over nonetheless
+	request = newTestControlRequest(t, testAmfUeNgapID, targetNCGI, e2apies.RiccontrolAckRequest_RICCONTROL_ACK_REQUEST_NO_ACK)
+	response, failure, err = m.RICControl(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	assert.Nil(t, failure)
+	select {
+	case ncgi := <-driver.handovers:
+		assert.Equal(t, targetNCGI, ncgi)
+	case <-time.After(time.Second):
+		t.Fatal("the UE is not handed over")
+	}
+}
@@ -14,6 +14,7 @@ import (
 func (m *Mho) reportPeriodicIndication(ctx context.Context, interval int32, subscription *subutils.Subscription) {
 	log.Debugf("Starting periodic report with interval %d ms", interval)
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	defer m.stopReport(subID)
 	intervalDuration := time.Duration(interval)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
@@ -32,6 +33,9 @@ func (m *Mho) reportPeriodicIndication(ctx context.Context, interval int32, subs
 		case <-sub.E2Channel.Context().Done():
 			sub.Ticker.Stop()
 			return
+		case <-ctx.Done():
+			sub.Ticker.Stop()
+			return
 		}
 	}
 }
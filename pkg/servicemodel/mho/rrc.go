@@ -6,23 +6,45 @@ package mho
 
 import (
 	"context"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 )
 
+// processRrcUpdate sends an indication for each RRC state change of a UE served by the node until the
+// subscription is deleted or its E2 channel is closed
 func (m *Mho) processRrcUpdate(ctx context.Context, subscription *subutils.Subscription) {
 	log.Info("Start processing RRC updates")
-	for update := range m.rrcUpdateChan {
-		log.Debugf("Received RRC Update, IMSI:%v, GnbID:%v, NCGI:%v", update.IMSI, update.Cell.ID, update.Cell.NCGI)
-
-		ue, err := m.ServiceModel.UEs.Get(ctx, update.IMSI)
-		if err != nil {
-			log.Warn(err)
-			continue
-		}
-		err = m.sendRicIndicationFormat2(ctx, update.Cell.NCGI, ue, subscription)
-		if err != nil {
-			log.Warn(err)
-			continue
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	defer m.stopReport(subID)
+	sub, err := m.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ch := make(chan model.UE)
+	if err := m.mobilityDriver.WatchRrcStateChanges(ctx, ch); err != nil {
+		log.Error(err)
+		return
+	}
+	for {
+		select {
+		case update, ok := <-ch:
+			if !ok {
+				return
+			}
+			if update.Cell == nil || !m.isNodeCell(update.Cell.NCGI) {
+				continue
+			}
+			log.Debugf("Received RRC Update, IMSI:%v, GnbID:%v, NCGI:%v", update.IMSI, update.Cell.ID, update.Cell.NCGI)
+			err = m.sendRicIndicationFormat2(ctx, update.Cell.NCGI, &update, subscription)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+		case <-sub.E2Channel.Context().Done():
+			return
 		}
 	}
 }
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+// The OID and the description of the RAN function tell the JSON encoding private to the simulator apart from the
+// ASN.1 encoding of E2SM-NI
+const (
+	modelFullName = "ORAN-E2SM-NI"
+	version       = "v1"
+	modelOID      = "1.3.6.1.4.1.53148.1.1.2.101"
+	description   = "Network Interface Monitoring (ran-simulator JSON encoding)"
+)
+
+// MessageCopyStyle is the RIC style of the REPORT action copying the interface messages of the node
+const MessageCopyStyle = 1
+
+// Interface types
+const (
+	InterfaceTypeF1 = "f1"
+	InterfaceTypeXn = "xn"
+	InterfaceTypeNG = "ng"
+)
+
+// Interface directions
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+)
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+)
+
+// E2SM-NI is specified in ASN.1, yet the simulator encodes its messages in JSON: this encoding is private to the
+// simulator, whose RAN function is advertised with its own OID and cannot be decoded by an ASN.1 E2SM-NI codec.
+// The copied interface messages are carried as the APER encoded F1AP, XnAP or NGAP PDUs.
+
+// RanFunctionDefinition RAN function definition
+type RanFunctionDefinition struct {
+	RanFunctionName           servicemodel.RanFunctionName `json:"ranFunctionName"`
+	ListOfSupportedInterfaces []SupportedInterface         `json:"listOfSupportedInterfaces"`
+	RicEventTriggerStyleList  []RicStyle                   `json:"ricEventTriggerStyleList"`
+	RicReportStyleList        []RicStyle                   `json:"ricReportStyleList"`
+}
+
+// SupportedInterface interface whose messages are copied by the node
+type SupportedInterface struct {
+	InterfaceType    string   `json:"interfaceType"`
+	ListOfProcedures []string `json:"listOfProcedures"`
+}
+
+// RicStyle RIC style
+type RicStyle struct {
+	RicStyleType  int32  `json:"ricStyleType"`
+	RicStyleName  string `json:"ricStyleName"`
+	RicFormatType int32  `json:"ricFormatType"`
+}
+
+// GlobalGnbID global gNB ID; the PLMN identity and gNB ID are hex strings
+type GlobalGnbID struct {
+	PlmnIdentity string `json:"pLMNIdentity"`
+	GnbID        string `json:"gNBId"`
+}
+
+// EventTriggerDefinition event trigger definition
+type EventTriggerDefinition struct {
+	EventTriggerDefinitionFormat EventTriggerDefinitionFormat `json:"eventTriggerDefinitionFormat"`
+}
+
+// EventTriggerDefinitionFormat event trigger definition format
+type EventTriggerDefinitionFormat struct {
+	EventTriggerFormat1 *EventTriggerFormat1 `json:"eventTriggerFormat1,omitempty"`
+}
+
+// EventTriggerFormat1 triggers a report for each message of the interface matching the filters
+type EventTriggerFormat1 struct {
+	InterfaceType string `json:"interfaceType"`
+	// InterfaceDirection selects the incoming or outgoing messages only; all messages if empty
+	InterfaceDirection string `json:"interfaceDirection,omitempty"`
+	// ListOfProcedures selects the messages of the listed procedures only, e.g. HandoverRequest; all messages if empty
+	ListOfProcedures []string `json:"listOfProcedures,omitempty"`
+	// ReportStoredMessages reports the messages the node exchanged before the subscription first
+	ReportStoredMessages bool `json:"reportStoredMessages,omitempty"`
+}
+
+// ActionDefinition action definition; the action definition of the message copy style has no parameters
+type ActionDefinition struct {
+	RicStyleType int32 `json:"ricStyleType"`
+}
+
+// IndicationHeader indication header
+type IndicationHeader struct {
+	IndicationHeaderFormat IndicationHeaderFormat `json:"indicationHeaderFormat"`
+}
+
+// IndicationHeaderFormat indication header format
+type IndicationHeaderFormat struct {
+	IndicationHeaderFormat1 *IndicationHeaderFormat1 `json:"indicationHeaderFormat1,omitempty"`
+}
+
+// IndicationHeaderFormat1 describes the interface the copied message was exchanged on
+type IndicationHeaderFormat1 struct {
+	InterfaceType      string `json:"interfaceType"`
+	InterfaceDirection string `json:"interfaceDirection"`
+	// PeerNodeID is the node on the other end of an Xn interface
+	PeerNodeID *GlobalGnbID `json:"peerNodeId,omitempty"`
+	Timestamp  string       `json:"timestamp"`
+}
+
+// IndicationMessage indication message
+type IndicationMessage struct {
+	IndicationMessageFormat IndicationMessageFormat `json:"indicationMessageFormat"`
+}
+
+// IndicationMessageFormat indication message format
+type IndicationMessageFormat struct {
+	IndicationMessageFormat1 *IndicationMessageFormat1 `json:"indicationMessageFormat1,omitempty"`
+}
+
+// IndicationMessageFormat1 carries a copy of an interface message
+type IndicationMessageFormat1 struct {
+	Procedure string `json:"procedure"`
+	// SequenceNumber orders the messages of the node
+	SequenceNumber uint64 `json:"sequenceNumber"`
+	// InterfaceMessage is the APER encoded interface PDU
+	InterfaceMessage []byte `json:"interfaceMessage"`
+}
+
+// NewGlobalGnbID returns the global gNB ID of the specified node
+func NewGlobalGnbID(plmnID ransimtypes.PlmnID, gnbID ransimtypes.GnbID) GlobalGnbID {
+	return GlobalGnbID{
+		PlmnIdentity: fmt.Sprintf("%06x", plmnID),
+		GnbID:        fmt.Sprintf("%x", gnbID),
+	}
+}
+
+func decode(bytes []byte, message interface{}) error {
+	err := json.Unmarshal(bytes, message)
+	if err != nil {
+		return errors.NewInvalid("invalid %s message: %v", modelFullName, err)
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+)
+
+var _ servicemodel.Client = &Client{}
+
+var log = logging.GetLogger()
+
+// Client ni service model client
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	reports      map[subscriptions.ID]context.CancelFunc
+	mu           sync.Mutex
+}
+
+// NewServiceModel creates a new service model
+func NewServiceModel(node model.Node, model *model.Model,
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store,
+	ueStore ues.Store, cellStore cells.Store, messageStore messages.Store) (registry.ServiceModel, error) {
+	niSm := registry.ServiceModel{
+		RanFunctionID: registry.Ni,
+		ModelName:     e2smtypes.ShortName(modelFullName),
+		Revision:      1,
+		OID:           modelOID,
+		Version:       version,
+		Node:          node,
+		Model:         model,
+		Subscriptions: subStore,
+		Nodes:         nodeStore,
+		UEs:           ueStore,
+		CellStore:     cellStore,
+		MessageStore:  messageStore,
+	}
+
+	niClient := &Client{
+		ServiceModel: &niSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
+	}
+	niSm.Client = niClient
+
+	ranFunctionDefinition, err := json.Marshal(niClient.createRanFunctionDefinition())
+	if err != nil {
+		log.Error(err)
+		return registry.ServiceModel{}, err
+	}
+	niSm.Description = ranFunctionDefinition
+	return niSm, nil
+}
+
+// E2ConnectionUpdate implements connection update handler
+func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
+	return nil, nil, errors.NewNotSupported("E2 connection update is not supported")
+}
+
+// RICControl implements control handler for ni service model
+func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
+	return nil, nil, errors.New(errors.NotSupported, "Control operation is not supported")
+}
+
+// RICSubscription implements subscription handler for ni service model
+func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
+	log.Infof("RIC Subscription request received for e2 node %d and service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	var ricActionsAccepted []*e2aptypes.RicActionID
+	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
+	actionList := subutils.GetRicActionToBeSetupList(request)
+	reqID, err := subutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionFailure := func(cause e2apies.CauseRicrequest) (*e2appducontents.RicsubscriptionFailure, error) {
+		return subutils.NewSubscription(
+			subutils.WithRequestID(*reqID),
+			subutils.WithRanFuncID(*ranFuncID),
+			subutils.WithRicInstanceID(*ricInstanceID),
+			subutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			})).BuildSubscriptionFailure()
+	}
+
+	if sm.ServiceModel.MessageStore == nil {
+		log.Warn("interface messages are not recorded")
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+	eventTrigger, err := sm.getEventTrigger(request)
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	for _, action := range actionList {
+		actionID := e2aptypes.RicActionID(action.GetValue().GetRicactionToBeSetupItem().GetRicActionId().GetValue())
+		actionType := action.GetValue().GetRicactionToBeSetupItem().GetRicActionType()
+		// ni service model supports report actions only
+		if actionType != e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		err := checkActionDefinition(action.GetValue().GetRicactionToBeSetupItem().GetRicActionDefinition().GetValue())
+		if err != nil {
+			log.Warn(err)
+			ricActionsNotAdmitted[actionID] = &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+				},
+			}
+			continue
+		}
+		ricActionsAccepted = append(ricActionsAccepted, &actionID)
+	}
+
+	// At least one required action must be accepted otherwise sends a subscription failure response
+	if len(ricActionsAccepted) == 0 {
+		log.Warn("no action is accepted")
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED)
+		return nil, failure, err
+	}
+
+	subscription := subutils.NewSubscription(
+		subutils.WithRequestID(*reqID),
+		subutils.WithRanFuncID(*ranFuncID),
+		subutils.WithRicInstanceID(*ricInstanceID),
+		subutils.WithActionsAccepted(ricActionsAccepted),
+		subutils.WithActionsNotAdmitted(ricActionsNotAdmitted))
+	response, err = subscription.BuildSubscriptionResponse()
+	if err != nil {
+		log.Warn(err)
+		failure, err = subscriptionFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED)
+		return nil, failure, err
+	}
+
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	reportCtx, cancel := context.WithCancel(context.Background())
+	sm.mu.Lock()
+	sm.reports[subID] = cancel
+	sm.mu.Unlock()
+	go func() {
+		defer sm.stopReport(subID)
+		err := sm.reportIndication(reportCtx, subscription, eventTrigger)
+		if err != nil {
+			log.Warn(err)
+		}
+	}()
+	return response, nil, nil
+}
+
+// RICSubscriptionDelete implements subscription delete handler for ni service model
+func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	log.Infof("RIC subscription delete request is received for e2 node %d and  service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	reqID, err := subdeleteutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subdeleteutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subdeleteutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
+	_, err = sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
+		subdeleteutils.WithRequestID(*reqID),
+		subdeleteutils.WithRanFuncID(*ranFuncID),
+		subdeleteutils.WithRicInstanceID(*ricInstanceID))
+	response, err = subscriptionDelete.BuildSubscriptionDeleteResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stops the goroutine sending the indication messages
+	sm.stopReport(subID)
+	return response, nil, nil
+}
+
+func (sm *Client) stopReport(subID subscriptions.ID) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cancel, ok := sm.reports[subID]; ok {
+		cancel()
+		delete(sm.reports, subID)
+	}
+}
+
+// reportIndication copies the interface messages of the node matching the event trigger to the RIC:
+// first the stored messages if requested and then each new message
+func (sm *Client) reportIndication(ctx context.Context, subscription *subutils.Subscription, t *trigger) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	// watch before listing the stored messages so that no message is missed; the messages added in
+	// between are both listed and watched and are reported once
+	ch := make(chan event.Event)
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = sm.ServiceModel.MessageStore.Watch(watchCtx, ch)
+	if err != nil {
+		return err
+	}
+	var lastStoredID uint64
+	for _, message := range sm.ServiceModel.MessageStore.ListNode(ctx, sm.ServiceModel.Node.GnbID) {
+		lastStoredID = message.ID
+		if !t.stored || !t.matches(message) {
+			continue
+		}
+		if err := sm.sendRicIndication(ctx, sub, subscription, message); err != nil {
+			log.Warn(err)
+		}
+	}
+
+	for {
+		select {
+		case messageEvent, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			message := messageEvent.Value.(*messages.Message)
+			if message.ID <= lastStoredID || message.GnbID != sm.ServiceModel.Node.GnbID || !t.matches(message) {
+				continue
+			}
+			if err := sm.sendRicIndication(ctx, sub, subscription, message); err != nil {
+				log.Warn(err)
+			}
+		case <-sub.E2Channel.Context().Done():
+			log.Debug("E2 channel context is done")
+			return nil
+		}
+	}
+}
+
+func (sm *Client) sendRicIndication(ctx context.Context, sub *subscriptions.Subscription, subscription *subutils.Subscription, message *messages.Message) error {
+	header := &IndicationHeaderFormat1{
+		InterfaceType:      interfaceType(message.Interface),
+		InterfaceDirection: interfaceDirection(message.Direction),
+		Timestamp:          message.Timestamp.Format(time.RFC3339Nano),
+	}
+	if message.Interface == messages.Xn {
+		peerNodeID := NewGlobalGnbID(sm.ServiceModel.Model.PlmnID, message.PeerGnbID)
+		header.PeerNodeID = &peerNodeID
+	}
+	indicationHeaderBytes, err := json.Marshal(&IndicationHeader{
+		IndicationHeaderFormat: IndicationHeaderFormat{IndicationHeaderFormat1: header},
+	})
+	if err != nil {
+		return err
+	}
+	indicationMessageBytes, err := json.Marshal(&IndicationMessage{
+		IndicationMessageFormat: IndicationMessageFormat{
+			IndicationMessageFormat1: &IndicationMessageFormat1{
+				Procedure:        message.Procedure,
+				SequenceNumber:   message.ID,
+				InterfaceMessage: message.Payload,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	indication := e2apIndicationUtils.NewIndication(
+		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+	ricIndication, err := indication.Build()
+	if err != nil {
+		return err
+	}
+	log.Debugf("Copying %s %s message %d to subscription %v", message.Interface, message.Procedure, message.ID, sub.ID)
+	return sub.E2Channel.RICIndication(ctx, ricIndication)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrigger(t *testing.T) {
+	tr, err := newTrigger(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{
+				InterfaceType:      InterfaceTypeXn,
+				InterfaceDirection: DirectionIncoming,
+				ListOfProcedures:   []string{messages.HandoverRequest},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, tr.matches(&messages.Message{Interface: messages.Xn, Direction: messages.Incoming, Procedure: messages.HandoverRequest}))
+	assert.False(t, tr.matches(&messages.Message{Interface: messages.Xn, Direction: messages.Outgoing, Procedure: messages.HandoverRequest}))
+	assert.False(t, tr.matches(&messages.Message{Interface: messages.Xn, Direction: messages.Incoming, Procedure: messages.UEContextRelease}))
+	assert.False(t, tr.matches(&messages.Message{Interface: messages.F1, Direction: messages.Incoming, Procedure: messages.HandoverRequest}))
+
+	tr, err = newTrigger(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{InterfaceType: InterfaceTypeF1},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, tr.matches(&messages.Message{Interface: messages.F1, Direction: messages.Outgoing, Procedure: messages.F1SetupRequest}))
+
+	tr, err = newTrigger(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{InterfaceType: InterfaceTypeF1, ListOfProcedures: []string{messages.F1SetupResponse}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, tr.matches(&messages.Message{Interface: messages.F1, Direction: messages.Outgoing, Procedure: messages.F1SetupResponse}))
+	assert.False(t, tr.matches(&messages.Message{Interface: messages.F1, Direction: messages.Outgoing, Procedure: messages.F1SetupRequest}))
+
+	_, err = newTrigger(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{InterfaceType: InterfaceTypeF1, ListOfProcedures: []string{messages.HandoverRequest}},
+		},
+	})
+	assert.True(t, errors.IsNotSupported(err))
+
+	_, err = newTrigger(&EventTriggerDefinition{
+		EventTriggerDefinitionFormat: EventTriggerDefinitionFormat{
+			EventTriggerFormat1: &EventTriggerFormat1{InterfaceType: "e1"},
+		},
+	})
+	assert.True(t, errors.IsNotSupported(err))
+
+	_, err = newTrigger(&EventTriggerDefinition{})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestActionDefinition(t *testing.T) {
+	assert.NoError(t, checkActionDefinition(nil))
+	assert.NoError(t, checkActionDefinition([]byte(`{"ricStyleType":1}`)))
+	assert.True(t, errors.IsNotSupported(checkActionDefinition([]byte(`{"ricStyleType":2}`))))
+	assert.True(t, errors.IsInvalid(checkActionDefinition([]byte("not json"))))
+}
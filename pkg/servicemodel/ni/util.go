@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
+)
+
+// interfaces are the interfaces whose messages are copied, in the order they are advertised
+var interfaces = []messages.Interface{messages.F1, messages.Xn, messages.NG}
+
+// procedures are the procedures of the messages generated by the simulator for each interface
+// TODO: the NGAP messages exchanged with the simulated AMF have no payload until the simulator encodes NGAP
+var procedures = map[messages.Interface][]string{
+	messages.F1: {messages.F1SetupRequest, messages.F1SetupResponse, messages.UEContextReleaseCommand},
+	messages.Xn: {messages.XnSetupRequest, messages.XnSetupResponse, messages.NGRANNodeConfigurationUpdate,
+		messages.HandoverRequest, messages.HandoverRequestAcknowledge, messages.SNStatusTransfer, messages.UEContextRelease},
+	messages.NG: {messages.Paging, messages.InitialUEMessage, messages.InitialContextSetupRequest,
+		messages.InitialContextSetupResponse, messages.UEContextReleaseRequest, messages.UEContextReleaseCommand,
+		messages.UEContextReleaseComplete},
+}
+
+func interfaceType(iface messages.Interface) string {
+	switch iface {
+	case messages.F1:
+		return InterfaceTypeF1
+	case messages.Xn:
+		return InterfaceTypeXn
+	default:
+		return InterfaceTypeNG
+	}
+}
+
+func parseInterfaceType(name string) (messages.Interface, error) {
+	for _, iface := range interfaces {
+		if interfaceType(iface) == name {
+			return iface, nil
+		}
+	}
+	return 0, errors.NewNotSupported("interface type %s is not supported", name)
+}
+
+func interfaceDirection(direction messages.Direction) string {
+	if direction == messages.Incoming {
+		return DirectionIncoming
+	}
+	return DirectionOutgoing
+}
+
+// trigger is a parsed event trigger definition
+type trigger struct {
+	iface      messages.Interface
+	direction  string
+	procedures map[string]bool
+	stored     bool
+}
+
+// matches returns true if the message triggers a report
+func (t *trigger) matches(message *messages.Message) bool {
+	if message.Interface != t.iface {
+		return false
+	}
+	if t.direction != "" && t.direction != interfaceDirection(message.Direction) {
+		return false
+	}
+	return len(t.procedures) == 0 || t.procedures[message.Procedure]
+}
+
+func (sm *Client) getEventTrigger(request *e2appducontents.RicsubscriptionRequest) (*trigger, error) {
+	var eventTriggerBytes []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
+			eventTriggerBytes = v.GetValue().GetRicsubscriptionDetails().GetRicEventTriggerDefinition().GetValue()
+			break
+		}
+	}
+	eventTrigger := &EventTriggerDefinition{}
+	if err := decode(eventTriggerBytes, eventTrigger); err != nil {
+		return nil, err
+	}
+	return newTrigger(eventTrigger)
+}
+
+func newTrigger(eventTrigger *EventTriggerDefinition) (*trigger, error) {
+	format1 := eventTrigger.EventTriggerDefinitionFormat.EventTriggerFormat1
+	if format1 == nil {
+		return nil, errors.NewInvalid("event trigger format is missing")
+	}
+	iface, err := parseInterfaceType(format1.InterfaceType)
+	if err != nil {
+		return nil, err
+	}
+	if format1.InterfaceDirection != "" && format1.InterfaceDirection != DirectionIncoming && format1.InterfaceDirection != DirectionOutgoing {
+		return nil, errors.NewInvalid("invalid interface direction %s", format1.InterfaceDirection)
+	}
+	t := &trigger{
+		iface:      iface,
+		direction:  format1.InterfaceDirection,
+		procedures: make(map[string]bool),
+		stored:     format1.ReportStoredMessages,
+	}
+	for _, procedure := range format1.ListOfProcedures {
+		if !isSupportedProcedure(iface, procedure) {
+			return nil, errors.NewNotSupported("procedure %s of interface %s is not supported", procedure, format1.InterfaceType)
+		}
+		t.procedures[procedure] = true
+	}
+	return t, nil
+}
+
+func isSupportedProcedure(iface messages.Interface, procedure string) bool {
+	for _, p := range procedures[iface] {
+		if p == procedure {
+			return true
+		}
+	}
+	return false
+}
+
+// checkActionDefinition checks the action definition of a REPORT action; an empty action definition
+// selects the message copy style
+func checkActionDefinition(actionDefinitionBytes []byte) error {
+	if len(actionDefinitionBytes) == 0 {
+		return nil
+	}
+	actionDefinition := &ActionDefinition{}
+	if err := decode(actionDefinitionBytes, actionDefinition); err != nil {
+		return err
+	}
+	if actionDefinition.RicStyleType != MessageCopyStyle {
+		return errors.NewNotSupported("RIC style %d is not supported", actionDefinition.RicStyleType)
+	}
+	return nil
+}
+
+func (sm *Client) createRanFunctionDefinition() *RanFunctionDefinition {
+	definition := &RanFunctionDefinition{
+		RanFunctionName: servicemodel.RanFunctionName{
+			RanFunctionShortName:   modelFullName,
+			RanFunctionE2SmOid:     modelOID,
+			RanFunctionDescription: description,
+		},
+		RicEventTriggerStyleList: []RicStyle{
+			{RicStyleType: MessageCopyStyle, RicStyleName: "Interface message", RicFormatType: 1},
+		},
+		RicReportStyleList: []RicStyle{
+			{RicStyleType: MessageCopyStyle, RicStyleName: "Message copy", RicFormatType: 1},
+		},
+	}
+	for _, iface := range interfaces {
+		definition.ListOfSupportedInterfaces = append(definition.ListOfSupportedInterfaces, SupportedInterface{
+			InterfaceType:    interfaceType(iface),
+			ListOfProcedures: procedures[iface],
+		})
+	}
+	return definition
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	meastype "github.com/onosproject/rrm-son-lib/pkg/model/measurement/type"
+)
+
+// RAN parameters controllable through RC-PRE
+const (
+	// PCIParameter is the PCI of the cell
+	PCIParameter = "pci"
+	// OcnRcParameter is the individual offset of the cell in all other cells of the node; the value is the index
+	// of the offset in the Q-offset range
+	OcnRcParameter = "ocn_rc"
+	// OcnParameter is the individual offset of a neighbor in the cell; the value is a printable string
+	// "<neighbor NCGI in hex>:<offset in dB>" with an offset of the Q-offset range
+	OcnParameter = "ocn"
+	// TxPowerParameter is the TX power of the cell in dBm
+	TxPowerParameter = "tx_power"
+	// HysteresisParameter is the hysteresis of the cell in dB
+	HysteresisParameter = "hysteresis"
+	// TimeToTriggerParameter is the time to trigger of the cell in ms
+	TimeToTriggerParameter = "time_to_trigger"
+	// A3OffsetParameter is the A3 offset of the cell in dB
+	A3OffsetParameter = "a3_offset"
+)
+
+const (
+	minTxPower       = -30
+	minHysteresis    = 0
+	maxHysteresis    = 30
+	minA3Offset      = -30
+	maxA3Offset      = 30
+	maxPCI           = 1007
+	maxTimeToTrigger = 5120
+)
+
+// maxTxPower is the highest TX power in dBm of each cell size; the cell sizes are mapped to the
+// base station classes of 3GPP TS 38.104: local area for femto and enterprise cells, medium range
+// for outdoor small cells and wide area for macro cells
+var maxTxPower = map[ransimtypes.CellType]float64{
+	ransimtypes.CellType_FEMTO:         24,
+	ransimtypes.CellType_ENTERPRISE:    24,
+	ransimtypes.CellType_OUTDOOR_SMALL: 38,
+	ransimtypes.CellType_MACRO:         60,
+}
+
+// timeToTriggerValues are the time to trigger values in ms defined by 3GPP TS 38.331
+var timeToTriggerValues = []int64{0, 40, 64, 80, 100, 128, 160, 256, 320, 480, 512, 640, 1024, 1280, 2560, 5120}
+
+// setParameter applies the value of the RAN parameter to the cell; the value is validated before any
+// cell is changed. It returns the cells changed by the parameter.
+func (sm *Client) setParameter(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) ([]*model.Cell, error) {
+	switch parameterName {
+	case PCIParameter:
+		pci, err := intValue(parameterName, parameterValue, 0, maxPCI)
+		if err != nil {
+			return nil, err
+		}
+		cell.PCI = uint32(pci)
+		return []*model.Cell{cell}, nil
+	case OcnRcParameter:
+		return sm.setHandoverOcn(ctx, parameterName, parameterValue, cell)
+	case OcnParameter:
+		return setNeighborOcn(parameterName, parameterValue, cell)
+	case TxPowerParameter:
+		txPower, err := intValue(parameterName, parameterValue, minTxPower, int64(maxTxPower[cell.CellType]))
+		if err != nil {
+			return nil, err
+		}
+		cell.TxPowerDB = float64(txPower)
+		return []*model.Cell{cell}, nil
+	case HysteresisParameter:
+		hysteresis, err := intValue(parameterName, parameterValue, minHysteresis, maxHysteresis)
+		if err != nil {
+			return nil, err
+		}
+		cell.MeasurementParams.Hysteresis = int32(hysteresis)
+		return []*model.Cell{cell}, nil
+	case TimeToTriggerParameter:
+		ttt, err := intValue(parameterName, parameterValue, 0, maxTimeToTrigger)
+		if err != nil {
+			return nil, err
+		}
+		if !isTimeToTrigger(ttt) {
+			return nil, errors.NewInvalid("%s %d ms is not one of %v", parameterName, ttt, timeToTriggerValues)
+		}
+		cell.MeasurementParams.TimeToTrigger = int32(ttt)
+		return []*model.Cell{cell}, nil
+	case A3OffsetParameter:
+		a3Offset, err := intValue(parameterName, parameterValue, minA3Offset, maxA3Offset)
+		if err != nil {
+			return nil, err
+		}
+		cell.MeasurementParams.EventA3Params.A3Offset = int32(a3Offset)
+		return []*model.Cell{cell}, nil
+	default:
+		return nil, errors.NewNotSupported("RAN parameter %s is not supported", parameterName)
+	}
+}
+
+// setHandoverOcn sets the individual offset of the cell in all other cells of the node having it as neighbor
+func (sm *Client) setHandoverOcn(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) ([]*model.Cell, error) {
+	index, err := intValue(parameterName, parameterValue, int64(meastype.QOffsetMinus24dB), int64(meastype.QOffset24dB))
+	if err != nil {
+		return nil, err
+	}
+	qOffset := meastype.QOffsetRange(index)
+	ocn := qOffset.GetValue().(int)
+	nCellNCGI := cell.NCGI
+	changed := make([]*model.Cell, 0)
+	for _, ncgi := range sm.ServiceModel.Node.Cells {
+		if ncgi == nCellNCGI {
+			continue
+		}
+		sCell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
+		if err != nil {
+			log.Errorf("NCGI (%v) is not in cell store", ncgi)
+			continue
+		}
+		if _, ok := sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI]; !ok {
+			log.Errorf("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", nCellNCGI, ncgi)
+			continue
+		}
+		log.Debugf("Cell (%v) Ocn in the cell (%v) is set from %v to %v", cell.NCGI, ncgi, sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI], ocn)
+		sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI] = int32(ocn)
+		changed = append(changed, sCell)
+	}
+	return changed, nil
+}
+
+// setNeighborOcn sets the individual offset of a neighbor in the cell
+func setNeighborOcn(parameterName string, parameterValue interface{}, cell *model.Cell) ([]*model.Cell, error) {
+	value, ok := parameterValue.(string)
+	if !ok {
+		return nil, errors.NewInvalid("%s must be a printable string", parameterName)
+	}
+	fields := strings.Split(value, ":")
+	if len(fields) != 2 {
+		return nil, errors.NewInvalid("%s %q is not formatted as <neighbor NCGI>:<offset>", parameterName, value)
+	}
+	ncgi, err := strconv.ParseUint(fields[0], 16, 64)
+	if err != nil {
+		return nil, errors.NewInvalid("%s %q has an invalid neighbor NCGI", parameterName, value)
+	}
+	ocn, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil || !isQOffset(ocn) {
+		return nil, errors.NewInvalid("%s %q must have an offset of the Q-offset range", parameterName, value)
+	}
+	nCellNCGI := ransimtypes.NCGI(ncgi)
+	if !isNeighbor(cell, nCellNCGI) {
+		return nil, errors.NewNotFound("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", nCellNCGI, cell.NCGI)
+	}
+	if cell.MeasurementParams.NCellIndividualOffsets == nil {
+		cell.MeasurementParams.NCellIndividualOffsets = make(map[ransimtypes.NCGI]int32)
+	}
+	cell.MeasurementParams.NCellIndividualOffsets[nCellNCGI] = int32(ocn)
+	return []*model.Cell{cell}, nil
+}
+
+func isNeighbor(cell *model.Cell, ncgi ransimtypes.NCGI) bool {
+	for _, neighbor := range cell.Neighbors {
+		if neighbor == ncgi {
+			return true
+		}
+	}
+	return false
+}
+
+// isQOffset returns whether the offset in dB is a value of the Q-offset range of 3GPP TS 38.331
+func isQOffset(ocn int64) bool {
+	for q := meastype.QOffsetMinus24dB; q <= meastype.QOffset24dB; q++ {
+		if int64(q.GetValue().(int)) == ocn {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimeToTrigger(ttt int64) bool {
+	for _, value := range timeToTriggerValues {
+		if value == ttt {
+			return true
+		}
+	}
+	return false
+}
+
+// intValue returns the integer value of the RAN parameter if it is within the specified range
+func intValue(parameterName string, parameterValue interface{}, min int64, max int64) (int64, error) {
+	var value int64
+	switch parameterValue := parameterValue.(type) {
+	case int32:
+		value = int64(parameterValue)
+	case uint32:
+		value = int64(parameterValue)
+	case int64:
+		value = parameterValue
+	case uint64:
+		value = int64(parameterValue)
+	default:
+		return 0, errors.NewInvalid("%s must be an integer", parameterName)
+	}
+	if value < min || value > max {
+		return 0, errors.NewInvalid("%s %d is out of the range from %d to %d", parameterName, value, min, max)
+	}
+	return value, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rc
+
+import (
+	"context"
+	"testing"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	meastype "github.com/onosproject/rrm-son-lib/pkg/model/measurement/type"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetParameter(t *testing.T) {
+	ctx := context.Background()
+	sm := &Client{ServiceModel: &registry.ServiceModel{}}
+	cell := &model.Cell{
+		NCGI:      1,
+		CellType:  ransimtypes.CellType_FEMTO,
+		Neighbors: []ransimtypes.NCGI{0x2a},
+	}
+
+	changed, err := sm.setParameter(ctx, TxPowerParameter, int64(20), cell)
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.Cell{cell}, changed)
+	assert.Equal(t, 20.0, cell.TxPowerDB)
+	_, err = sm.setParameter(ctx, TxPowerParameter, int64(30), cell)
+	assert.True(t, errors.IsInvalid(err))
+	cell.CellType = ransimtypes.CellType_MACRO
+	_, err = sm.setParameter(ctx, TxPowerParameter, int64(30), cell)
+	assert.NoError(t, err)
+
+	_, err = sm.setParameter(ctx, HysteresisParameter, int64(4), cell)
+	assert.NoError(t, err)
+	_, err = sm.setParameter(ctx, A3OffsetParameter, int64(-6), cell)
+	assert.NoError(t, err)
+	_, err = sm.setParameter(ctx, TimeToTriggerParameter, int64(640), cell)
+	assert.NoError(t, err)
+	_, err = sm.setParameter(ctx, TimeToTriggerParameter, int64(650), cell)
+	assert.True(t, errors.IsInvalid(err))
+	assert.Equal(t, int32(4), cell.MeasurementParams.Hysteresis)
+	assert.Equal(t, int32(-6), cell.MeasurementParams.EventA3Params.A3Offset)
+	assert.Equal(t, int32(640), cell.MeasurementParams.TimeToTrigger)
+
+	_, err = sm.setParameter(ctx, OcnParameter, "2a:-3", cell)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-3), cell.MeasurementParams.NCellIndividualOffsets[0x2a])
+	_, err = sm.setParameter(ctx, OcnParameter, "2b:-3", cell)
+	assert.True(t, errors.IsNotFound(err))
+	_, err = sm.setParameter(ctx, OcnParameter, "2a", cell)
+	assert.True(t, errors.IsInvalid(err))
+	_, err = sm.setParameter(ctx, OcnParameter, "2a:7", cell)
+	assert.True(t, errors.IsInvalid(err))
+
+	_, err = sm.setParameter(ctx, "tilt", int64(1), cell)
+	assert.True(t, errors.IsNotSupported(err))
+}
+
+func TestSetHandoverOcn(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cells.NewCellRegistry(map[string]model.Cell{
+		"cell1": {
+			NCGI:              0x1,
+			Neighbors:         []ransimtypes.NCGI{0x2},
+			MeasurementParams: model.MeasurementParams{NCellIndividualOffsets: map[ransimtypes.NCGI]int32{0x2: 0}},
+		},
+		"cell2": {NCGI: 0x2},
+	}, nodes.NewNodeRegistry(map[string]model.Node{}))
+	sm := &Client{ServiceModel: &registry.ServiceModel{
+		Node:      model.Node{Cells: []ransimtypes.NCGI{0x1, 0x2}},
+		CellStore: cellStore,
+	}}
+	cell, err := cellStore.Get(ctx, 0x2)
+	assert.NoError(t, err)
+
+	// the value is the index of the offset in the Q-offset range
+	changed, err := sm.setParameter(ctx, OcnRcParameter, int64(meastype.QOffset5dB), cell)
+	assert.NoError(t, err)
+	assert.Len(t, changed, 1)
+	assert.Equal(t, ransimtypes.NCGI(0x1), changed[0].NCGI)
+	assert.Equal(t, int32(5), changed[0].MeasurementParams.NCellIndividualOffsets[0x2])
+	changed, err = sm.setParameter(ctx, OcnRcParameter, int64(meastype.QOffsetMinus24dB), cell)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-24), changed[0].MeasurementParams.NCellIndividualOffsets[0x2])
+
+	_, err = sm.setParameter(ctx, OcnRcParameter, int64(meastype.QOffset24dB)+1, cell)
+	assert.True(t, errors.IsInvalid(err))
+	_, err = sm.setParameter(ctx, OcnRcParameter, int64(-1), cell)
+	assert.True(t, errors.IsInvalid(err))
+}
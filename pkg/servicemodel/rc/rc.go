@@ -6,6 +6,7 @@ package rc
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/onosproject/ran-simulator/pkg/utils"
@@ -57,6 +58,8 @@ var log = logging.GetLogger()
 // Client rc service model client
 type Client struct {
 	ServiceModel *registry.ServiceModel
+	reports      map[subscriptions.ID]context.CancelFunc
+	mu           sync.Mutex
 }
 
 func (sm *Client) reportPeriodicIndication(ctx context.Context, interval uint32, subscription *subutils.Subscription) error {
@@ -81,6 +84,9 @@ func (sm *Client) reportPeriodicIndication(ctx context.Context, interval uint32,
 		case <-sub.E2Channel.Context().Done():
 			sub.Ticker.Stop()
 			return nil
+		case <-ctx.Done():
+			sub.Ticker.Stop()
+			return nil
 		}
 	}
 }
@@ -118,7 +124,7 @@ func (sm *Client) reportIndicationOnChange(ctx context.Context, subscription *su
 	}
 	cellEventCh := make(chan event.Event)
 	nodeCells := sm.ServiceModel.Node.Cells
-	err = sm.ServiceModel.CellStore.Watch(context.Background(), cellEventCh)
+	err = sm.ServiceModel.CellStore.Watch(ctx, cellEventCh)
 	if err != nil {
 		return err
 	}
@@ -131,7 +137,10 @@ func (sm *Client) reportIndicationOnChange(ctx context.Context, subscription *su
 
 	for {
 		select {
-		case cellEvent := <-cellEventCh:
+		case cellEvent, ok := <-cellEventCh:
+			if !ok {
+				return nil
+			}
 			log.Debug("Received cell event:", cellEvent)
 			cellEventType := cellEvent.Type.(cells.CellEvent)
 			if cellEventType == cells.UpdatedNeighbors || cellEventType == cells.Updated {
@@ -175,6 +184,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 
 	rcClient := &Client{
 		ServiceModel: &rcSm,
+		reports:      make(map[subscriptions.ID]context.CancelFunc),
 	}
 
 	rcSm.Client = rcClient
@@ -276,20 +286,29 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 	ncgi := ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci))
 	parameterName := controlMessage.GetControlMessage().ParameterType.RanParameterName.Value
 	parameterID := controlMessage.GetControlMessage().ParameterType.RanParameterId.Value
-	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
+	outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
+		controloutcome.WithRanParameterID(parameterID)).
+		ToAsn1Bytes()
 	if err != nil {
-		log.Debugf("Ran parameter for entity %d not found", ncgi)
-		outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
-			controloutcome.WithRanParameterID(parameterID)).
-			ToAsn1Bytes()
-		if err != nil {
-			return nil, nil, err
-		}
-		failure, err = controlutils.NewControl(
+		return nil, nil, err
+	}
+	controlFailure := func(cause e2apies.CauseRicrequest) (*e2appducontents.RiccontrolFailure, error) {
+		return controlutils.NewControl(
 			controlutils.WithRanFuncID(*ranFuncID),
 			controlutils.WithRequestID(*reqID),
 			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(&e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: cause,
+				},
+			}),
 			controlutils.WithRicControlOutcome(outcomeAsn1Bytes)).BuildControlFailure()
+	}
+
+	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
+	if err != nil {
+		log.Debugf("Ran parameter for entity %d not found", ncgi)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -305,33 +324,27 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 	case e2smrcpreies.RanparameterType_RANPARAMETER_TYPE_PRINTABLE_STRING:
 		parameterValue = controlMessage.GetControlMessage().GetParameterVal().GetValuePrtS()
 	}
-	setPCI(parameterName, parameterValue, cell)
-	sm.setHandoverOcn(ctx, parameterName, parameterValue, cell)
-
-	err = sm.ServiceModel.CellStore.Update(ctx, cell)
+	changedCells, err := sm.setParameter(ctx, parameterName, parameterValue, cell)
 	if err != nil {
-		outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
-			controloutcome.WithRanParameterID(parameterID)).
-			ToAsn1Bytes()
-		if err != nil {
-			return nil, nil, err
-		}
-		failure, err = controlutils.NewControl(
-			controlutils.WithRanFuncID(*ranFuncID),
-			controlutils.WithRequestID(*reqID),
-			controlutils.WithRicInstanceID(*ricInstanceID),
-			controlutils.WithRicControlOutcome(outcomeAsn1Bytes)).BuildControlFailure()
+		log.Warn(err)
+		failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID)
 		if err != nil {
 			return nil, nil, err
 		}
 		return nil, failure, nil
 	}
 
-	outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
-		controloutcome.WithRanParameterID(parameterID)).
-		ToAsn1Bytes()
-	if err != nil {
-		return nil, nil, err
+	// Updates the changed cells so that the subscriptions report the change
+	for _, changedCell := range changedCells {
+		err = sm.ServiceModel.CellStore.Update(ctx, changedCell)
+		if err != nil {
+			log.Warn(err)
+			failure, err = controlFailure(e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_FAILED_TO_EXECUTE)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, failure, nil
+		}
 	}
 
 	response, err = controlutils.NewControl(
@@ -451,28 +464,29 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		return nil, subscriptionFailure, nil
 	}
 
+	subID := subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID)
 	switch eventTriggerType {
 	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_UPON_CHANGE:
 		log.Debug("Received on change report subscription request")
+		reportCtx := sm.startReport(subID)
 		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			err = sm.reportIndicationOnChange(ctx, subscription)
+			defer sm.stopReport(subID)
+			err = sm.reportIndicationOnChange(reportCtx, subscription)
 			if err != nil {
 				return
 			}
 		}()
 	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_PERIODIC:
 		log.Debug("Received periodic report subscription request")
+		reportCtx := sm.startReport(subID)
 		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			defer sm.stopReport(subID)
 			interval, err := sm.getReportPeriod(request)
 			if err != nil {
 				log.Error(err)
 				return
 			}
-			err = sm.reportPeriodicIndication(ctx, interval, subscription)
+			err = sm.reportPeriodicIndication(reportCtx, interval, subscription)
 			if err != nil {
 				return
 			}
@@ -528,10 +542,29 @@ func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducon
 	switch eventTriggerType {
 	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_PERIODIC:
 		log.Debug("Stopping the periodic report subscription")
-		sub.Ticker.Stop()
 	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_UPON_CHANGE:
-		// TODO stop on change event trigger
+		log.Debug("Stopping the on change report subscription")
 	}
+	// Stops the goroutine sending the indication messages
+	sm.stopReport(subID)
 
 	return response, nil, nil
 }
+
+// startReport registers the report of the subscription and returns the context cancelled when it stops
+func (sm *Client) startReport(subID subscriptions.ID) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.reports[subID] = cancel
+	return ctx
+}
+
+func (sm *Client) stopReport(subID subscriptions.ID) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cancel, ok := sm.reports[subID]; ok {
+		cancel()
+		delete(sm.reports, subID)
+	}
+}
@@ -11,10 +11,6 @@ import (
 	e2smrcpresm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/servicemodel"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 
-	meastype "github.com/onosproject/rrm-son-lib/pkg/model/measurement/type"
-
-	"github.com/onosproject/ran-simulator/pkg/model"
-
 	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	rcindicationhdr "github.com/onosproject/ran-simulator/pkg/utils/e2sm/rc/indication/header"
@@ -262,53 +258,3 @@ func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI
 	}
 	return ricIndication, nil
 }
-
-func setPCI(parameterName string, parameterValue interface{}, cell *model.Cell) {
-	if parameterName == "pci" {
-		switch parameterValue := parameterValue.(type) {
-		case int32:
-			cell.PCI = uint32(parameterValue)
-		case uint32:
-			cell.PCI = parameterValue
-		case int64:
-			cell.PCI = uint32(parameterValue)
-		case uint64:
-			cell.PCI = uint32(parameterValue)
-		}
-	}
-}
-
-func (sm *Client) setHandoverOcn(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) {
-	var ocnRc meastype.QOffsetRange
-	nCellNCGI := cell.NCGI
-
-	if parameterName == "ocn_rc" {
-		switch parameterValue := parameterValue.(type) {
-		case int32:
-			ocnRc = meastype.QOffsetRange(parameterValue)
-		case uint32:
-			ocnRc = meastype.QOffsetRange(parameterValue)
-		case int64:
-			ocnRc = meastype.QOffsetRange(parameterValue)
-		case uint64:
-			ocnRc = meastype.QOffsetRange(parameterValue)
-		}
-
-		for _, ncgi := range sm.ServiceModel.Node.Cells {
-			if ncgi == nCellNCGI {
-				continue
-			}
-			sCell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
-			if err != nil {
-				log.Errorf("NCGI (%v) is not in cell store")
-			}
-			if _, ok := sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI]; !ok {
-				log.Errorf("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", nCellNCGI, ncgi)
-				continue
-			}
-			log.Debugf("Cell (%v) Ocn in the cell (%v) is set from %v to %v", cell.NCGI, ncgi, sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI], ocnRc.GetValue().(int))
-			sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI] = int32(ocnRc.GetValue().(int))
-		}
-	}
-
-}
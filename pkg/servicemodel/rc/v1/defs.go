@@ -14,6 +14,7 @@ const (
 	eventTriggerStyle1  = "Message Event"
 	eventTriggerStyle2  = "Call Process Breakpoint"
 	eventTriggerStyle3  = "E2 Node Information"
+	eventTriggerStyle4  = "UE Information Change"
 	controlStyleType3   = 3
 	controlStyleType200 = 200 // for PCI use-case: since there is no style for PCI use-case, define a new style
 	controlActionID1    = 1
@@ -21,12 +22,22 @@ const (
 	ricInsertIndicationIDForMHO = 1
 	ricInsertStyleType3         = 3
 
+	ricPolicyStyleType1 = 1
+	ricPolicyStyleName1 = "Radio Bearer Control"
+	ricPolicyStyleType2 = 2
+	ricPolicyStyleName2 = "Radio Resource Allocation Control"
 	ricPolicyStyleType3 = 3
 	ricPolicyStyleName  = "Connected Mode Mobility Control"
 
 	ricPolicyActionIDForMLB             = 1
 	ricPolicyActionNameForMLB           = "Policy for Handover Control"
 	ricActionDefinitionFormatTypeForMLB = 2
+
+	ricPolicyActionIDForDRBQoS             = 1
+	ricPolicyActionNameForDRBQoS           = "DRB QoS Configuration"
+	ricPolicyActionIDForSlicePRBQuota      = 6
+	ricPolicyActionNameForSlicePRBQuota    = "Slice-level PRB quota"
+	ricActionDefinitionFormatTypeForPolicy = 2
 )
 
 // RAN parameter IDs
@@ -57,6 +68,38 @@ const (
 	CellSpecificOffsetRANParameterID = 10201
 	// CellSpecificOffsetRANParameterName Ocn RAN parameter name
 	CellSpecificOffsetRANParameterName = "Cell Specific Offset"
+
+	// RRMPolicyRatioListRANParameterID RRM Policy Ratio List RAN parameter ID
+	RRMPolicyRatioListRANParameterID = 1
+	// SSTRANParameterID SST RAN parameter ID
+	SSTRANParameterID = 8
+	// SDRANParameterID SD RAN parameter ID
+	SDRANParameterID = 9
+	// MinPRBPolicyRatioRANParameterID Min PRB Policy Ratio RAN parameter ID
+	MinPRBPolicyRatioRANParameterID = 10
+	// MaxPRBPolicyRatioRANParameterID Max PRB Policy Ratio RAN parameter ID
+	MaxPRBPolicyRatioRANParameterID = 11
+	// DedicatedPRBPolicyRatioRANParameterID Dedicated PRB Policy Ratio RAN parameter ID
+	DedicatedPRBPolicyRatioRANParameterID = 12
+)
+
+// RAN parameter IDs resolved against the simulated UE and cell state when policy conditions are evaluated.
+// E2SM-RC does not assign IDs to these, so the simulator defines its own.
+const (
+	// ServingCellNCGIRANParameterID serving cell NCGI as a printable hex string
+	ServingCellNCGIRANParameterID = 30001
+	// ServingCellPCIRANParameterID serving cell PCI
+	ServingCellPCIRANParameterID = 30002
+	// ServingCellRrcConnectedUEsRANParameterID number of RRC connected UEs in the serving cell
+	ServingCellRrcConnectedUEsRANParameterID = 30003
+	// UERrcStateRANParameterID UE RRC state
+	UERrcStateRANParameterID = 30004
+	// FiveQIRANParameterID UE 5QI; also used as the policy action parameter of DRB QoS configuration
+	FiveQIRANParameterID = 30005
+	// ServingCellRSRPRANParameterID RSRP of the serving cell measured by the UE
+	ServingCellRSRPRANParameterID = 30006
+	// TargetCellRSRPRANParameterID RSRP of the target cell measured by the UE
+	TargetCellRSRPRANParameterID = 30007
 )
 
 // UE Event IDs
@@ -64,6 +107,12 @@ const (
 	A3MeasurementReportUEEventID = 2
 )
 
+// E2 Node Information Change IDs
+const (
+	CellConfigurationChangeID    = 1
+	CellNeighborRelationChangeID = 2
+)
+
 // Call Process Breakpoint
 const (
 	CallProcessTypeIDMobilityManagement = 3
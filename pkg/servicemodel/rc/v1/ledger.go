@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+)
+
+func (c *Client) policyKey(subID subscriptions.ID) policies.Key {
+	return policies.Key{
+		GnbID:          c.ServiceModel.Node.GnbID,
+		SubscriptionID: string(subID),
+	}
+}
+
+// sliceKey identifies a slice by its SST and SD in the policy ledger
+func sliceKey(sst uint32, sd uint32) uint64 {
+	return uint64(sst)<<32 | uint64(sd)
+}
+
+// recordPolicyChange records a change applied by the policies of a subscription in the policy ledger. The ledger
+// keeps the original value of the first change of each parameter of the subscription: when the policy is re-applied,
+// the value it set before is not taken as the original, only the applied value is updated.
+func (c *Client) recordPolicyChange(ctx context.Context, subID subscriptions.ID, change policies.Change) {
+	err := c.ServiceModel.PolicyStore.Record(ctx, c.policyKey(subID), change)
+	if err != nil {
+		log.Warnf("Failed to record %v change of policy for subscription %v: %v", change.Parameter, subID, err)
+	}
+}
+
+// revertPolicy removes the ledger entry of a subscription and restores the original values of the parameters
+// changed by its policies. A parameter is only restored if it still holds the value the policy set, so that
+// changes made since by others, including other policies, are left untouched.
+func (c *Client) revertPolicy(ctx context.Context, subID subscriptions.ID) {
+	policy, err := c.ServiceModel.PolicyStore.Delete(ctx, c.policyKey(subID))
+	if err != nil {
+		return
+	}
+	for i := len(policy.Changes) - 1; i >= 0; i-- {
+		change := policy.Changes[i]
+		switch change.Parameter {
+		case policies.CellIndividualOffset:
+			err = c.revertCellIndividualOffset(ctx, change)
+		case policies.FiveQI:
+			err = c.revertFiveQI(ctx, change)
+		case policies.RrmPolicyRatio:
+			err = c.revertRrmPolicyRatio(ctx, change)
+		}
+		if err != nil {
+			log.Warnf("Failed to revert %v change of policy for subscription %v: %v", change.Parameter, subID, err)
+		}
+	}
+	log.Infof("Reverted %d changes of policy for subscription %v", len(policy.Changes), subID)
+}
+
+func (c *Client) revertCellIndividualOffset(ctx context.Context, change *policies.Change) error {
+	cell, err := c.ServiceModel.CellStore.Get(ctx, ransimtypes.NCGI(change.Target))
+	if err != nil {
+		return err
+	}
+	ncgi := ransimtypes.NCGI(change.Key)
+	if current, ok := cell.MeasurementParams.NCellIndividualOffsets[ncgi]; !ok || current != change.Applied.(int32) {
+		return nil
+	}
+	log.Infof("Cell (%v) Ocn in the cell (%v) is restored to %v", ncgi, cell.NCGI, change.Original)
+	cell.MeasurementParams.NCellIndividualOffsets[ncgi] = change.Original.(int32)
+	return c.ServiceModel.CellStore.Update(ctx, cell)
+}
+
+func (c *Client) revertFiveQI(ctx context.Context, change *policies.Change) error {
+	ue, err := c.ServiceModel.UEs.Get(ctx, ransimtypes.IMSI(change.Target))
+	if err != nil {
+		return err
+	}
+	if ue.FiveQi != change.Applied.(int) {
+		return nil
+	}
+	log.Infof("5QI of UE %v is restored to %v", ue.IMSI, change.Original)
+	return c.ServiceModel.UEs.UpdateUE(ctx, ue.IMSI, change.Original.(int), true)
+}
+
+func (c *Client) revertRrmPolicyRatio(ctx context.Context, change *policies.Change) error {
+	cell, err := c.ServiceModel.CellStore.Get(ctx, ransimtypes.NCGI(change.Target))
+	if err != nil {
+		return err
+	}
+	applied := change.Applied.(model.RrmPolicyRatio)
+	for i, current := range cell.RrmPolicyRatios {
+		if current.Sst != applied.Sst || current.Sd != applied.Sd {
+			continue
+		}
+		if current != applied {
+			return nil
+		}
+		if change.Original == nil {
+			cell.RrmPolicyRatios = append(cell.RrmPolicyRatios[:i], cell.RrmPolicyRatios[i+1:]...)
+		} else {
+			cell.RrmPolicyRatios[i] = change.Original.(model.RrmPolicyRatio)
+		}
+		log.Infof("RRM policy ratios of the cell (%v) are restored to %+v", cell.NCGI, cell.RrmPolicyRatios)
+		return c.ServiceModel.CellStore.Update(ctx, cell)
+	}
+	return nil
+}
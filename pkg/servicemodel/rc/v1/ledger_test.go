@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smrcies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc/v1/e2sm-rc-ies"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFiveQIPolicyAction(fiveQI int64) *e2smrcies.RicPolicyAction {
+	return &e2smrcies.RicPolicyAction{
+		RanParametersList: []*e2smrcies.RicPolicyActionRanparameterItem{
+			{
+				RanParameterId: &e2smrcies.RanparameterId{Value: FiveQIRANParameterID},
+				RanParameterValueType: &e2smrcies.RanparameterValueType{
+					RanparameterValueType: &e2smrcies.RanparameterValueType_RanPChoiceElementFalse{
+						RanPChoiceElementFalse: &e2smrcies.RanparameterValueTypeChoiceElementFalse{
+							RanParameterValue: &e2smrcies.RanparameterValue{
+								RanparameterValue: &e2smrcies.RanparameterValue_ValueInt{ValueInt: fiveQI},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReappliedPolicyRevert(t *testing.T) {
+	ctx := context.Background()
+	ncgi := types.ToNCGI(0x138426, 0x1)
+	nodeStore := nodes.NewNodeRegistry(map[string]model.Node{})
+	cellStore := cells.NewCellRegistry(map[string]model.Cell{"cell1": {NCGI: ncgi}}, nodeStore)
+	ueStore := ues.NewUERegistry(1, cellStore, "connected")
+	c := &Client{
+		ServiceModel: &registry.ServiceModel{
+			Node:        model.Node{GnbID: 144, Cells: []types.NCGI{ncgi}},
+			UEs:         ueStore,
+			CellStore:   cellStore,
+			PolicyStore: policies.NewPolicyRegistry(),
+		},
+	}
+	subID := subscriptions.ID("1:1:4")
+	assert.NoError(t, c.ServiceModel.PolicyStore.Add(ctx, c.policyKey(subID), nil))
+	ue := ueStore.ListAllUEs(ctx)[0]
+	assert.NoError(t, ueStore.UpdateUE(ctx, ue.IMSI, 9, true))
+
+	// the policy is applied, then re-applied with another 5QI
+	for _, fiveQI := range []int64{7, 5} {
+		ue, err := ueStore.Get(ctx, ue.IMSI)
+		assert.NoError(t, err)
+		assert.NoError(t, c.processDRBQoSLogic(ctx, subID, newFiveQIPolicyAction(fiveQI), ue))
+	}
+	policy, err := c.ServiceModel.PolicyStore.Get(ctx, c.policyKey(subID))
+	assert.NoError(t, err)
+	assert.Len(t, policy.Changes, 1)
+	assert.Equal(t, 9, policy.Changes[0].Original)
+	assert.Equal(t, 5, policy.Changes[0].Applied)
+
+	// the 5QI the UE had before the policy was first applied is restored
+	c.revertPolicy(ctx, subID)
+	ue, err = ueStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, ue.FiveQi)
+}
@@ -0,0 +1,569 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	e2smrcies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc/v1/e2sm-rc-ies"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+)
+
+// policy is a single RIC policy installed by a POLICY action
+type policy struct {
+	styleType int32
+	actionID  int32
+	action    *e2smrcies.RicPolicyAction
+	condition *e2smrcies.RanparameterTesting
+}
+
+// policyContext is the UE and cell state a policy is evaluated against
+type policyContext struct {
+	ue          *model.UE
+	servingCell *model.Cell
+	targetCell  *model.Cell
+}
+
+// policyTrigger is the kind of event which causes the installed policies to be evaluated
+type policyTrigger int
+
+const (
+	// triggerOnA3 evaluates policies whenever a UE of the node enters the A3 condition
+	triggerOnA3 policyTrigger = iota
+	// triggerOnUEChange evaluates policies whenever a UE of the node is updated
+	triggerOnUEChange
+	// triggerOnCellChange evaluates policies whenever a cell of the node is updated
+	triggerOnCellChange
+)
+
+// policyEngine keeps the policies of a subscription applied for as long as the subscription exists
+type policyEngine struct {
+	client   *Client
+	subID    subscriptions.ID
+	policies []*policy
+	cancel   context.CancelFunc
+	// mu serializes applying the policies with reverting them once the engine is stopped
+	mu      sync.Mutex
+	stopped bool
+}
+
+func newPolicies(actionDefinitionsMaps map[*e2aptypes.RicActionID]*e2smrcies.E2SmRcActionDefinition) ([]*policy, error) {
+	policies := make([]*policy, 0)
+	for _, ad := range actionDefinitionsMaps {
+		adFormat2 := ad.GetRicActionDefinitionFormats().GetActionDefinitionFormat2()
+		if adFormat2 == nil {
+			continue
+		}
+		styleType := ad.GetRicStyleType().GetValue()
+		for _, item := range adFormat2.GetRicPolicyConditionsList() {
+			p := &policy{
+				styleType: styleType,
+				actionID:  item.GetRicPolicyAction().GetRicPolicyActionId().GetValue(),
+				action:    item.GetRicPolicyAction(),
+				condition: item.GetRicPolicyConditionDefinition(),
+			}
+			if !p.isSupported() {
+				return nil, errors.NewNotSupported("policy action %d of policy style %d is not supported", p.actionID, p.styleType)
+			}
+			policies = append(policies, p)
+		}
+	}
+	return policies, nil
+}
+
+func (p *policy) isSupported() bool {
+	switch p.styleType {
+	case ricPolicyStyleType1:
+		return p.actionID == ricPolicyActionIDForDRBQoS
+	case ricPolicyStyleType2:
+		return p.actionID == ricPolicyActionIDForSlicePRBQuota
+	case ricPolicyStyleType3:
+		return p.actionID == ricPolicyActionIDForMLB
+	}
+	return false
+}
+
+// isUEPolicy returns true if the policy is applied per UE rather than per cell
+func (p *policy) isUEPolicy() bool {
+	return p.styleType == ricPolicyStyleType1
+}
+
+func getPolicyTriggers(eventTriggers *e2smrcies.E2SmRcEventTrigger) ([]policyTrigger, error) {
+	triggers := make([]policyTrigger, 0)
+	eventTriggerFormats := eventTriggers.GetRicEventTriggerFormats()
+	switch eventTrigger := eventTriggerFormats.RicEventTriggerFormats.(type) {
+	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat1:
+		// Process RIC Event trigger definition IE style 1: Message Event
+		for _, m := range eventTrigger.EventTriggerFormat1.GetMessageList() {
+			for _, e := range m.GetAssociatedUeevent().GetUeEventList() {
+				if e.GetUeEventId().GetValue() == A3MeasurementReportUEEventID {
+					triggers = append(triggers, triggerOnA3)
+				}
+			}
+		}
+	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat3:
+		// Process RIC Event trigger definition IE style 3: E2 Node Information Change
+		for _, e2NodeChange := range eventTrigger.EventTriggerFormat3.GetE2NodeInfoChangeList() {
+			if e2NodeChange.GetE2NodeInfoChangeId() == CellConfigurationChangeID {
+				triggers = append(triggers, triggerOnCellChange)
+			}
+		}
+	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat4:
+		// Process RIC Event trigger definition IE style 4: UE Information Change
+		triggers = append(triggers, triggerOnUEChange)
+	}
+	if len(triggers) == 0 {
+		return nil, errors.NewNotSupported("event trigger is not supported for policy actions")
+	}
+	return triggers, nil
+}
+
+// startPolicyEngine applies the policies once and keeps them applied on every matching event
+func (c *Client) startPolicyEngine(ctx context.Context, subscription *subutils.Subscription, triggers []policyTrigger, installed []*policy) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	sub, err := c.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	engineCtx, cancel := context.WithCancel(context.Background())
+	engine := &policyEngine{
+		client:   c,
+		subID:    subID,
+		policies: installed,
+		cancel:   cancel,
+	}
+
+	c.mu.Lock()
+	prev := c.policyEngines[subID]
+	c.policyEngines[subID] = engine
+	c.mu.Unlock()
+	if prev != nil {
+		prev.stop()
+	}
+
+	actions := make([]policies.Action, 0, len(engine.policies))
+	for _, p := range engine.policies {
+		actions = append(actions, policies.Action{StyleType: p.styleType, ActionID: p.actionID})
+	}
+	err = c.ServiceModel.PolicyStore.Add(ctx, c.policyKey(subID), actions)
+	if err != nil {
+		c.stopPolicyEngine(subID)
+		return err
+	}
+
+	engine.applyAll(ctx)
+
+	go func() {
+		select {
+		case <-sub.E2Channel.Context().Done():
+			log.Debugf("E2 channel is closed for subscription: %v", subID)
+			c.stopPolicyEngine(subID)
+		case <-engineCtx.Done():
+		}
+	}()
+
+	for _, trigger := range triggers {
+		switch trigger {
+		case triggerOnA3, triggerOnUEChange:
+			ch := make(chan event.Event)
+			err = c.ServiceModel.UEs.Watch(engineCtx, ch)
+			if err != nil {
+				c.stopPolicyEngine(subID)
+				return err
+			}
+			go engine.processUEEvents(engineCtx, ch, trigger)
+		case triggerOnCellChange:
+			ch := make(chan event.Event)
+			err = c.ServiceModel.CellStore.Watch(engineCtx, ch)
+			if err != nil {
+				c.stopPolicyEngine(subID)
+				return err
+			}
+			go engine.processCellEvents(engineCtx, ch)
+		}
+	}
+	return nil
+}
+
+// stopPolicyEngine stops evaluating the policies of the given subscription and reverts their changes
+func (c *Client) stopPolicyEngine(subID subscriptions.ID) {
+	c.mu.Lock()
+	engine, ok := c.policyEngines[subID]
+	if ok {
+		delete(c.policyEngines, subID)
+	}
+	c.mu.Unlock()
+	if ok {
+		engine.stop()
+	}
+}
+
+// stop stops the engine and reverts the changes recorded in the policy ledger
+func (e *policyEngine) stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped {
+		return
+	}
+	e.stopped = true
+	e.cancel()
+	e.client.revertPolicy(context.Background(), e.subID)
+}
+
+func (e *policyEngine) processUEEvents(ctx context.Context, ch chan event.Event, trigger policyTrigger) {
+	for ueEvent := range ch {
+		if ctx.Err() != nil {
+			continue
+		}
+		if ueEvent.Type.(ues.UeEvent) != ues.Updated {
+			continue
+		}
+		ue := ueEvent.Value.(*model.UE)
+		if ue.Cell == nil || !e.client.isNodeCell(ue.Cell.NCGI) {
+			continue
+		}
+		sCell, err := e.client.ServiceModel.CellStore.Get(ctx, ue.Cell.NCGI)
+		if err != nil {
+			continue
+		}
+		pc := &policyContext{
+			ue:          ue,
+			servingCell: sCell,
+		}
+		if trigger == triggerOnA3 {
+			pc.targetCell = e.client.getA3TargetCell(ctx, ue, sCell)
+			if pc.targetCell == nil {
+				continue
+			}
+		} else if len(ue.Cells) > 0 {
+			pc.targetCell, _ = e.client.ServiceModel.CellStore.Get(ctx, ue.Cells[0].NCGI)
+		}
+		e.apply(ctx, pc)
+	}
+}
+
+func (e *policyEngine) processCellEvents(ctx context.Context, ch chan event.Event) {
+	for cellEvent := range ch {
+		if ctx.Err() != nil {
+			continue
+		}
+		if cellEvent.Type.(cells.CellEvent) != cells.Updated {
+			continue
+		}
+		cell := cellEvent.Value.(*model.Cell)
+		if !e.client.isNodeCell(cell.NCGI) {
+			continue
+		}
+		e.applyCell(ctx, cell)
+	}
+}
+
+// applyAll applies the policies to all cells of the node and their UEs
+func (e *policyEngine) applyAll(ctx context.Context) {
+	for _, ncgi := range e.client.ServiceModel.Node.Cells {
+		cell, err := e.client.ServiceModel.CellStore.Get(ctx, ncgi)
+		if err != nil {
+			log.Warnf("NCGI (%v) is not in cell store", ncgi)
+			continue
+		}
+		e.applyCell(ctx, cell)
+	}
+}
+
+// applyCell applies the policies to the given cell and its UEs
+func (e *policyEngine) applyCell(ctx context.Context, cell *model.Cell) {
+	for _, p := range e.policies {
+		if p.isUEPolicy() {
+			for _, ue := range e.client.ServiceModel.UEs.ListUEs(ctx, cell.NCGI) {
+				e.applyPolicy(ctx, p, &policyContext{ue: ue, servingCell: cell})
+			}
+			continue
+		}
+		pc := &policyContext{servingCell: cell}
+		if p.styleType == ricPolicyStyleType3 {
+			if ncgi, err := e.client.extractNCGIFromPrintableNCGI(p.action); err == nil {
+				pc.targetCell, _ = e.client.ServiceModel.CellStore.Get(ctx, ncgi)
+			}
+		}
+		e.applyPolicy(ctx, p, pc)
+	}
+}
+
+// apply applies every policy whose conditions are met in the given context
+func (e *policyEngine) apply(ctx context.Context, pc *policyContext) {
+	for _, p := range e.policies {
+		if p.isUEPolicy() && pc.ue == nil {
+			continue
+		}
+		e.applyPolicy(ctx, p, pc)
+	}
+}
+
+func (e *policyEngine) applyPolicy(ctx context.Context, p *policy, pc *policyContext) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped || !pc.evaluate(p.condition.GetValue()) {
+		return
+	}
+	var err error
+	switch p.styleType {
+	case ricPolicyStyleType1:
+		err = e.client.processDRBQoSLogic(ctx, e.subID, p.action, pc.ue)
+	case ricPolicyStyleType2:
+		err = e.client.processSlicePRBQuotaLogic(ctx, e.subID, p.action, pc.servingCell)
+	case ricPolicyStyleType3:
+		err = e.client.processMLBLogic(ctx, e.subID, p.action, []ransimtypes.NCGI{pc.servingCell.NCGI})
+	}
+	if err != nil {
+		log.Warnf("Failed to apply policy action %d of style %d for subscription %v: %v", p.actionID, p.styleType, e.subID, err)
+	}
+}
+
+// evaluate evaluates a list of RAN parameter tests; consecutive tests joined by logical OR form a
+// clause and all clauses must hold. An empty list always holds.
+func (pc *policyContext) evaluate(items []*e2smrcies.RanparameterTestingItem) bool {
+	result := true
+	clause := false
+	for i, item := range items {
+		clause = clause || pc.evaluateItem(item)
+		if isLogicalOr(item) && i < len(items)-1 {
+			continue
+		}
+		result = result && clause
+		clause = false
+	}
+	return result
+}
+
+func isLogicalOr(item *e2smrcies.RanparameterTestingItem) bool {
+	elementFalse := item.GetRanParameterType().GetRanPChoiceElementFalse()
+	return elementFalse != nil && elementFalse.LogicalOr != nil && *elementFalse.LogicalOr == e2smrcies.LogicalOr_LOGICAL_OR_TRUE
+}
+
+func (pc *policyContext) evaluateItem(item *e2smrcies.RanparameterTestingItem) bool {
+	ranParameterType := item.GetRanParameterType()
+	switch {
+	case ranParameterType.GetRanPChoiceStructure() != nil:
+		return pc.evaluate(ranParameterType.GetRanPChoiceStructure().GetRanParameterStructure().GetValue())
+	case ranParameterType.GetRanPChoiceList() != nil:
+		for _, listItem := range ranParameterType.GetRanPChoiceList().GetRanParameterList().GetValue() {
+			if pc.evaluateItem(listItem) {
+				return true
+			}
+		}
+		return false
+	case ranParameterType.GetRanPChoiceElementTrue() != nil:
+		value, ok := pc.lookup(item.GetRanParameterId().GetValue())
+		return ok && compareRanParameterValues(value, ranParameterType.GetRanPChoiceElementTrue().GetRanParameterValue(), e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_EQUAL)
+	case ranParameterType.GetRanPChoiceElementFalse() != nil:
+		elementFalse := ranParameterType.GetRanPChoiceElementFalse()
+		value, ok := pc.lookup(item.GetRanParameterId().GetValue())
+		testCondition := elementFalse.GetRanParameterTestCondition()
+		if _, isPresence := testCondition.GetRanparameterTestingCondition().(*e2smrcies.RanparameterTestingCondition_RanPChoicePresence); isPresence {
+			switch testCondition.GetRanPChoicePresence() {
+			case e2smrcies.RanPChoicePresence_RAN_P_CHOICE_PRESENCE_PRESENT, e2smrcies.RanPChoicePresence_RAN_P_CHOICE_PRESENCE_CONFIGURED:
+				return ok
+			case e2smrcies.RanPChoicePresence_RAN_P_CHOICE_PRESENCE_NONZERO:
+				return ok && !isZeroRanParameterValue(value)
+			}
+			return false
+		}
+		return ok && compareRanParameterValues(value, elementFalse.GetRanParameterValue(), testCondition.GetRanPChoiceComparison())
+	}
+	return false
+}
+
+// lookup resolves a RAN parameter against the UE and cell state of the context
+func (pc *policyContext) lookup(ranParameterID int64) (*e2smrcies.RanparameterValue, bool) {
+	switch ranParameterID {
+	case NRCGIRANParameterID:
+		if pc.targetCell != nil {
+			return printableRanParameterValue(fmt.Sprintf("%x", pc.targetCell.NCGI)), true
+		}
+	case CellSpecificOffsetRANParameterID:
+		if pc.servingCell != nil && pc.targetCell != nil {
+			if ocn, ok := pc.servingCell.MeasurementParams.NCellIndividualOffsets[pc.targetCell.NCGI]; ok {
+				return intRanParameterValue(int64(ocn)), true
+			}
+		}
+	case ServingCellNCGIRANParameterID:
+		if pc.servingCell != nil {
+			return printableRanParameterValue(fmt.Sprintf("%x", pc.servingCell.NCGI)), true
+		}
+	case ServingCellPCIRANParameterID:
+		if pc.servingCell != nil {
+			return intRanParameterValue(int64(pc.servingCell.PCI)), true
+		}
+	case ServingCellRrcConnectedUEsRANParameterID:
+		if pc.servingCell != nil {
+			return intRanParameterValue(int64(pc.servingCell.RrcConnectedCount)), true
+		}
+	case UERrcStateRANParameterID:
+		if pc.ue != nil {
+			return intRanParameterValue(int64(pc.ue.RrcState)), true
+		}
+	case FiveQIRANParameterID:
+		if pc.ue != nil {
+			return intRanParameterValue(int64(pc.ue.FiveQi)), true
+		}
+	case ServingCellRSRPRANParameterID:
+		if pc.ue != nil && pc.ue.Cell != nil {
+			return realRanParameterValue(pc.ue.Cell.Strength), true
+		}
+	case TargetCellRSRPRANParameterID:
+		if pc.ue != nil && pc.targetCell != nil {
+			for _, ueCell := range pc.ue.Cells {
+				if ueCell.NCGI == pc.targetCell.NCGI {
+					return realRanParameterValue(ueCell.Strength), true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func intRanParameterValue(value int64) *e2smrcies.RanparameterValue {
+	return &e2smrcies.RanparameterValue{
+		RanparameterValue: &e2smrcies.RanparameterValue_ValueInt{ValueInt: value},
+	}
+}
+
+func realRanParameterValue(value float64) *e2smrcies.RanparameterValue {
+	return &e2smrcies.RanparameterValue{
+		RanparameterValue: &e2smrcies.RanparameterValue_ValueReal{ValueReal: float32(value)},
+	}
+}
+
+func printableRanParameterValue(value string) *e2smrcies.RanparameterValue {
+	return &e2smrcies.RanparameterValue{
+		RanparameterValue: &e2smrcies.RanparameterValue_ValuePrintableString{ValuePrintableString: value},
+	}
+}
+
+func isZeroRanParameterValue(value *e2smrcies.RanparameterValue) bool {
+	switch v := value.GetRanparameterValue().(type) {
+	case *e2smrcies.RanparameterValue_ValueInt:
+		return v.ValueInt == 0
+	case *e2smrcies.RanparameterValue_ValueReal:
+		return v.ValueReal == 0
+	case *e2smrcies.RanparameterValue_ValueBoolean:
+		return !v.ValueBoolean
+	case *e2smrcies.RanparameterValue_ValuePrintableString:
+		return v.ValuePrintableString == ""
+	case *e2smrcies.RanparameterValue_ValueOctS:
+		return len(v.ValueOctS) == 0
+	}
+	return true
+}
+
+// compareRanParameterValues compares the actual value of a RAN parameter with the value of a policy condition
+func compareRanParameterValues(actual *e2smrcies.RanparameterValue, expected *e2smrcies.RanparameterValue, comparison e2smrcies.RanPChoiceComparison) bool {
+	if expected == nil {
+		return false
+	}
+	if actualNumber, ok := numericRanParameterValue(actual); ok {
+		expectedNumber, ok := numericRanParameterValue(expected)
+		if !ok {
+			return false
+		}
+		switch comparison {
+		case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_EQUAL:
+			return actualNumber == expectedNumber
+		case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_DIFFERENCE:
+			return actualNumber != expectedNumber
+		case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_GREATERTHAN:
+			return actualNumber > expectedNumber
+		case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_LESSTHAN:
+			return actualNumber < expectedNumber
+		}
+		return false
+	}
+
+	actualString := stringRanParameterValue(actual)
+	expectedString := stringRanParameterValue(expected)
+	switch comparison {
+	case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_EQUAL:
+		return strings.EqualFold(actualString, expectedString)
+	case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_DIFFERENCE:
+		return !strings.EqualFold(actualString, expectedString)
+	case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_CONTAINS:
+		return strings.Contains(strings.ToLower(actualString), strings.ToLower(expectedString))
+	case e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_STARTS_WITH:
+		return strings.HasPrefix(strings.ToLower(actualString), strings.ToLower(expectedString))
+	}
+	return false
+}
+
+func numericRanParameterValue(value *e2smrcies.RanparameterValue) (float64, bool) {
+	switch v := value.GetRanparameterValue().(type) {
+	case *e2smrcies.RanparameterValue_ValueInt:
+		return float64(v.ValueInt), true
+	case *e2smrcies.RanparameterValue_ValueReal:
+		return float64(v.ValueReal), true
+	}
+	return 0, false
+}
+
+func stringRanParameterValue(value *e2smrcies.RanparameterValue) string {
+	switch v := value.GetRanparameterValue().(type) {
+	case *e2smrcies.RanparameterValue_ValuePrintableString:
+		return v.ValuePrintableString
+	case *e2smrcies.RanparameterValue_ValueOctS:
+		return fmt.Sprintf("%x", v.ValueOctS)
+	case *e2smrcies.RanparameterValue_ValueBitS:
+		return fmt.Sprintf("%x", v.ValueBitS.GetValue())
+	case *e2smrcies.RanparameterValue_ValueBoolean:
+		return fmt.Sprintf("%t", v.ValueBoolean)
+	}
+	return ""
+}
+
+// getA3TargetCell returns the strongest neighbor cell which satisfies the A3 entering condition for the given UE
+func (c *Client) getA3TargetCell(ctx context.Context, ue *model.UE, sCell *model.Cell) *model.Cell {
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		return nil
+	}
+	params := sCell.MeasurementParams
+	threshold := ue.Cell.Strength + float64(params.PCellIndividualOffset) + float64(params.EventA3Params.A3Offset)
+	var target *model.Cell
+	var targetStrength float64
+	for _, ueCell := range ue.Cells {
+		ocn := params.NCellIndividualOffsets[ueCell.NCGI]
+		strength := ueCell.Strength + float64(ocn)
+		if strength-float64(params.Hysteresis) <= threshold || (target != nil && strength <= targetStrength) {
+			continue
+		}
+		tCell, err := c.ServiceModel.CellStore.Get(ctx, ueCell.NCGI)
+		if err != nil {
+			continue
+		}
+		target = tCell
+		targetStrength = strength
+	}
+	return target
+}
+
+func (c *Client) isNodeCell(ncgi ransimtypes.NCGI) bool {
+	for _, id := range c.ServiceModel.Node.Cells {
+		if id == ncgi {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	e2smrcies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc/v1/e2sm-rc-ies"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newComparisonItem(id int64, comparison e2smrcies.RanPChoiceComparison, value *e2smrcies.RanparameterValue, logicalOr bool) *e2smrcies.RanparameterTestingItem {
+	elementFalse := &e2smrcies.RanparameterTestingItemChoiceElementFalse{
+		RanParameterTestCondition: &e2smrcies.RanparameterTestingCondition{
+			RanparameterTestingCondition: &e2smrcies.RanparameterTestingCondition_RanPChoiceComparison{
+				RanPChoiceComparison: comparison,
+			},
+		},
+		RanParameterValue: value,
+	}
+	if logicalOr {
+		or := e2smrcies.LogicalOr_LOGICAL_OR_TRUE
+		elementFalse.LogicalOr = &or
+	}
+	return &e2smrcies.RanparameterTestingItem{
+		RanParameterId: &e2smrcies.RanparameterId{Value: id},
+		RanParameterType: &e2smrcies.RanParameterType{
+			RanParameterType: &e2smrcies.RanParameterType_RanPChoiceElementFalse{
+				RanPChoiceElementFalse: elementFalse,
+			},
+		},
+	}
+}
+
+func TestPolicyConditions(t *testing.T) {
+	pc := &policyContext{
+		ue: &model.UE{
+			RrcState: e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED,
+			FiveQi:   9,
+			Cell:     &model.UECell{NCGI: 0x1, Strength: -90},
+		},
+		servingCell: &model.Cell{NCGI: 0x1, PCI: 42, RrcConnectedCount: 10},
+		targetCell:  &model.Cell{NCGI: 0x2},
+	}
+
+	// an empty condition always holds
+	assert.True(t, pc.evaluate(nil))
+
+	greaterThan := e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_GREATERTHAN
+	equal := e2smrcies.RanPChoiceComparison_RAN_P_CHOICE_COMPARISON_EQUAL
+
+	assert.True(t, pc.evaluate([]*e2smrcies.RanparameterTestingItem{
+		newComparisonItem(ServingCellRrcConnectedUEsRANParameterID, greaterThan, intRanParameterValue(5), false),
+		newComparisonItem(NRCGIRANParameterID, equal, printableRanParameterValue("2"), false),
+	}))
+
+	assert.False(t, pc.evaluate([]*e2smrcies.RanparameterTestingItem{
+		newComparisonItem(ServingCellRrcConnectedUEsRANParameterID, greaterThan, intRanParameterValue(5), false),
+		newComparisonItem(FiveQIRANParameterID, equal, intRanParameterValue(7), false),
+	}))
+
+	// tests joined by logical OR hold if any of them holds
+	assert.True(t, pc.evaluate([]*e2smrcies.RanparameterTestingItem{
+		newComparisonItem(FiveQIRANParameterID, equal, intRanParameterValue(7), true),
+		newComparisonItem(FiveQIRANParameterID, equal, intRanParameterValue(9), false),
+		newComparisonItem(ServingCellPCIRANParameterID, equal, intRanParameterValue(42), false),
+	}))
+
+	// parameters which cannot be resolved in the context never match
+	cellContext := &policyContext{servingCell: pc.servingCell}
+	assert.False(t, cellContext.evaluate([]*e2smrcies.RanparameterTestingItem{
+		newComparisonItem(FiveQIRANParameterID, equal, intRanParameterValue(9), false),
+	}))
+}
+
+func TestA3TargetCell(t *testing.T) {
+	cellStore := cells.NewCellRegistry(map[string]model.Cell{
+		"cell1": {NCGI: 0x1},
+		"cell2": {NCGI: 0x2},
+		"cell3": {NCGI: 0x3},
+		"cell4": {NCGI: 0x4},
+	}, nodes.NewNodeRegistry(map[string]model.Node{}))
+	c := &Client{ServiceModel: &registry.ServiceModel{CellStore: cellStore}}
+	sCell := &model.Cell{
+		NCGI: 0x1,
+		MeasurementParams: model.MeasurementParams{
+			Hysteresis:             1,
+			NCellIndividualOffsets: map[types.NCGI]int32{0x4: -10},
+		},
+	}
+	ue := &model.UE{
+		RrcState: e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED,
+		Cell:     &model.UECell{NCGI: 0x1, Strength: -90},
+		Cells: []*model.UECell{
+			{NCGI: 0x2, Strength: -85},
+			{NCGI: 0x3, Strength: -80},
+			{NCGI: 0x4, Strength: -75},
+		},
+	}
+
+	// the strongest neighbor after its offset is picked, not the first one satisfying the entering condition
+	tCell := c.getA3TargetCell(context.Background(), ue, sCell)
+	assert.NotNil(t, tCell)
+	assert.Equal(t, types.NCGI(0x3), tCell.NCGI)
+
+	// no neighbor satisfies the entering condition
+	ue.Cell.Strength = -70
+	assert.Nil(t, c.getA3TargetCell(context.Background(), ue, sCell))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
@@ -35,6 +36,7 @@ import (
 	"github.com/onosproject/rrm-son-lib/pkg/handover"
 	"github.com/onosproject/rrm-son-lib/pkg/model/id"
 	meastype "github.com/onosproject/rrm-son-lib/pkg/model/measurement/type"
+	"sync"
 )
 
 var _ servicemodel.Client = &Client{}
@@ -45,13 +47,19 @@ var log = logging.GetLogger()
 type Client struct {
 	ServiceModel   *registry.ServiceModel
 	mobilityDriver mobility.Driver
+	policyEngines  map[subscriptions.ID]*policyEngine
+	// a3Inserts cancels the insert services of the subscriptions for the A3 measurement reports
+	a3Inserts map[subscriptions.ID]context.CancelFunc
+	// reports cancels the reports of the subscriptions for the E2 node information changes
+	reports map[subscriptions.ID]context.CancelFunc
+	mu      sync.Mutex
 }
 
 // NewServiceModel creates a new service model
 func NewServiceModel(node model.Node, model *model.Model,
 	subStore *subscriptions.Subscriptions, nodeStore nodes.Store,
-	ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store,
-	a3Chan chan handover.A3HandoverDecision, mobilityDriver mobility.Driver) (registry.ServiceModel, error) {
+	ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store, policyStore policies.Store,
+	mobilityDriver mobility.Driver) (registry.ServiceModel, error) {
 	var rcsm e2smrc.RCServiceModel
 	modelName := e2smtypes.ShortName(modelFullName)
 	rcSm := registry.ServiceModel{
@@ -67,12 +75,15 @@ func NewServiceModel(node model.Node, model *model.Model,
 		UEs:           ueStore,
 		CellStore:     cellStore,
 		MetricStore:   metricStore,
-		A3Chan:        a3Chan,
+		PolicyStore:   policyStore,
 	}
 
 	rcClient := &Client{
 		ServiceModel:   &rcSm,
 		mobilityDriver: mobilityDriver,
+		policyEngines:  make(map[subscriptions.ID]*policyEngine),
+		a3Inserts:      make(map[subscriptions.ID]context.CancelFunc),
+		reports:        make(map[subscriptions.ID]context.CancelFunc),
 	}
 
 	rcSm.Client = rcClient
@@ -100,11 +111,18 @@ func NewServiceModel(node model.Node, model *model.Model,
 		return registry.ServiceModel{}, err
 	}
 
+	// Event trigger style 4: UE Information Change
+	ricEventTriggerStyle4, err := pdubuilder.CreateRanfunctionDefinitionEventTriggerStyleItem(4, eventTriggerStyle4, 4)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+
 	// Create event trigger style list
 	ricEventTriggerStyleList := make([]*e2smrcies.RanfunctionDefinitionEventTriggerStyleItem, 0)
 	ricEventTriggerStyleList = append(ricEventTriggerStyleList, ricEventTriggerStyle1)
 	ricEventTriggerStyleList = append(ricEventTriggerStyleList, ricEventTriggerStyle2)
 	ricEventTriggerStyleList = append(ricEventTriggerStyleList, ricEventTriggerStyle3)
+	ricEventTriggerStyleList = append(ricEventTriggerStyleList, ricEventTriggerStyle4)
 	ranFunctionDefinitionEventTrigger, err := pdubuilder.CreateRanfunctionDefinitionEventTrigger(ricEventTriggerStyleList)
 	if err != nil {
 		return registry.ServiceModel{}, err
@@ -229,6 +247,11 @@ func NewServiceModel(node model.Node, model *model.Model,
 	policyConditionRANParameterList := make([]*e2smrcies.PolicyConditionRanparameterItem, 0)
 	policyConditionRANParameterList = append(policyConditionRANParameterList, policyConditionRANParameterItem1)
 	policyConditionRANParameterList = append(policyConditionRANParameterList, policyConditionRANParameterItem2)
+	policyConditionRANParametersStateList, err := createPolicyConditionRANParametersList()
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	policyConditionRANParameterList = append(policyConditionRANParameterList, policyConditionRANParametersStateList...)
 
 	ranFunctionDefinitionPolicyActionItem.SetRanPolicyActionParametersList(policyActionRANParameterList)
 	ranFunctionDefinitionPolicyActionItem.SetRanPolicyConditionParametersList(policyConditionRANParameterList)
@@ -239,6 +262,19 @@ func NewServiceModel(node model.Node, model *model.Model,
 	ranFunctionDefinitionPolicyItem.SetRicPolicyActionList(ranFunctionDefinitionPolicyActionList)
 
 	ranFunctionDefinitionPolicyList = append(ranFunctionDefinitionPolicyList, ranFunctionDefinitionPolicyItem)
+
+	// Policy style 1: Radio Bearer Control
+	ranFunctionDefinitionPolicyItem1, err := createRANFunctionDefinitionPolicyStyle1Item()
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	// Policy style 2: Radio Resource Allocation Control
+	ranFunctionDefinitionPolicyItem2, err := createRANFunctionDefinitionPolicyStyle2Item()
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	ranFunctionDefinitionPolicyList = append(ranFunctionDefinitionPolicyList, ranFunctionDefinitionPolicyItem1)
+	ranFunctionDefinitionPolicyList = append(ranFunctionDefinitionPolicyList, ranFunctionDefinitionPolicyItem2)
 	ranFunctionDefinitionPolicy, err := pdubuilder.CreateRanfunctionDefinitionPolicy(ranFunctionDefinitionPolicyList)
 	if err != nil {
 		return registry.ServiceModel{}, err
@@ -673,79 +709,148 @@ func (c *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducont
 	if sub.Ticker != nil {
 		sub.Ticker.Stop()
 	}
+	// Stops applying the policies installed by the subscription and reverts their changes
+	c.stopPolicyEngine(subID)
+	// Stops the insert service for the A3 measurement reports
+	c.stopA3Insert(subID)
+	// Stops the reports of the E2 node information changes
+	c.stopReport(subID)
 	return subDeleteResponse, nil, nil
 }
 
-func (c *Client) processMLBLogic(ctx context.Context, adFormat2List []*e2smrcies.E2SmRcActionDefinitionFormat2Item) error {
-	for _, pc := range adFormat2List {
-		ncgi, err := c.extractNCGIFromPrintableNCGI(pc.GetRicPolicyAction())
+func (c *Client) processMLBLogic(ctx context.Context, subID subscriptions.ID, policyAction *e2smrcies.RicPolicyAction, cellIDs []ransimtypes.NCGI) error {
+	ncgi, err := c.extractNCGIFromPrintableNCGI(policyAction)
+	if err != nil {
+		return err
+	}
+	ocnInt, err := c.extractOcn(policyAction)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	ocn := meastype.QOffsetRange(ocnInt)
+	ocnValue := int32(ocn.GetValue().(int))
+
+	for _, id := range cellIDs {
+		// id: serving cell ID
+		log.Debugf("MLB: sCell NCGI: %v / NCGI: %v / Ocn: %d", id, ncgi, ocnInt)
+
+		sCell, err := c.ServiceModel.CellStore.Get(ctx, id)
 		if err != nil {
-			return err
+			log.Errorf("NCGI (%v) is not in cell store", id)
+			continue
+		}
+		currentOcn, ok := sCell.MeasurementParams.NCellIndividualOffsets[ncgi]
+		if !ok {
+			log.Debugf("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", ncgi, id)
+			continue
+		}
+		if currentOcn == ocnValue {
+			continue
 		}
-		ocnInt, err := c.extractOcn(pc.GetRicPolicyAction())
+		log.Infof("Cell (%v) Ocn in the cell (%v) is set from %v to %v", ncgi, id, currentOcn, ocnValue)
+		sCell.MeasurementParams.NCellIndividualOffsets[ncgi] = ocnValue
+		err = c.ServiceModel.CellStore.Update(ctx, sCell)
 		if err != nil {
-			log.Error(err)
 			return err
 		}
+		c.recordPolicyChange(ctx, subID, policies.Change{
+			Action:    policies.Action{StyleType: ricPolicyStyleType3, ActionID: ricPolicyActionIDForMLB},
+			Parameter: policies.CellIndividualOffset,
+			Target:    uint64(id),
+			Key:       uint64(ncgi),
+			Original:  currentOcn,
+			Applied:   ocnValue,
+		})
+	}
+	return nil
+}
 
-		ocn := meastype.QOffsetRange(ocnInt)
-
-		for _, id := range c.ServiceModel.Node.Cells {
-			// id: serving cell ID
-			log.Debugf("MLB: sCell NCGI: %v / NCGI: %v / Ocn: %d", id, ncgi, ocnInt)
-
-			sCell, err := c.ServiceModel.CellStore.Get(ctx, id)
-			if err != nil {
-				log.Errorf("NCGI (%v) is not in cell store", id)
-				continue
-			}
-			if _, ok := sCell.MeasurementParams.NCellIndividualOffsets[ncgi]; !ok {
-				log.Errorf("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", ncgi, id)
-				continue
-			}
-			log.Infof("Cell (%v) Ocn in the cell (%v) is set from %v to %v", ncgi, id, sCell.MeasurementParams.NCellIndividualOffsets[ncgi], ocn.GetValue().(int))
-			sCell.MeasurementParams.NCellIndividualOffsets[ncgi] = int32(ocn.GetValue().(int))
-		}
+// processDRBQoSLogic applies the 5QI requested by a radio bearer control policy to the UE
+func (c *Client) processDRBQoSLogic(ctx context.Context, subID subscriptions.ID, policyAction *e2smrcies.RicPolicyAction, ue *model.UE) error {
+	fiveQI, err := extractPolicyActionRanParameterInt(policyAction, FiveQIRANParameterID)
+	if err != nil {
+		return err
+	}
+	if ue.FiveQi == int(fiveQI) {
+		return nil
+	}
+	log.Infof("5QI of UE %v is set from %v to %v", ue.IMSI, ue.FiveQi, fiveQI)
+	original := ue.FiveQi
+	err = c.ServiceModel.UEs.UpdateUE(ctx, ue.IMSI, int(fiveQI), true)
+	if err != nil {
+		return err
 	}
+	c.recordPolicyChange(ctx, subID, policies.Change{
+		Action:    policies.Action{StyleType: ricPolicyStyleType1, ActionID: ricPolicyActionIDForDRBQoS},
+		Parameter: policies.FiveQI,
+		Target:    uint64(ue.IMSI),
+		Original:  original,
+		Applied:   int(fiveQI),
+	})
 	return nil
 }
 
-func (c *Client) processPolicyAction(ctx context.Context, subscription *subutils.Subscription, eventTriggers *e2smrcies.E2SmRcEventTrigger, actionDefinitionsMaps map[*e2aptypes.RicActionID]*e2smrcies.E2SmRcActionDefinition) error {
-	eventTriggerFormats := eventTriggers.GetRicEventTriggerFormats()
-	switch eventTrigger := eventTriggerFormats.RicEventTriggerFormats.(type) {
-	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat1:
-		// Process RIC Event trigger definition IE style 1: Message Event
-		messageList := eventTrigger.EventTriggerFormat1.GetMessageList()
-		for _, m := range messageList {
-			ueEventList := m.GetAssociatedUeevent().GetUeEventList()
-			for _, e := range ueEventList {
-				if e.GetUeEventId().Value == A3MeasurementReportUEEventID {
-					log.Debugf("Processing event trigger format 1: Message Event - A3 measurement report received (UE Event ID: %d)", A3MeasurementReportUEEventID)
-					log.Debugf("Action definition maps: %+v", actionDefinitionsMaps)
-					for _, ad := range actionDefinitionsMaps {
-						if adFormat2 := ad.GetRicActionDefinitionFormats().GetActionDefinitionFormat2(); adFormat2 != nil {
-							err := c.processMLBLogic(ctx, adFormat2.GetRicPolicyConditionsList())
-							if err != nil {
-								log.Warn(err)
-							}
-						}
-					}
+// processSlicePRBQuotaLogic applies the slice PRB quotas requested by a radio resource allocation policy to the cell
+func (c *Client) processSlicePRBQuotaLogic(ctx context.Context, subID subscriptions.ID, policyAction *e2smrcies.RicPolicyAction, cell *model.Cell) error {
+	ratios, err := extractRrmPolicyRatios(policyAction)
+	if err != nil {
+		return err
+	}
+	changes := make([]policies.Change, 0)
+	for _, ratio := range ratios {
+		change := policies.Change{
+			Action:    policies.Action{StyleType: ricPolicyStyleType2, ActionID: ricPolicyActionIDForSlicePRBQuota},
+			Parameter: policies.RrmPolicyRatio,
+			Target:    uint64(cell.NCGI),
+			Key:       sliceKey(ratio.Sst, ratio.Sd),
+			Applied:   ratio,
+		}
+		found := false
+		for i, current := range cell.RrmPolicyRatios {
+			if current.Sst == ratio.Sst && current.Sd == ratio.Sd {
+				found = true
+				if current != ratio {
+					cell.RrmPolicyRatios[i] = ratio
+					change.Original = current
+					changes = append(changes, change)
 				}
+				break
 			}
 		}
-	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat2:
-		// TODO Process RIC Event trigger definition IE style 2: Call Process Breakpoint
-	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat3:
-		// TODO Process RIC Event trigger definition IE style 3
-	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat4:
-		// TODO Process RIC Event trigger definition IE style 4
-	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat5:
-		// TODO Process RIC Event trigger definition IE style 5
-
+		if !found {
+			cell.RrmPolicyRatios = append(cell.RrmPolicyRatios, ratio)
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	log.Infof("RRM policy ratios of the cell (%v) are set to %+v", cell.NCGI, cell.RrmPolicyRatios)
+	err = c.ServiceModel.CellStore.Update(ctx, cell)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		c.recordPolicyChange(ctx, subID, change)
 	}
 	return nil
 }
 
+func (c *Client) processPolicyAction(ctx context.Context, subscription *subutils.Subscription, eventTriggers *e2smrcies.E2SmRcEventTrigger, actionDefinitionsMaps map[*e2aptypes.RicActionID]*e2smrcies.E2SmRcActionDefinition) error {
+	triggers, err := getPolicyTriggers(eventTriggers)
+	if err != nil {
+		return err
+	}
+	policies, err := newPolicies(actionDefinitionsMaps)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Installing %d policies for e2 node %v", len(policies), c.ServiceModel.Node.GnbID)
+	return c.startPolicyEngine(ctx, subscription, triggers, policies)
+}
+
 func (c *Client) processInsertAction(ctx context.Context, subscription *subutils.Subscription, eventTriggers *e2smrcies.E2SmRcEventTrigger) error {
 	eventTriggerFormats := eventTriggers.GetRicEventTriggerFormats()
 	switch eventTrigger := eventTriggerFormats.RicEventTriggerFormats.(type) {
@@ -759,8 +864,14 @@ func (c *Client) processInsertAction(ctx context.Context, subscription *subutils
 		// handover
 		if callProcessTypeID == CallProcessTypeIDMobilityManagement && callBreakPointID == CallBreakpointIDHandoverPreparation {
 			log.Debug("Processing event trigger format 2: Call Process Breakpoint - Mobility Management / Handover Preparation")
+			subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+			insertCtx, cancel := context.WithCancel(context.Background())
+			c.mu.Lock()
+			c.a3Inserts[subID] = cancel
+			c.mu.Unlock()
 			go func() {
-				err := c.insertOnA3MeasurementReceived(ctx, subscription)
+				defer c.stopA3Insert(subID)
+				err := c.insertOnA3MeasurementReceived(insertCtx, subscription)
 				if err != nil {
 					log.Warn(err)
 					// TODO we should propagate this error back
@@ -790,12 +901,14 @@ func (c *Client) processReportAction(ctx context.Context, subscription *subutils
 	case *e2smrcies.RicEventTriggerFormats_EventTriggerFormat3:
 		// Process RIC Event trigger definition IE style 3: E2 Node Information Change
 		e2NodeInfoChangeList := eventTrigger.EventTriggerFormat3.GetE2NodeInfoChangeList()
+		subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+		reportCtx := c.startReport(subID)
 		for _, e2NodeChange := range e2NodeInfoChangeList {
 			e2NodeInfoChangeID := e2NodeChange.E2NodeInfoChangeId
-			if e2NodeInfoChangeID == 1 {
+			if e2NodeInfoChangeID == CellConfigurationChangeID {
 				log.Debugf("Processing event trigger format 3: cell configuration change for e2 Node %v", c.ServiceModel.Node.GnbID)
 				go func(e *e2smrcies.E2SmRcEventTriggerFormat3Item) {
-					err := c.reportOnCellConfigurationChange(ctx, subscription, e)
+					err := c.reportOnCellConfigurationChange(reportCtx, subscription, e)
 					if err != nil {
 						log.Warn(err)
 						// TODO we should propagate this error back
@@ -803,10 +916,10 @@ func (c *Client) processReportAction(ctx context.Context, subscription *subutils
 					}
 				}(e2NodeChange)
 
-			} else if e2NodeInfoChangeID == 2 {
+			} else if e2NodeInfoChangeID == CellNeighborRelationChangeID {
 				log.Debug("Processing event trigger format 3: cell neighbor relation change for e2 node %v", c.ServiceModel.Node.GnbID)
 				go func(e *e2smrcies.E2SmRcEventTriggerFormat3Item) {
-					err := c.reportOnCellNeighborRelationChange(ctx, subscription, e)
+					err := c.reportOnCellNeighborRelationChange(reportCtx, subscription, e)
 					if err != nil {
 						log.Warn(err)
 						// TODO we should propagate this error back
@@ -842,7 +955,7 @@ func (c *Client) reportOnCellConfigurationChange(ctx context.Context, subscripti
 		cellList = node.Cells
 	} // TODO else create a list of cells based on cell info list to report cell changes just for those requested cells
 	cellEventCh := make(chan event.Event)
-	err = c.ServiceModel.CellStore.Watch(context.Background(), cellEventCh)
+	err = c.ServiceModel.CellStore.Watch(ctx, cellEventCh)
 	if err != nil {
 		return err
 	}
@@ -855,7 +968,10 @@ func (c *Client) reportOnCellConfigurationChange(ctx context.Context, subscripti
 
 	for {
 		select {
-		case cellEvent := <-cellEventCh:
+		case cellEvent, ok := <-cellEventCh:
+			if !ok {
+				return nil
+			}
 			log.Debugf("A Cell change event is occurred %v", cellEvent)
 			cellEventType := cellEvent.Type.(cells.CellEvent)
 			if cellEventType == cells.Updated {
@@ -869,7 +985,8 @@ func (c *Client) reportOnCellConfigurationChange(ctx context.Context, subscripti
 		case <-sub.E2Channel.Context().Done():
 			log.Debugf("E2 channel is closed for subscription: %v", subID)
 			return nil
-
+		case <-ctx.Done():
+			return nil
 		}
 	}
 }
@@ -888,7 +1005,7 @@ func (c *Client) reportOnCellNeighborRelationChange(ctx context.Context, subscri
 		cellList = node.Cells
 	} // TODO else create a list of cells based on cell info list to report cell changes just for those requested cells
 	cellEventCh := make(chan event.Event)
-	err = c.ServiceModel.CellStore.Watch(context.Background(), cellEventCh)
+	err = c.ServiceModel.CellStore.Watch(ctx, cellEventCh)
 	if err != nil {
 		return err
 	}
@@ -901,7 +1018,10 @@ func (c *Client) reportOnCellNeighborRelationChange(ctx context.Context, subscri
 
 	for {
 		select {
-		case cellEvent := <-cellEventCh:
+		case cellEvent, ok := <-cellEventCh:
+			if !ok {
+				return nil
+			}
 			log.Debugf("A Cell change event is occurred %v", cellEvent)
 			cellEventType := cellEvent.Type.(cells.CellEvent)
 			if cellEventType == cells.UpdatedNeighbors {
@@ -915,7 +1035,8 @@ func (c *Client) reportOnCellNeighborRelationChange(ctx context.Context, subscri
 		case <-sub.E2Channel.Context().Done():
 			log.Debugf("E2 channel is closed for subscription: %v", subID)
 			return nil
-
+		case <-ctx.Done():
+			return nil
 		}
 	}
 }
@@ -955,12 +1076,23 @@ func (c *Client) insertOnA3MeasurementReceived(ctx context.Context, subscription
 		return err
 	}
 
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ch := make(chan handover.A3HandoverDecision)
+	if err := c.mobilityDriver.GetHoCtrl().Watch(watchCtx, ch, c.ServiceModel.Node.Cells...); err != nil {
+		return err
+	}
+
 	for {
 		select {
 		case <-sub.E2Channel.Context().Done():
 			log.Debugf("E2 channel is closed for subscription: %v", subID)
 			return nil
-		case report := <-c.ServiceModel.A3Chan:
+		case report, ok := <-ch:
+			if !ok {
+				log.Debugf("Insert service is stopped for subscription: %v", subID)
+				return nil
+			}
 			log.Debugf("received event a3 measurement report: %v", report)
 			log.Debugf("Send upon-rcv-meas-report indication for cell ecgi:%d, IMSI:%s",
 				report.UE.GetSCell().GetID().GetID().(id.ECGI), report.UE.GetID().String())
@@ -979,26 +1111,26 @@ func (c *Client) insertOnA3MeasurementReceived(ctx context.Context, subscription
 						AmfUeNgapId: &e2smcommonies.AmfUeNgapId{
 							Value: int64(ue.AmfUeNgapID),
 						},
-						// ToDo - move out GUAMI hardcoding
+						// GUAMI assigned by the AMF when the UE registered
 						Guami: &e2smcommonies.Guami{
 							PLmnidentity: &e2smcommonies.Plmnidentity{
 								Value: c.getPlmnID().ToBytes(),
 							},
 							AMfregionId: &e2smcommonies.AmfregionId{
 								Value: &asn1.BitString{
-									Value: []byte{0xDD},
+									Value: []byte{byte(ue.Guami.AmfRegionID)},
 									Len:   8,
 								},
 							},
 							AMfsetId: &e2smcommonies.AmfsetId{
 								Value: &asn1.BitString{
-									Value: []byte{0xCC, 0xC0},
+									Value: []byte{byte(ue.Guami.AmfSetID >> 2), byte(ue.Guami.AmfSetID << 6)},
 									Len:   10,
 								},
 							},
 							AMfpointer: &e2smcommonies.Amfpointer{
 								Value: &asn1.BitString{
-									Value: []byte{0xFC},
+									Value: []byte{byte(ue.Guami.AmfPointer << 2)},
 									Len:   6,
 								},
 							},
@@ -1033,6 +1165,34 @@ func (c *Client) insertOnA3MeasurementReceived(ctx context.Context, subscription
 	}
 }
 
+// startReport returns the context of the reports of the subscription; the context is done once the subscription
+// is deleted
+func (c *Client) startReport(subID subscriptions.ID) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.reports[subID] = cancel
+	c.mu.Unlock()
+	return ctx
+}
+
+func (c *Client) stopReport(subID subscriptions.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.reports[subID]; ok {
+		cancel()
+		delete(c.reports, subID)
+	}
+}
+
+func (c *Client) stopA3Insert(subID subscriptions.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.a3Inserts[subID]; ok {
+		cancel()
+		delete(c.a3Inserts, subID)
+	}
+}
+
 func (c *Client) sendRICIndicationFormat5Header2(ctx context.Context, subscription *subutils.Subscription, ueID *e2smcommonies.Ueid, ricInsertStyleType int32, insertIndicationID int32, targetNCGI ransimtypes.NCGI) error {
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
 	sub, err := c.ServiceModel.Subscriptions.Get(subID)
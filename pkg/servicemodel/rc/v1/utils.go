@@ -570,7 +570,9 @@ func (c *Client) runHandover(ctx context.Context, controlHeader *e2smrcies.E2SmR
 				ID:   ransimtypes.GnbID(ncgi),
 				NCGI: ncgi,
 			}
-			c.mobilityDriver.Handover(ctx, ue.IMSI, tCell)
+			if err := c.mobilityDriver.Handover(ctx, ue.IMSI, tCell); err != nil {
+				log.Warn(err)
+			}
 		}
 	}
 	return nil
@@ -676,3 +678,188 @@ func (c *Client) checkAndSetPCI(ctx context.Context, controlMessage *e2smrcies.E
 	}
 	return nil
 }
+
+func createPolicyConditionRANParametersList() ([]*e2smrcies.PolicyConditionRanparameterItem, error) {
+	// RAN Parameters that can be tested in policy conditions
+	policyConditionRANParametersList := make([]*e2smrcies.PolicyConditionRanparameterItem, 0)
+	ranParameters := []struct {
+		id   int64
+		name string
+	}{
+		{ServingCellNCGIRANParameterID, "Serving Cell NCGI"},
+		{ServingCellPCIRANParameterID, "Serving Cell PCI"},
+		{ServingCellRrcConnectedUEsRANParameterID, "Serving Cell RRC Connected UEs"},
+		{UERrcStateRANParameterID, "UE RRC State"},
+		{FiveQIRANParameterID, "5QI"},
+		{ServingCellRSRPRANParameterID, "Serving Cell RSRP"},
+		{TargetCellRSRPRANParameterID, "Target Cell RSRP"},
+	}
+	for _, rp := range ranParameters {
+		ranParameter, err := pdubuilder.CreatePolicyConditionRanparameterItem(rp.id, rp.name)
+		if err != nil {
+			return nil, err
+		}
+		policyConditionRANParametersList = append(policyConditionRANParametersList, ranParameter)
+	}
+	return policyConditionRANParametersList, nil
+}
+
+func createRANFunctionDefinitionPolicyStyle1Item() (*e2smrcies.RanfunctionDefinitionPolicyItem, error) {
+	// Policy style 1 is evaluated upon UE information change
+	policyItem, err := pdubuilder.CreateRanfunctionDefinitionPolicyItem(ricPolicyStyleType1, ricPolicyStyleName1, 4)
+	if err != nil {
+		return nil, err
+	}
+	policyActionItem, err := pdubuilder.CreateRanfunctionDefinitionPolicyActionItem(ricPolicyActionIDForDRBQoS, ricPolicyActionNameForDRBQoS, ricActionDefinitionFormatTypeForPolicy)
+	if err != nil {
+		return nil, err
+	}
+	ranParameter1, err := pdubuilder.CreatePolicyActionRanparameterItem(FiveQIRANParameterID, "5QI")
+	if err != nil {
+		return nil, err
+	}
+	policyConditionRANParametersList, err := createPolicyConditionRANParametersList()
+	if err != nil {
+		return nil, err
+	}
+	policyActionItem.SetRanPolicyActionParametersList([]*e2smrcies.PolicyActionRanparameterItem{ranParameter1})
+	policyActionItem.SetRanPolicyConditionParametersList(policyConditionRANParametersList)
+	policyItem.SetRicPolicyActionList([]*e2smrcies.RanfunctionDefinitionPolicyActionItem{policyActionItem})
+	return policyItem, nil
+}
+
+func createRANFunctionDefinitionPolicyStyle2Item() (*e2smrcies.RanfunctionDefinitionPolicyItem, error) {
+	// Policy style 2 is evaluated upon E2 node information change
+	policyItem, err := pdubuilder.CreateRanfunctionDefinitionPolicyItem(ricPolicyStyleType2, ricPolicyStyleName2, 3)
+	if err != nil {
+		return nil, err
+	}
+	policyActionItem, err := pdubuilder.CreateRanfunctionDefinitionPolicyActionItem(ricPolicyActionIDForSlicePRBQuota, ricPolicyActionNameForSlicePRBQuota, ricActionDefinitionFormatTypeForPolicy)
+	if err != nil {
+		return nil, err
+	}
+	ranParameters := []struct {
+		id   int64
+		name string
+	}{
+		{RRMPolicyRatioListRANParameterID, "RRM Policy Ratio List"},
+		{SSTRANParameterID, "SST"},
+		{SDRANParameterID, "SD"},
+		{MinPRBPolicyRatioRANParameterID, "Min PRB Policy Ratio"},
+		{MaxPRBPolicyRatioRANParameterID, "Max PRB Policy Ratio"},
+		{DedicatedPRBPolicyRatioRANParameterID, "Dedicated PRB Policy Ratio"},
+	}
+	policyActionRANParametersList := make([]*e2smrcies.PolicyActionRanparameterItem, 0)
+	for _, rp := range ranParameters {
+		ranParameter, err := pdubuilder.CreatePolicyActionRanparameterItem(rp.id, rp.name)
+		if err != nil {
+			return nil, err
+		}
+		policyActionRANParametersList = append(policyActionRANParametersList, ranParameter)
+	}
+	policyConditionRANParametersList, err := createPolicyConditionRANParametersList()
+	if err != nil {
+		return nil, err
+	}
+	policyActionItem.SetRanPolicyActionParametersList(policyActionRANParametersList)
+	policyActionItem.SetRanPolicyConditionParametersList(policyConditionRANParametersList)
+	policyItem.SetRicPolicyActionList([]*e2smrcies.RanfunctionDefinitionPolicyActionItem{policyActionItem})
+	return policyItem, nil
+}
+
+// findRanParameterValue searches a RAN parameter value type, including nested structures and lists, for the value of the given RAN parameter
+func findRanParameterValue(ranParameterID int64, itemID int64, valueType *e2smrcies.RanparameterValueType) *e2smrcies.RanparameterValue {
+	if itemID == ranParameterID {
+		if elementFalse := valueType.GetRanPChoiceElementFalse(); elementFalse != nil {
+			return elementFalse.GetRanParameterValue()
+		}
+		if elementTrue := valueType.GetRanPChoiceElementTrue(); elementTrue != nil {
+			return elementTrue.GetRanParameterValue()
+		}
+	}
+	for _, item := range valueType.GetRanPChoiceStructure().GetRanParameterStructure().GetSequenceOfRanParameters() {
+		if value := findRanParameterValue(ranParameterID, item.GetRanParameterId().GetValue(), item.GetRanParameterValueType()); value != nil {
+			return value
+		}
+	}
+	for _, structure := range valueType.GetRanPChoiceList().GetRanParameterList().GetListOfRanParameter() {
+		for _, item := range structure.GetSequenceOfRanParameters() {
+			if value := findRanParameterValue(ranParameterID, item.GetRanParameterId().GetValue(), item.GetRanParameterValueType()); value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func extractPolicyActionRanParameterInt(pa *e2smrcies.RicPolicyAction, ranParameterID int64) (int64, error) {
+	for _, rp := range pa.GetRanParametersList() {
+		if value := findRanParameterValue(ranParameterID, rp.GetRanParameterId().GetValue(), rp.GetRanParameterValueType()); value != nil {
+			return value.GetValueInt(), nil
+		}
+	}
+	return 0, errors.NewNotFound("RanParameter %d not found", ranParameterID)
+}
+
+// ranParameterValueToUint32 converts an integer or octet string RAN parameter value, e.g. an SST or SD
+func ranParameterValueToUint32(value *e2smrcies.RanparameterValue) uint32 {
+	if octets := value.GetValueOctS(); len(octets) > 0 {
+		var result uint32
+		for _, b := range octets {
+			result = result<<8 | uint32(b)
+		}
+		return result
+	}
+	return uint32(value.GetValueInt())
+}
+
+func newRrmPolicyRatio(valueType *e2smrcies.RanparameterValueType) (model.RrmPolicyRatio, error) {
+	sst := findRanParameterValue(SSTRANParameterID, 0, valueType)
+	if sst == nil {
+		return model.RrmPolicyRatio{}, errors.NewNotFound("RanParameter %d for SST not found", SSTRANParameterID)
+	}
+	ratio := model.RrmPolicyRatio{
+		Sst: ranParameterValueToUint32(sst),
+	}
+	if sd := findRanParameterValue(SDRANParameterID, 0, valueType); sd != nil {
+		ratio.Sd = ranParameterValueToUint32(sd)
+	}
+	if minRatio := findRanParameterValue(MinPRBPolicyRatioRANParameterID, 0, valueType); minRatio != nil {
+		ratio.MinPrbRatio = int32(minRatio.GetValueInt())
+	}
+	if maxRatio := findRanParameterValue(MaxPRBPolicyRatioRANParameterID, 0, valueType); maxRatio != nil {
+		ratio.MaxPrbRatio = int32(maxRatio.GetValueInt())
+	}
+	if dedicatedRatio := findRanParameterValue(DedicatedPRBPolicyRatioRANParameterID, 0, valueType); dedicatedRatio != nil {
+		ratio.DedicatedPrbRatio = int32(dedicatedRatio.GetValueInt())
+	}
+	return ratio, nil
+}
+
+// extractRrmPolicyRatios extracts the slice PRB quotas of the RRM Policy Ratio List RAN parameter
+func extractRrmPolicyRatios(pa *e2smrcies.RicPolicyAction) ([]model.RrmPolicyRatio, error) {
+	ratios := make([]model.RrmPolicyRatio, 0)
+	for _, rp := range pa.GetRanParametersList() {
+		if rp.GetRanParameterId().GetValue() != RRMPolicyRatioListRANParameterID {
+			continue
+		}
+		for _, structure := range rp.GetRanParameterValueType().GetRanPChoiceList().GetRanParameterList().GetListOfRanParameter() {
+			valueType := &e2smrcies.RanparameterValueType{
+				RanparameterValueType: &e2smrcies.RanparameterValueType_RanPChoiceStructure{
+					RanPChoiceStructure: &e2smrcies.RanparameterValueTypeChoiceStructure{
+						RanParameterStructure: structure,
+					},
+				},
+			}
+			ratio, err := newRrmPolicyRatio(valueType)
+			if err != nil {
+				return nil, err
+			}
+			ratios = append(ratios, ratio)
+		}
+	}
+	if len(ratios) == 0 {
+		return nil, errors.NewNotFound("RanParameter %d for RRM policy ratio list not found", RRMPolicyRatioListRANParameterID)
+	}
+	return ratios, nil
+}
@@ -28,4 +28,8 @@ const (
 	Mho
 	// O-RAN-E2SM-RC
 	Rc
+	// O-RAN-E2SM-CCC
+	Ccc
+	// O-RAN-E2SM-LLC
+	Llc
 )
@@ -7,20 +7,22 @@ package registry
 import (
 	"sync"
 
-	"github.com/onosproject/rrm-son-lib/pkg/handover"
-
 	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 
+	"github.com/onosproject/ran-simulator/pkg/store/messages"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/policies"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/scheduler"
 
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
@@ -53,7 +55,9 @@ type ServiceModel struct {
 	UEs           ues.Store
 	CellStore     cells.Store
 	MetricStore   metrics.Store
-	A3Chan        chan handover.A3HandoverDecision
+	PolicyStore   policies.Store
+	MessageStore  messages.Store
+	Scheduler     scheduler.Scheduler
 }
 
 // NewServiceModelRegistry creates a service model registry
@@ -84,6 +88,54 @@ func (s *ServiceModelRegistry) RegisterServiceModel(sm ServiceModel) error {
 	return nil
 }
 
+// UnregisterServiceModel unregisters a service model
+func (s *ServiceModelRegistry) UnregisterServiceModel(id RanFunctionID) error {
+	log.Info("Unregister Service Model:", id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.serviceModels[id]; !exists {
+		return errors.NewNotFound("the service model with ran function ID %d is not registered", id)
+	}
+	delete(s.serviceModels, id)
+	delete(s.ranFunctions, e2aptypes.RanFunctionID(id))
+	return nil
+}
+
+// ModifyServiceModel replaces the RAN function definition of a registered service model and increments the
+// revision of its RAN function
+func (s *ServiceModelRegistry) ModifyServiceModel(id RanFunctionID, description []byte) (ServiceModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sm, exists := s.serviceModels[id]
+	if !exists {
+		return ServiceModel{}, errors.NewNotFound("the service model with ran function ID %d is not registered", id)
+	}
+	sm.Description = description
+	sm.Revision++
+	s.ranFunctions[e2aptypes.RanFunctionID(id)] = e2aptypes.RanFunctionItem{
+		Description: sm.Description,
+		Revision:    e2aptypes.RanFunctionRevision(sm.Revision),
+		OID:         e2aptypes.RanFunctionOID(sm.OID),
+	}
+	s.serviceModels[id] = sm
+	return sm, nil
+}
+
+// SubscriptionDeleteRequired returns whether the subscription of the service model can no longer be served once the
+// cell of the node is deleted: the MHO and NI subscriptions report on the UEs and the interface messages of the node
+// regardless of its cells, the subscriptions of the other service models on the cells they target, all the cells of
+// the node unless their service model tells otherwise
+func (sm ServiceModel) SubscriptionDeleteRequired(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	switch sm.RanFunctionID {
+	case Mho, Ni:
+		return false
+	}
+	if targeter, ok := sm.Client.(servicemodel.CellTargeter); ok {
+		return targeter.Targets(sub, ncgi)
+	}
+	return true
+}
+
 // GetServiceModel finds and initialize service model interface pointer
 func (s *ServiceModelRegistry) GetServiceModel(id RanFunctionID) (ServiceModel, error) {
 	s.mu.RLock()
@@ -99,10 +151,20 @@ func (s *ServiceModelRegistry) GetServiceModel(id RanFunctionID) (ServiceModel,
 func (s *ServiceModelRegistry) GetServiceModels() map[RanFunctionID]ServiceModel {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.serviceModels
+	serviceModels := make(map[RanFunctionID]ServiceModel, len(s.serviceModels))
+	for id, sm := range s.serviceModels {
+		serviceModels[id] = sm
+	}
+	return serviceModels
 }
 
 // GetRanFunctions returns the list of registered ran functions
 func (s *ServiceModelRegistry) GetRanFunctions() e2aptypes.RanFunctions {
-	return s.ranFunctions
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ranFunctions := make(e2aptypes.RanFunctions, len(s.ranFunctions))
+	for id, ranFunction := range s.ranFunctions {
+		ranFunctions[id] = ranFunction
+	}
+	return ranFunctions
 }
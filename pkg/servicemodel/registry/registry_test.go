@@ -8,10 +8,13 @@ import (
 	"context"
 	"testing"
 
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"github.com/stretchr/testify/assert"
 
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 )
 
 var _ servicemodel.Client = &mockServiceModel{}
@@ -69,4 +72,40 @@ func TestRegisterServiceModel(t *testing.T) {
 	ranFunctions := registry.GetRanFunctions()
 	assert.Equal(t, len(ranFunctions), 1)
 
+	modified, err := registry.ModifyServiceModel(Internal, []byte{0x05})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, modified.Revision)
+	assert.Equal(t, m, modified.Client)
+	assert.Equal(t, e2aptypes.RanFunctionDescription{0x05}, registry.GetRanFunctions()[e2aptypes.RanFunctionID(Internal)].Description)
+	_, err = registry.ModifyServiceModel(Kpm2, []byte{0x05})
+	assert.Error(t, err)
+
+	assert.NoError(t, registry.UnregisterServiceModel(Internal))
+	assert.Len(t, registry.GetRanFunctions(), 0)
+	_, err = registry.GetServiceModel(Internal)
+	assert.Error(t, err)
+	assert.Error(t, registry.UnregisterServiceModel(Internal))
+}
+
+// mockCellTargeter is a service model whose subscriptions report on a single cell
+type mockCellTargeter struct {
+	mockServiceModel
+	ncgi ransimtypes.NCGI
+}
+
+func (sm mockCellTargeter) Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool {
+	return ncgi == sm.ncgi
+}
+
+func TestSubscriptionDeleteRequired(t *testing.T) {
+	sub := &subscriptions.Subscription{}
+	assert.True(t, ServiceModel{RanFunctionID: Kpm2}.SubscriptionDeleteRequired(sub, 1))
+	assert.True(t, ServiceModel{RanFunctionID: Ccc}.SubscriptionDeleteRequired(sub, 1))
+	assert.False(t, ServiceModel{RanFunctionID: Mho}.SubscriptionDeleteRequired(sub, 1))
+	assert.False(t, ServiceModel{RanFunctionID: Ni}.SubscriptionDeleteRequired(sub, 1))
+
+	// the subscriptions reporting on other cells are left alone
+	targeter := mockCellTargeter{ncgi: 1}
+	assert.True(t, ServiceModel{RanFunctionID: Llc, Client: targeter}.SubscriptionDeleteRequired(sub, 1))
+	assert.False(t, ServiceModel{RanFunctionID: Llc, Client: targeter}.SubscriptionDeleteRequired(sub, 2))
 }
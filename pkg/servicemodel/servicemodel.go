@@ -4,9 +4,36 @@
 
 package servicemodel
 
-import e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+import (
+	"context"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+)
 
 // Client service model client interface
 type Client interface {
 	e2.ClientInterface
 }
+
+// RanFunctionName is the RAN function name of the JSON encoded RAN function definitions
+type RanFunctionName struct {
+	RanFunctionShortName   string `json:"ranFunctionShortName"`
+	RanFunctionE2SmOid     string `json:"ranFunctionE2SmOid"`
+	RanFunctionDescription string `json:"ranFunctionDescription"`
+	RanFunctionInstance    int32  `json:"ranFunctionInstance,omitempty"`
+}
+
+// CellTargeter is implemented by the service model clients whose subscriptions report on some cells of the node only
+type CellTargeter interface {
+	// Targets returns whether the subscription reports on the cell
+	Targets(sub *subscriptions.Subscription, ncgi ransimtypes.NCGI) bool
+}
+
+// CellDescriber is implemented by the service model clients whose RAN function definition describes the cells of the node
+type CellDescriber interface {
+	// RanFunctionDescription returns the RAN function definition of the service model for the current cells of the node
+	RanFunctionDescription(ctx context.Context, node model.Node) ([]byte, error)
+}
@@ -5,6 +5,7 @@
 package connections
 
 import (
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
 )
 
@@ -89,4 +90,6 @@ type Connection struct {
 	ID     ConnectionID
 	Client e2.ClientConn
 	Status ConnectionStatus
+	// Usage is the usage of the TNL association: RIC services, E2 support functions or both
+	Usage e2apies.Tnlusage
 }
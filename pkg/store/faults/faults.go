@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+var log = liblog.GetLogger()
+
+// Fault is a kind of fault injected on the E2 interface of a node
+type Fault string
+
+const (
+	// Delayed is a delayed indication
+	Delayed Fault = "delayed"
+	// Dropped is a dropped indication
+	Dropped Fault = "dropped"
+	// Duplicated is a duplicated indication
+	Duplicated Fault = "duplicated"
+	// Reordered is an indication sent after the next one
+	Reordered Fault = "reordered"
+	// Flushed is an indication held back and sent without a next indication
+	Flushed Fault = "flushed"
+	// Corrupted is an indication with a corrupted encoded message
+	Corrupted Fault = "corrupted"
+	// RejectedSubscription is a rejected subscription
+	RejectedSubscription Fault = "rejected_subscription"
+	// FailedControl is a failed control
+	FailedControl Fault = "failed_control"
+)
+
+// Store tracks the fault profiles of the nodes and the faults injected on their E2 interface
+type Store interface {
+	// Get returns the fault profile of the specified node
+	Get(ctx context.Context, gnbID types.GnbID) model.FaultProfile
+
+	// Set sets the fault profile of the specified node
+	Set(ctx context.Context, gnbID types.GnbID, profile model.FaultProfile)
+
+	// Inject counts a fault injected on the E2 interface of the specified node
+	Inject(ctx context.Context, gnbID types.GnbID, fault Fault)
+
+	// Counters returns the number of faults of each kind injected on the E2 interface of the specified node
+	Counters(ctx context.Context, gnbID types.GnbID) map[Fault]uint64
+
+	// Clear removes all the fault profiles and counters
+	Clear(ctx context.Context)
+}
+
+type store struct {
+	mu       sync.RWMutex
+	profiles map[types.GnbID]model.FaultProfile
+	counters map[types.GnbID]map[Fault]uint64
+}
+
+// NewFaultRegistry creates a new store of fault profiles primed with the profiles of the specified nodes
+func NewFaultRegistry(nodes map[string]model.Node) Store {
+	log.Infof("Creating fault registry")
+	s := &store{
+		profiles: make(map[types.GnbID]model.FaultProfile),
+		counters: make(map[types.GnbID]map[Fault]uint64),
+	}
+	for _, node := range nodes {
+		if node.Faults != (model.FaultProfile{}) {
+			s.profiles[node.GnbID] = node.Faults
+		}
+	}
+	return s
+}
+
+func (s *store) Get(ctx context.Context, gnbID types.GnbID) model.FaultProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profiles[gnbID]
+}
+
+func (s *store) Set(ctx context.Context, gnbID types.GnbID, profile model.FaultProfile) {
+	log.Infof("Setting fault profile of node %d: %+v", gnbID, profile)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[gnbID] = profile
+}
+
+func (s *store) Inject(ctx context.Context, gnbID types.GnbID, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counters, ok := s.counters[gnbID]
+	if !ok {
+		counters = make(map[Fault]uint64)
+		s.counters[gnbID] = counters
+	}
+	counters[fault]++
+}
+
+func (s *store) Counters(ctx context.Context, gnbID types.GnbID) map[Fault]uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	counters := make(map[Fault]uint64, len(s.counters[gnbID]))
+	for fault, count := range s.counters[gnbID] {
+		counters[fault] = count
+	}
+	return counters
+}
+
+func (s *store) Clear(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = make(map[types.GnbID]model.FaultProfile)
+	s.counters = make(map[types.GnbID]map[Fault]uint64)
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaults(t *testing.T) {
+	ctx := context.TODO()
+	store := NewFaultRegistry(map[string]model.Node{
+		"node1": {GnbID: 144470, Faults: model.FaultProfile{DropRate: 0.5}},
+		"node2": {GnbID: 144471},
+	})
+	assert.Equal(t, 0.5, store.Get(ctx, 144470).DropRate)
+	assert.Equal(t, model.FaultProfile{}, store.Get(ctx, 144471))
+
+	store.Set(ctx, 144471, model.FaultProfile{ControlFailureRate: 1})
+	assert.Equal(t, 1.0, store.Get(ctx, 144471).ControlFailureRate)
+
+	store.Inject(ctx, 144470, Dropped)
+	store.Inject(ctx, 144470, Dropped)
+	store.Inject(ctx, 144470, Corrupted)
+	counters := store.Counters(ctx, 144470)
+	assert.Equal(t, uint64(2), counters[Dropped])
+	assert.Equal(t, uint64(1), counters[Corrupted])
+	assert.Len(t, store.Counters(ctx, 144471), 0)
+
+	store.Clear(ctx)
+	assert.Equal(t, model.FaultProfile{}, store.Get(ctx, 144470))
+	assert.Len(t, store.Counters(ctx, 144470), 0)
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messages
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/watcher"
+)
+
+var log = liblog.GetLogger()
+
+// DefaultCapacity is the default number of messages kept by the store
+const DefaultCapacity = 1024
+
+// Store is a log of the F1AP, XnAP and NGAP messages generated by the simulated nodes; only the most
+// recent messages are kept
+type Store interface {
+	// Add adds a message to the log; the ID and timestamp of the message are assigned by the store
+	Add(ctx context.Context, message *Message) *Message
+
+	// List returns the messages of all nodes in the order they were added
+	List(ctx context.Context) []*Message
+
+	// ListNode returns the messages of the specified node in the order they were added
+	ListNode(ctx context.Context, gnbID types.GnbID) []*Message
+
+	// Len returns the number of messages in the log
+	Len(ctx context.Context) int
+
+	// Watch watches the messages added to the log using the supplied channel. Events are not guaranteed
+	// to be delivered in order; the message ID gives the order
+	Watch(ctx context.Context, ch chan<- event.Event) error
+
+	// Clear removes all messages; no events will be generated
+	Clear(ctx context.Context)
+}
+
+type store struct {
+	mu       sync.RWMutex
+	messages []*Message
+	capacity int
+	lastID   uint64
+	watchers *watcher.Watchers
+}
+
+// NewMessageLog creates a new message log keeping up to the given number of messages
+func NewMessageLog(capacity int) Store {
+	log.Infof("Creating message log")
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &store{
+		mu:       sync.RWMutex{},
+		messages: make([]*Message, 0, capacity),
+		capacity: capacity,
+		watchers: watcher.NewWatchers(),
+	}
+}
+
+func (s *store) Add(ctx context.Context, message *Message) *Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	added := *message
+	added.ID = s.lastID
+	added.Timestamp = time.Now()
+	if len(s.messages) == s.capacity {
+		copy(s.messages, s.messages[1:])
+		s.messages = s.messages[:len(s.messages)-1]
+	}
+	s.messages = append(s.messages, &added)
+	log.Debugf("%s %s %s message of node %d", added.Direction, added.Interface, added.Procedure, added.GnbID)
+	s.watchers.Send(event.Event{
+		Key:   added.ID,
+		Value: &added,
+		Type:  Created,
+	})
+	return &added
+}
+
+func (s *store) List(ctx context.Context) []*Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*Message{}, s.messages...)
+}
+
+func (s *store) ListNode(ctx context.Context, gnbID types.GnbID) []*Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*Message, 0)
+	for _, message := range s.messages {
+		if message.GnbID == gnbID {
+			list = append(list, message)
+		}
+	}
+	return list
+}
+
+func (s *store) Len(ctx context.Context) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.messages)
+}
+
+// Clear removes all messages; no events will be generated
+func (s *store) Clear(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = make([]*Message, 0, s.capacity)
+}
+
+func (s *store) Watch(ctx context.Context, ch chan<- event.Event) error {
+	log.Debug("Watching message log")
+
+	id := uuid.New()
+	err := s.watchers.AddWatcher(id, ch)
+	if err != nil {
+		log.Error(err)
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		err = s.watchers.RemoveWatcher(id)
+		if err != nil {
+			log.Error(err)
+		}
+		close(ch)
+	}()
+	return nil
+}
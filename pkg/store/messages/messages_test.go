@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageLog(t *testing.T) {
+	ctx := context.Background()
+	messageLog := NewMessageLog(3)
+	assert.Equal(t, 0, messageLog.Len(ctx))
+
+	ch := make(chan event.Event)
+	err := messageLog.Watch(ctx, ch)
+	assert.NoError(t, err)
+
+	message := messageLog.Add(ctx, &Message{GnbID: 144470, Interface: F1, Procedure: "F1SetupRequest", Payload: []byte{0x01}})
+	assert.Equal(t, uint64(1), message.ID)
+	assert.False(t, message.Timestamp.IsZero())
+	messageEvent := <-ch
+	assert.Equal(t, Created, messageEvent.Type.(MessageEvent))
+	assert.Equal(t, uint64(1), messageEvent.Key)
+
+	for i := 0; i < 3; i++ {
+		messageLog.Add(ctx, &Message{GnbID: 144471, Interface: Xn, Procedure: "HandoverRequest"})
+		<-ch
+	}
+
+	// the oldest message is dropped once the log is full
+	assert.Equal(t, 3, messageLog.Len(ctx))
+	list := messageLog.List(ctx)
+	assert.Equal(t, uint64(2), list[0].ID)
+	assert.Equal(t, uint64(4), list[2].ID)
+	assert.Equal(t, 0, len(messageLog.ListNode(ctx, 144470)))
+	assert.Equal(t, 3, len(messageLog.ListNode(ctx, 144471)))
+
+	messageLog.Clear(ctx)
+	assert.Equal(t, 0, messageLog.Len(ctx))
+	assert.Equal(t, uint64(5), messageLog.Add(ctx, &Message{GnbID: 144470}).ID)
+	<-ch
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messages
+
+import (
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// MessageEvent a message event
+type MessageEvent int
+
+const (
+	// None none message event
+	None MessageEvent = iota
+	// Created created message event
+	Created
+)
+
+// String converts message event to string
+func (e MessageEvent) String() string {
+	return [...]string{"None", "Created"}[e]
+}
+
+// Interface is the RAN interface a message is exchanged on
+type Interface int
+
+const (
+	// F1 interface between the gNB-CU and the gNB-DU; messages are encoded with F1AP
+	F1 Interface = iota
+	// Xn interface between NG-RAN nodes; messages are encoded with XnAP
+	Xn
+	// NG interface between the NG-RAN node and the AMF; messages are encoded with NGAP
+	NG
+)
+
+// String converts interface to string
+func (i Interface) String() string {
+	return [...]string{"F1", "Xn", "NG"}[i]
+}
+
+// Procedures of the messages generated by the simulator
+const (
+	// F1SetupRequest F1AP F1 Setup Request sent by the gNB-DU at E2 setup
+	F1SetupRequest = "F1SetupRequest"
+	// F1SetupResponse F1AP F1 Setup Response returned by the gNB-CU at E2 setup
+	F1SetupResponse = "F1SetupResponse"
+	// UEContextReleaseCommand F1AP UE Context Release Command sent by the gNB-CU once a UE is handed over, or
+	// NGAP UE Context Release Command sent by the AMF once a UE goes IDLE
+	UEContextReleaseCommand = "UEContextReleaseCommand"
+	// XnSetupRequest XnAP Xn Setup Request sent by the node at E2 setup
+	XnSetupRequest = "XnSetupRequest"
+	// XnSetupResponse XnAP Xn Setup Response returned by the Xn peer of the node at E2 setup
+	XnSetupResponse = "XnSetupResponse"
+	// HandoverRequest XnAP Handover Request sent by the source node of a handover
+	HandoverRequest = "HandoverRequest"
+	// HandoverRequestAcknowledge XnAP Handover Request Acknowledge returned by the target node of a handover
+	HandoverRequestAcknowledge = "HandoverRequestAcknowledge"
+	// SNStatusTransfer XnAP SN Status Transfer sent by the source node of a handover
+	SNStatusTransfer = "SNStatusTransfer"
+	// UEContextRelease XnAP UE Context Release sent by the target node of a handover
+	UEContextRelease = "UEContextRelease"
+	// NGRANNodeConfigurationUpdate XnAP NG-RAN Node Configuration Update sent by the node to its Xn peers when
+	// the neighbours of its cells change
+	NGRANNodeConfigurationUpdate = "NGRANNodeConfigurationUpdate"
+	// Paging NGAP Paging sent by the AMF to the node serving an IDLE UE
+	Paging = "Paging"
+	// InitialUEMessage NGAP Initial UE Message sent by the node when an IDLE UE connects
+	InitialUEMessage = "InitialUEMessage"
+	// InitialContextSetupRequest NGAP Initial Context Setup Request sent by the AMF in response to the Initial UE Message
+	InitialContextSetupRequest = "InitialContextSetupRequest"
+	// InitialContextSetupResponse NGAP Initial Context Setup Response returned by the node once the UE is connected
+	InitialContextSetupResponse = "InitialContextSetupResponse"
+	// UEContextReleaseRequest NGAP UE Context Release Request sent by the node when a UE goes IDLE
+	UEContextReleaseRequest = "UEContextReleaseRequest"
+	// UEContextReleaseComplete NGAP UE Context Release Complete returned by the node to the AMF
+	UEContextReleaseComplete = "UEContextReleaseComplete"
+)
+
+// Direction is the direction of a message as seen by the node it is recorded for
+type Direction int
+
+const (
+	// Outgoing message sent by the node
+	Outgoing Direction = iota
+	// Incoming message received by the node
+	Incoming
+)
+
+// String converts direction to string
+func (d Direction) String() string {
+	return [...]string{"Outgoing", "Incoming"}[d]
+}
+
+// Message is an encoded interface message sent or received by an E2 node
+type Message struct {
+	// ID is the sequence number of the message; it increases in the order the messages are added
+	ID uint64
+	// GnbID is the node the message is recorded for
+	GnbID types.GnbID
+	// PeerGnbID is the node on the other end of an Xn message
+	PeerGnbID types.GnbID
+	Interface Interface
+	Direction Direction
+	// Procedure is the name of the message, e.g. HandoverRequest
+	Procedure string
+	// IMSI is the UE of a UE-associated message; zero otherwise
+	IMSI types.IMSI
+	// NCGI is the cell of a UE-associated message; zero otherwise
+	NCGI      types.NCGI
+	Payload   []byte
+	Timestamp time.Time
+}
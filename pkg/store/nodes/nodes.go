@@ -48,6 +48,10 @@ type Store interface {
 	// SetsStatus changes the E2 node agent status value
 	SetStatus(ctx context.Context, gnbID types.GnbID, status string) error
 
+	// SetConnectionState records the phase of the E2 connection of the node to the controller and the cause of its
+	// last setup failure
+	SetConnectionState(ctx context.Context, gnbID types.GnbID, controller string, phase string, failureCause string) error
+
 	// PruneCell  the node that has the specified cell
 	PruneCell(ctx context.Context, ncgi types.NCGI) error
 
@@ -189,6 +193,27 @@ func (s *store) SetStatus(ctx context.Context, gnbID types.GnbID, status string)
 	return errors.New(errors.NotFound, "node not found")
 }
 
+func (s *store) SetConnectionState(ctx context.Context, gnbID types.GnbID, controller string, phase string, failureCause string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if node, ok := s.nodes[gnbID]; ok {
+		// The states are copied since the copies of the node handed out share them
+		states := make(map[string]model.ConnectionState, len(node.ConnectionStates)+1)
+		for c, state := range node.ConnectionStates {
+			states[c] = state
+		}
+		state := states[controller]
+		state.Phase = phase
+		if failureCause != "" {
+			state.SetupFailureCause = failureCause
+		}
+		states[controller] = state
+		node.ConnectionStates = states
+		return nil
+	}
+	return errors.New(errors.NotFound, "node not found")
+}
+
 // Delete deletes a node
 func (s *store) Delete(ctx context.Context, gnbID types.GnbID) (*model.Node, error) {
 	log.Debugf("Deleting node %d:", gnbID)
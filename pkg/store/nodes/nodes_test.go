@@ -58,6 +58,15 @@ func TestNodes(t *testing.T) {
 	node1, err = nodeStore.Get(ctx, node1GnbID)
 	assert.NoError(t, err)
 	assert.Equal(t, node1.GnbID, node1GnbID)
+
+	// the connection state is recorded per controller and keeps the last setup failure cause
+	assert.NoError(t, nodeStore.SetConnectionState(ctx, node1GnbID, "controller1", "SetupFailed", "CAUSE_MISC_OM_INTERVENTION"))
+	assert.NoError(t, nodeStore.SetConnectionState(ctx, node1GnbID, "controller1", "Connected", ""))
+	assert.NoError(t, nodeStore.SetConnectionState(ctx, node1GnbID, "controller2", "Connecting", ""))
+	node1, err = nodeStore.Get(ctx, node1GnbID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ConnectionState{Phase: "Connected", SetupFailureCause: "CAUSE_MISC_OM_INTERVENTION"}, node1.ConnectionStates["controller1"])
+	assert.Equal(t, "Connecting", node1.ConnectionStates["controller2"].Phase)
 	_, err = nodeStore.Delete(ctx, node1GnbID)
 	assert.NoError(t, err)
 	nodeEvent = <-ch
@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/watcher"
+)
+
+var log = liblog.GetLogger()
+
+// Store is a ledger of the RIC policies installed on the E2 nodes and the RAN parameter changes they applied
+type Store interface {
+	// Add adds a ledger entry for the policies installed by a subscription
+	Add(ctx context.Context, key Key, actions []Action) error
+
+	// Record records a change applied by the policies of a subscription; if the same parameter
+	// was already changed by the subscription, only the applied value is updated
+	Record(ctx context.Context, key Key, change Change) error
+
+	// Get retrieves the ledger entry of a subscription
+	Get(ctx context.Context, key Key) (*Policy, error)
+
+	// Delete removes the ledger entry of a subscription and returns it
+	Delete(ctx context.Context, key Key) (*Policy, error)
+
+	// List returns the ledger entries of all nodes
+	List(ctx context.Context) []*Policy
+
+	// ListNode returns the ledger entries of the specified node
+	ListNode(ctx context.Context, gnbID types.GnbID) []*Policy
+
+	// Len returns the number of ledger entries
+	Len(ctx context.Context) int
+
+	// Watch watches the policy events using the supplied channel
+	Watch(ctx context.Context, ch chan<- event.Event, options ...WatchOptions) error
+
+	// Clear removes all ledger entries; no events will be generated
+	Clear(ctx context.Context)
+}
+
+// WatchOptions allows tailoring the Watch behaviour
+type WatchOptions struct {
+	Replay bool
+}
+
+type store struct {
+	mu       sync.RWMutex
+	policies map[Key]*Policy
+	watchers *watcher.Watchers
+}
+
+// NewPolicyRegistry creates a new policy ledger
+func NewPolicyRegistry() Store {
+	log.Infof("Creating policy registry")
+	return &store{
+		mu:       sync.RWMutex{},
+		policies: make(map[Key]*Policy),
+		watchers: watcher.NewWatchers(),
+	}
+}
+
+// Clear removes all ledger entries; no events will be generated
+func (s *store) Clear(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.policies {
+		delete(s.policies, key)
+	}
+}
+
+func (s *store) Len(ctx context.Context) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.policies)
+}
+
+func (s *store) Add(ctx context.Context, key Key, actions []Action) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.policies[key]; ok {
+		return errors.New(errors.AlreadyExists, "policy already exists")
+	}
+	now := time.Now()
+	policy := &Policy{
+		Key:       key,
+		Actions:   append([]Action{}, actions...),
+		Changes:   make([]*Change, 0),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.policies[key] = policy
+	s.watchers.Send(event.Event{
+		Key:   key,
+		Value: policy.clone(),
+		Type:  Created,
+	})
+	return nil
+}
+
+func (s *store) Record(ctx context.Context, key Key, change Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policy, ok := s.policies[key]
+	if !ok {
+		return errors.New(errors.NotFound, "policy not found")
+	}
+	found := false
+	for _, c := range policy.Changes {
+		if c.Parameter == change.Parameter && c.Target == change.Target && c.Key == change.Key {
+			c.Action = change.Action
+			c.Applied = change.Applied
+			found = true
+			break
+		}
+	}
+	if !found {
+		policy.Changes = append(policy.Changes, &change)
+	}
+	policy.UpdatedAt = time.Now()
+	s.watchers.Send(event.Event{
+		Key:   key,
+		Value: policy.clone(),
+		Type:  Updated,
+	})
+	return nil
+}
+
+func (s *store) Get(ctx context.Context, key Key) (*Policy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if policy, ok := s.policies[key]; ok {
+		return policy.clone(), nil
+	}
+	return nil, errors.New(errors.NotFound, "policy not found")
+}
+
+func (s *store) Delete(ctx context.Context, key Key) (*Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if policy, ok := s.policies[key]; ok {
+		delete(s.policies, key)
+		s.watchers.Send(event.Event{
+			Key:   key,
+			Value: policy,
+			Type:  Deleted,
+		})
+		return policy, nil
+	}
+	return nil, errors.New(errors.NotFound, "policy not found")
+}
+
+func (s *store) List(ctx context.Context) []*Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*Policy, 0, len(s.policies))
+	for _, policy := range s.policies {
+		list = append(list, policy.clone())
+	}
+	return list
+}
+
+func (s *store) ListNode(ctx context.Context, gnbID types.GnbID) []*Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*Policy, 0)
+	for key, policy := range s.policies {
+		if key.GnbID == gnbID {
+			list = append(list, policy.clone())
+		}
+	}
+	return list
+}
+
+func (s *store) Watch(ctx context.Context, ch chan<- event.Event, options ...WatchOptions) error {
+	log.Debug("Watching policy changes")
+	replay := len(options) > 0 && options[0].Replay
+
+	id := uuid.New()
+	err := s.watchers.AddWatcher(id, ch)
+	if err != nil {
+		log.Error(err)
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		err = s.watchers.RemoveWatcher(id)
+		if err != nil {
+			log.Error(err)
+		}
+		close(ch)
+	}()
+
+	if replay {
+		policies := s.List(ctx)
+		go func() {
+			for _, policy := range policies {
+				ch <- event.Event{
+					Key:   policy.Key,
+					Value: policy,
+					Type:  None,
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// clone copies the entry so that it can be handed out without holding the store lock
+func (p *Policy) clone() *Policy {
+	changes := make([]*Change, 0, len(p.Changes))
+	for _, c := range p.Changes {
+		change := *c
+		changes = append(changes, &change)
+	}
+	return &Policy{
+		Key:       p.Key,
+		Actions:   append([]Action{}, p.Actions...),
+		Changes:   changes,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
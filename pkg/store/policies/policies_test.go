@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyRegistry(t *testing.T) {
+	ctx := context.Background()
+	policies := NewPolicyRegistry()
+	assert.Equal(t, 0, policies.Len(ctx))
+
+	ch := make(chan event.Event)
+	err := policies.Watch(ctx, ch)
+	assert.NoError(t, err)
+
+	key1 := Key{GnbID: 144470, SubscriptionID: "1:1:6"}
+	key2 := Key{GnbID: 144471, SubscriptionID: "1:1:6"}
+	action := Action{StyleType: 3, ActionID: 1}
+
+	err = policies.Add(ctx, key1, []Action{action})
+	assert.NoError(t, err)
+	policyEvent := <-ch
+	assert.Equal(t, Created, policyEvent.Type.(PolicyEvent))
+	err = policies.Add(ctx, key1, []Action{action})
+	assert.Error(t, err)
+	err = policies.Add(ctx, key2, []Action{action})
+	assert.NoError(t, err)
+	<-ch
+	assert.Equal(t, 2, policies.Len(ctx))
+	assert.Equal(t, 1, len(policies.ListNode(ctx, 144470)))
+
+	err = policies.Record(ctx, key1, Change{Action: action, Parameter: CellIndividualOffset, Target: 1, Key: 2, Original: int32(0), Applied: int32(3)})
+	assert.NoError(t, err)
+	policyEvent = <-ch
+	assert.Equal(t, Updated, policyEvent.Type.(PolicyEvent))
+
+	// the original value is kept when the same parameter changes again
+	err = policies.Record(ctx, key1, Change{Action: action, Parameter: CellIndividualOffset, Target: 1, Key: 2, Original: int32(3), Applied: int32(5)})
+	assert.NoError(t, err)
+	<-ch
+	policy, err := policies.Get(ctx, key1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(policy.Changes))
+	assert.Equal(t, int32(0), policy.Changes[0].Original)
+	assert.Equal(t, int32(5), policy.Changes[0].Applied)
+
+	err = policies.Record(ctx, Key{GnbID: 1, SubscriptionID: "1:1:6"}, Change{})
+	assert.Error(t, err)
+
+	policy, err = policies.Delete(ctx, key1)
+	assert.NoError(t, err)
+	assert.Equal(t, key1, policy.Key)
+	policyEvent = <-ch
+	assert.Equal(t, Deleted, policyEvent.Type.(PolicyEvent))
+	_, err = policies.Get(ctx, key1)
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(policies.List(ctx)))
+
+	policies.Clear(ctx)
+	assert.Equal(t, 0, policies.Len(ctx))
+}
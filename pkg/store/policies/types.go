@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// PolicyEvent a policy event
+type PolicyEvent int
+
+const (
+	// None none policy event
+	None PolicyEvent = iota
+	// Created created policy event
+	Created
+	// Updated updated policy event
+	Updated
+	// Deleted deleted policy event
+	Deleted
+)
+
+// String converts policy event to string
+func (e PolicyEvent) String() string {
+	return [...]string{"None", "Created", "Updated", "Deleted"}[e]
+}
+
+// Parameter is the kind of RAN parameter changed by a policy
+type Parameter int
+
+const (
+	// CellIndividualOffset the Ocn of a neighbor in a cell; target is the serving cell NCGI, key the neighbor NCGI
+	CellIndividualOffset Parameter = iota
+	// FiveQI the 5QI of a UE; target is the UE IMSI
+	FiveQI
+	// RrmPolicyRatio the RRM policy ratio of a slice in a cell; target is the cell NCGI, key the slice
+	RrmPolicyRatio
+)
+
+// String converts parameter to string
+func (p Parameter) String() string {
+	return [...]string{"CellIndividualOffset", "FiveQI", "RrmPolicyRatio"}[p]
+}
+
+// Key identifies the policies installed by a subscription of an E2 node
+type Key struct {
+	GnbID          types.GnbID
+	SubscriptionID string
+}
+
+// Action is a policy action installed by a subscription
+type Action struct {
+	StyleType int32
+	ActionID  int32
+}
+
+// Change is a single RAN parameter change applied by a policy
+type Change struct {
+	Action    Action
+	Parameter Parameter
+	Target    uint64
+	Key       uint64
+	// Original is the value before the policy was first applied; nil if the parameter did not exist
+	Original interface{}
+	// Applied is the value most recently set by the policy
+	Applied interface{}
+}
+
+// Policy is the ledger entry of a subscription; the changes are kept in the order they were first applied
+type Policy struct {
+	Key       Key
+	Actions   []Action
+	Changes   []*Change
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -6,6 +6,7 @@ package ues
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	e2smcommonies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc/v1/e2sm-common-ies"
 	"math/rand"
@@ -28,6 +29,9 @@ import (
 const (
 	minIMSI = 1000000
 	maxIMSI = 9999999
+
+	// firstCRNTI is the C-RNTI of the first UE
+	firstCRNTI = 90125
 )
 
 var log = liblog.GetLogger()
@@ -52,15 +56,31 @@ type Store interface {
 	// UpdateMaxUEsPerCell updates the maximum number of active UEs for all cells
 	UpdateMaxUEsPerCell(ctx context.Context)
 
-	// CreateUEs creates the specified number of UEs
+	// CreateUEs creates the specified number of UEs; the UEs are not registered with the AMF
 	CreateUEs(ctx context.Context, count uint)
 
 	// Get retrieves the UE with the specified IMSI
 	Get(ctx context.Context, imsi types.IMSI) (*model.UE, error)
 
-	// GetWithGNbUeID retrieves the UE with the gNB UE ID
+	// GetWithGNbUeID retrieves the UE with the gNB UE ID; the UE is identified by its AMF UE NGAP ID or, if absent,
+	// by its gNB-CU UE F1AP ID or its RAN UE ID
 	GetWithGNbUeID(ctx context.Context, gNBUeID *e2smcommonies.UeidGnb) (*model.UE, error)
 
+	// UpdateRegistration records the AMF UE NGAP ID and the GUAMI assigned to the UE by the AMF
+	UpdateRegistration(ctx context.Context, imsi types.IMSI, amfUeNgapID types.AmfUENgapID, guami model.Guami) error
+
+	// GetWithAmfUeNgapID retrieves the UE with the AMF UE NGAP ID
+	GetWithAmfUeNgapID(ctx context.Context, amfUeNgapID types.AmfUENgapID) (*model.UE, error)
+
+	// GetWithGnbCuUeF1apID retrieves the UE with the gNB-CU UE F1AP ID
+	GetWithGnbCuUeF1apID(ctx context.Context, f1apID model.GnbCuUeF1apID) (*model.UE, error)
+
+	// GetWithRanUeID retrieves the UE with the RAN UE ID
+	GetWithRanUeID(ctx context.Context, ranUeID model.RanUeID) (*model.UE, error)
+
+	// GetWithCRNTI retrieves the UE with the C-RNTI served by the specified cell
+	GetWithCRNTI(ctx context.Context, ncgi types.NCGI, crnti types.CRNTI) (*model.UE, error)
+
 	// Delete destroy the specified UE
 	Delete(ctx context.Context, imsi types.IMSI) (*model.UE, error)
 
@@ -102,6 +122,14 @@ type store struct {
 	cellStore       cells.Store
 	watchers        *watcher.Watchers
 	initialRrcState string
+
+	// nextUEID is the sequence number of the next UE; the identities of the UEs are derived from it so
+	// that no two UEs of the store share an identity
+	nextUEID     uint64
+	amfUeNgapIDs map[types.AmfUENgapID]types.IMSI
+	f1apIDs      map[model.GnbCuUeF1apID]types.IMSI
+	ranUeIDs     map[model.RanUeID]types.IMSI
+	crntis       map[types.CRNTI]types.IMSI
 }
 
 // NewUERegistry creates a new user-equipment registry primed with the specified number of UEs to start.
@@ -112,6 +140,10 @@ func NewUERegistry(count uint, cellStore cells.Store, initialRrcState string) St
 	store := &store{
 		mu:              sync.RWMutex{},
 		ues:             make(map[types.IMSI]*model.UE),
+		amfUeNgapIDs:    make(map[types.AmfUENgapID]types.IMSI),
+		f1apIDs:         make(map[model.GnbCuUeF1apID]types.IMSI),
+		ranUeIDs:        make(map[model.RanUeID]types.IMSI),
+		crntis:          make(map[types.CRNTI]types.IMSI),
 		maxUEs:          make(map[uint64]int),
 		cellStore:       cellStore,
 		watchers:        watchers,
@@ -211,10 +243,11 @@ func (s *store) CreateUEs(ctx context.Context, count uint) {
 	s.mu.Lock()
 	for i := uint(0); i < count; i++ {
 		imsi := types.IMSI(rand.Int63n(maxIMSI-minIMSI) + minIMSI)
-		if _, ok := s.ues[imsi]; ok {
-			// FIXME: more robust check for duplicates
+		for _, ok := s.ues[imsi]; ok; _, ok = s.ues[imsi] {
 			imsi = types.IMSI(rand.Int63n(maxIMSI-minIMSI) + minIMSI)
 		}
+		seq := s.nextUEID
+		s.nextUEID++
 
 		randomCell, err := s.cellStore.GetRandomCell()
 		if err != nil {
@@ -240,22 +273,32 @@ func (s *store) CreateUEs(ctx context.Context, count uint) {
 			}
 		}
 		ue := &model.UE{
-			IMSI:        imsi,
-			AmfUeNgapID: types.AmfUENgapID(i + 1000),
-			Type:        "phone",
-			Location:    model.Coordinate{Lat: 0, Lng: 0},
-			Heading:     0,
+			IMSI:          imsi,
+			GnbCuUeF1apID: model.GnbCuUeF1apID(seq + 1),
+			RanUeID:       model.RanUeID(seq + 1),
+			Type:          "phone",
+			Location:      model.Coordinate{Lat: 0, Lng: 0},
+			Heading:       0,
 			Cell: &model.UECell{
 				ID:       types.GnbID(ncgi), // placeholder
 				NCGI:     ncgi,
 				Strength: rand.Float64() * 100,
 			},
-			CRNTI:      types.CRNTI(90125 + i),
+			CRNTI:      types.CRNTI(firstCRNTI + seq),
 			Cells:      nil,
 			IsAdmitted: false,
 			RrcState:   rrcState,
 		}
 		s.ues[ue.IMSI] = ue
+		s.f1apIDs[ue.GnbCuUeF1apID] = ue.IMSI
+		s.ranUeIDs[ue.RanUeID] = ue.IMSI
+		s.crntis[ue.CRNTI] = ue.IMSI
+		createEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Created,
+		}
+		s.watchers.Send(createEvent)
 	}
 	s.mu.Unlock()
 	s.UpdateMaxUEsPerCell(ctx)
@@ -273,17 +316,81 @@ func (s *store) Get(ctx context.Context, imsi types.IMSI) (*model.UE, error) {
 }
 
 func (s *store) GetWithGNbUeID(ctx context.Context, gNBUeID *e2smcommonies.UeidGnb) (*model.UE, error) {
+	// TODO add GUAMI - currently RAN simulator only supports single AMF, it should be fine
+	// TODO for the future, GUAMI should be considered here
+	if gNBUeID.GetAmfUeNgapId() != nil {
+		return s.GetWithAmfUeNgapID(ctx, types.AmfUENgapID(gNBUeID.GetAmfUeNgapId().GetValue()))
+	}
+	if f1apIDs := gNBUeID.GetGNbCuUeF1ApIdList().GetValue(); len(f1apIDs) > 0 {
+		return s.GetWithGnbCuUeF1apID(ctx, model.GnbCuUeF1apID(f1apIDs[0].GetGNbCuUeF1ApId().GetValue()))
+	}
+	if ranUeID := gNBUeID.GetRanUeid().GetValue(); len(ranUeID) == 8 {
+		return s.GetWithRanUeID(ctx, model.RanUeID(binary.BigEndian.Uint64(ranUeID)))
+	}
+	return nil, errors.NewNotFound(fmt.Sprintf("the UE having gNB UE ID %v Not found", gNBUeID))
+}
+
+func (s *store) UpdateRegistration(ctx context.Context, imsi types.IMSI, amfUeNgapID types.AmfUENgapID, guami model.Guami) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ue, ok := s.ues[imsi]
+	if !ok {
+		return errors.New(errors.NotFound, "UE not found")
+	}
+	if other, ok := s.amfUeNgapIDs[amfUeNgapID]; ok && other != imsi {
+		return errors.NewAlreadyExists("AMF UE NGAP ID %d is already assigned to UE %d", amfUeNgapID, other)
+	}
+	if ue.AmfUeNgapID != 0 {
+		delete(s.amfUeNgapIDs, ue.AmfUeNgapID)
+	}
+	ue.AmfUeNgapID = amfUeNgapID
+	ue.Guami = guami
+	s.amfUeNgapIDs[amfUeNgapID] = imsi
+	updateEvent := event.Event{
+		Key:   ue.IMSI,
+		Value: ue,
+		Type:  Updated,
+	}
+	s.watchers.Send(updateEvent)
+	return nil
+}
+
+func (s *store) GetWithAmfUeNgapID(ctx context.Context, amfUeNgapID types.AmfUENgapID) (*model.UE, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	amfUeNgapID := gNBUeID.AmfUeNgapId.GetValue()
-	for _, ue := range s.ues {
-		// TODO add GUAMI - currently RAN simulator only supports single AMF, it should be fine
-		// TODO for the future, GUAMI should be considered here
-		if int64(ue.AmfUeNgapID) == amfUeNgapID {
+	if imsi, ok := s.amfUeNgapIDs[amfUeNgapID]; ok {
+		return s.ues[imsi], nil
+	}
+	return nil, errors.NewNotFound("the UE having AMF UE NGAP ID %d not found", amfUeNgapID)
+}
+
+func (s *store) GetWithGnbCuUeF1apID(ctx context.Context, f1apID model.GnbCuUeF1apID) (*model.UE, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if imsi, ok := s.f1apIDs[f1apID]; ok {
+		return s.ues[imsi], nil
+	}
+	return nil, errors.NewNotFound("the UE having gNB-CU UE F1AP ID %d not found", f1apID)
+}
+
+func (s *store) GetWithRanUeID(ctx context.Context, ranUeID model.RanUeID) (*model.UE, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if imsi, ok := s.ranUeIDs[ranUeID]; ok {
+		return s.ues[imsi], nil
+	}
+	return nil, errors.NewNotFound("the UE having RAN UE ID %d not found", ranUeID)
+}
+
+func (s *store) GetWithCRNTI(ctx context.Context, ncgi types.NCGI, crnti types.CRNTI) (*model.UE, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if imsi, ok := s.crntis[crnti]; ok {
+		if ue := s.ues[imsi]; ue.Cell != nil && ue.Cell.NCGI == ncgi {
 			return ue, nil
 		}
 	}
-	return nil, errors.NewNotFound(fmt.Sprintf("the UE having gNB UE ID %v Not found", gNBUeID))
+	return nil, errors.NewNotFound("the UE having C-RNTI %d in the cell %v not found", crnti, ncgi)
 }
 
 // Delete deletes a UE based on a given imsi
@@ -292,6 +399,10 @@ func (s *store) Delete(ctx context.Context, imsi types.IMSI) (*model.UE, error)
 	defer s.mu.Unlock()
 	if ue, ok := s.ues[imsi]; ok {
 		delete(s.ues, imsi)
+		delete(s.amfUeNgapIDs, ue.AmfUeNgapID)
+		delete(s.f1apIDs, ue.GnbCuUeF1apID)
+		delete(s.ranUeIDs, ue.RanUeID)
+		delete(s.crntis, ue.CRNTI)
 		deleteEvent := event.Event{
 			Key:   imsi,
 			Value: ue,
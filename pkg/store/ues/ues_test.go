@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
@@ -130,3 +131,52 @@ func TestUpdateCells(t *testing.T) {
 	assert.Equal(t, 42.0, ue1.Cells[0].Strength)
 	assert.Equal(t, 6.28, ue1.Cells[1].Strength)
 }
+
+func TestUEIdentities(t *testing.T) {
+	ctx := context.Background()
+	ues := NewUERegistry(20, cellStore(t), "random")
+	ues.SetUECount(ctx, 10)
+	ues.SetUECount(ctx, 30)
+
+	// The UEs are not registered with the AMF when created
+	for i, ue := range ues.ListAllUEs(ctx) {
+		assert.Equal(t, types.AmfUENgapID(0), ue.AmfUeNgapID)
+		err := ues.UpdateRegistration(ctx, ue.IMSI, types.AmfUENgapID(1000+i), model.Guami{AmfRegionID: 0xdd})
+		assert.NoError(t, err)
+	}
+	list := ues.ListAllUEs(ctx)
+	ue := list[0]
+	err := ues.UpdateRegistration(ctx, list[1].IMSI, ue.AmfUeNgapID, model.Guami{})
+	assert.True(t, errors.IsAlreadyExists(err))
+
+	amfUeNgapIDs := make(map[types.AmfUENgapID]bool)
+	crntis := make(map[types.CRNTI]bool)
+	for _, ue := range ues.ListAllUEs(ctx) {
+		assert.Equal(t, uint32(0xdd), ue.Guami.AmfRegionID)
+		assert.False(t, amfUeNgapIDs[ue.AmfUeNgapID])
+		assert.False(t, crntis[ue.CRNTI])
+		amfUeNgapIDs[ue.AmfUeNgapID] = true
+		crntis[ue.CRNTI] = true
+
+		found, err := ues.GetWithAmfUeNgapID(ctx, ue.AmfUeNgapID)
+		assert.NoError(t, err)
+		assert.Equal(t, ue.IMSI, found.IMSI)
+		found, err = ues.GetWithGnbCuUeF1apID(ctx, ue.GnbCuUeF1apID)
+		assert.NoError(t, err)
+		assert.Equal(t, ue.IMSI, found.IMSI)
+		found, err = ues.GetWithRanUeID(ctx, ue.RanUeID)
+		assert.NoError(t, err)
+		assert.Equal(t, ue.IMSI, found.IMSI)
+		found, err = ues.GetWithCRNTI(ctx, ue.Cell.NCGI, ue.CRNTI)
+		assert.NoError(t, err)
+		assert.Equal(t, ue.IMSI, found.IMSI)
+		_, err = ues.GetWithCRNTI(ctx, ue.Cell.NCGI+1, ue.CRNTI)
+		assert.True(t, errors.IsNotFound(err))
+	}
+	assert.Len(t, amfUeNgapIDs, 30)
+
+	_, err = ues.Delete(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	_, err = ues.GetWithAmfUeNgapID(ctx, ue.AmfUeNgapID)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -64,8 +64,7 @@ func (ws *Watchers) RemoveWatcher(id uuid.UUID) error {
 	watchers := make(map[uuid.UUID]Watcher, len(ws.watchers)-1)
 	for _, watcher := range ws.watchers {
 		if watcher.id != id {
-			watchers[id] = watcher
-
+			watchers[watcher.id] = watcher
 		}
 	}
 	ws.watchers = watchers
@@ -11,6 +11,7 @@ import (
 	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 )
 
@@ -19,6 +20,7 @@ type ConfigurationUpdate struct {
 	transactionID int32
 	plmnID        ransimtypes.Uint24
 	e2NodeID      uint64
+	configUpdates []*types.E2NodeComponentConfigUpdateItem
 }
 
 // NewConfigurationUpdate creates a new instance of configuration update
@@ -53,6 +55,13 @@ func WithPlmnID(plmnID ransimtypes.Uint24) func(update *ConfigurationUpdate) {
 	}
 }
 
+// WithComponentConfigUpdates sets the updated configurations of the E2 node components
+func WithComponentConfigUpdates(configUpdates []*types.E2NodeComponentConfigUpdateItem) func(update *ConfigurationUpdate) {
+	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.configUpdates = configUpdates
+	}
+}
+
 // Build builds a configuration update request
 func (c *ConfigurationUpdate) Build() (*e2appducontents.E2NodeConfigurationUpdate, error) {
 	gE2NodeID := &e2apies.GlobalE2NodeId{
@@ -78,6 +87,9 @@ func (c *ConfigurationUpdate) Build() (*e2appducontents.E2NodeConfigurationUpdat
 		ProtocolIes: make([]*e2appducontents.E2NodeConfigurationUpdateIes, 0),
 	}
 	configUpdate.SetTransactionID(c.transactionID).SetGlobalE2nodeID(gE2NodeID)
+	if len(c.configUpdates) > 0 {
+		configUpdate.SetE2nodeComponentConfigUpdate(c.configUpdates)
+	}
 
 	return configUpdate, nil
 }
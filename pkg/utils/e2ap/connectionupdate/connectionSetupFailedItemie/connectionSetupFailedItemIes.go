@@ -35,6 +35,13 @@ func WithTnlInfo(tnlInfo *e2apies.Tnlinformation) func(ie *IEs) {
 	}
 }
 
+// WithCause sets the cause of the failure
+func WithCause(cause *e2apies.Cause) func(ie *IEs) {
+	return func(connectionSetupFailedItemIe *IEs) {
+		connectionSetupFailedItemIe.cause = cause
+	}
+}
+
 // BuildConnectionSetupFailedItemIes builds connection setup failed Item Ies
 func (c *IEs) BuildConnectionSetupFailedItemIes() *e2appducontents.E2ConnectionSetupFailedItemIes {
 	connectionSetupFailedItemIes := &e2appducontents.E2ConnectionSetupFailedItemIes{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 )
 
@@ -61,3 +62,13 @@ func GetRicInstanceID(request *e2appducontents.RiccontrolRequest) (*int32, error
 
 	return &res, nil
 }
+
+// GetRicControlAckRequest gets RIC control ack request; the control request is acknowledged if the IE is absent
+func GetRicControlAckRequest(request *e2appducontents.RiccontrolRequest) e2apies.RiccontrolAckRequest {
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolAckRequest) {
+			return v.GetValue().GetRiccontrolAckRequest()
+		}
+	}
+	return e2apies.RiccontrolAckRequest_RICCONTROL_ACK_REQUEST_ACK
+}
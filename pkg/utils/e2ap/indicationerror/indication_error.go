@@ -9,6 +9,7 @@ import (
 	e2ap_commondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2appdudescriptions "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-descriptions"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 )
 
@@ -63,6 +64,20 @@ func WithFailureProcCode(failureProcCode int32) func(*ErrorIndication) {
 	}
 }
 
+// WithFailureCriticality sets the criticality of the failed procedure
+func WithFailureCriticality(failureCrit e2ap_commondatatypes.Criticality) func(*ErrorIndication) {
+	return func(errorIndication *ErrorIndication) {
+		errorIndication.failureCrit = &failureCrit
+	}
+}
+
+// WithFailureTriggeringMessage sets the message of the failed procedure triggering the error
+func WithFailureTriggeringMessage(failureTrigMsg e2ap_commondatatypes.TriggeringMessage) func(*ErrorIndication) {
+	return func(errorIndication *ErrorIndication) {
+		errorIndication.failureTrigMsg = &failureTrigMsg
+	}
+}
+
 // WithCause sets cause of error
 func WithCause(cause *e2apies.Cause) func(*ErrorIndication) {
 	return func(errorIndication *ErrorIndication) {
@@ -86,3 +101,24 @@ func (e *ErrorIndication) Build() (*e2appducontents.ErrorIndication, error) {
 
 	return errorIndication, nil
 }
+
+// BuildPdu builds the E2AP PDU of an error indication message
+func (e *ErrorIndication) BuildPdu() (*e2appdudescriptions.E2ApPdu, error) {
+	errorIndication, err := e.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &e2appdudescriptions.E2ApPdu{
+		E2ApPdu: &e2appdudescriptions.E2ApPdu_InitiatingMessage{
+			InitiatingMessage: &e2appdudescriptions.InitiatingMessage{
+				ProcedureCode: int32(v2.ProcedureCodeIDErrorIndication),
+				Criticality:   e2ap_commondatatypes.Criticality_CRITICALITY_IGNORE,
+				Value: &e2appdudescriptions.InitiatingMessageE2ApElementaryProcedures{
+					ImValues: &e2appdudescriptions.InitiatingMessageE2ApElementaryProcedures_ErrorIndication{
+						ErrorIndication: errorIndication,
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -5,6 +5,8 @@
 package f1ap
 
 import (
+	"fmt"
+
 	f1apv1 "github.com/onosproject/onos-e2t/api/f1ap/v1"
 	f1apcommondatatypesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_commondatatypes"
 	f1apiesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_ies"
@@ -13,6 +15,7 @@ import (
 	"github.com/onosproject/onos-e2t/pkg/southbound/f1ap/encoder"
 	"github.com/onosproject/onos-e2t/pkg/southbound/f1ap/pdubuilder"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 )
 
@@ -26,7 +29,96 @@ type SCellItemInfo struct {
 	SulFreqBandIndicationNr         int32
 	FreqBandIndicatorNr             int32
 	NrArfcn                         int32
+	Nrscs                           f1apiesv1.Nrscs
+	Nrnrb                           f1apiesv1.Nrnrb
 	MeasureTimingConfigurationBytes []byte
+	FiveGsTacBytes                  []byte
+	// ServedPlmns are the PLMNs served by the cell; the cell serves the PLMN of its NR CGI without slices if not set
+	ServedPlmns []ServedPlmnInfo
+}
+
+// ServedPlmnInfo is a PLMN served by a cell with the slices it supports
+type ServedPlmnInfo struct {
+	PlmnIDBytes []byte
+	Slices      []SliceInfo
+}
+
+// SliceInfo is the S-NSSAI of a slice; the slice differentiator is optional
+type SliceInfo struct {
+	Sst []byte
+	Sd  []byte
+}
+
+// nrbs lists the numbers of resource blocks of the transmission bandwidths of TS 38.104 by subcarrier spacing
+// in kHz and channel bandwidth in MHz
+var nrbs = map[uint32]map[uint32]uint32{
+	15:  {5: 25, 10: 52, 15: 79, 20: 106, 25: 133, 30: 160, 40: 216, 50: 270},
+	30:  {5: 11, 10: 24, 15: 38, 20: 51, 25: 65, 30: 78, 40: 106, 50: 133, 60: 162, 70: 189, 80: 217, 90: 245, 100: 273},
+	60:  {10: 11, 15: 18, 20: 24, 25: 31, 30: 38, 40: 51, 50: 65, 60: 79, 70: 93, 80: 107, 90: 121, 100: 135, 200: 264},
+	120: {50: 32, 100: 66, 200: 132, 400: 264},
+}
+
+// TransmissionBandwidth returns the NR subcarrier spacing and number of resource blocks of the transmission
+// bandwidth of a channel of the specified bandwidth in MHz with the specified subcarrier spacing in kHz
+func TransmissionBandwidth(scs uint32, bandwidth uint32) (f1apiesv1.Nrscs, f1apiesv1.Nrnrb, error) {
+	nrscs, ok := f1apiesv1.Nrscs_value[fmt.Sprintf("NRSCS_SCS%d", scs)]
+	if !ok {
+		return 0, 0, errors.NewInvalid("subcarrier spacing %d kHz is not supported", scs)
+	}
+	nrb, ok := nrbs[scs][bandwidth]
+	if !ok {
+		return 0, 0, errors.NewInvalid("channel bandwidth %d MHz is not supported with subcarrier spacing %d kHz", bandwidth, scs)
+	}
+	nrnrb, ok := f1apiesv1.Nrnrb_value[fmt.Sprintf("NRNRB_NRB%d", nrb)]
+	if !ok {
+		return 0, 0, errors.NewInvalid("%d resource blocks are not supported", nrb)
+	}
+	return f1apiesv1.Nrscs(nrscs), f1apiesv1.Nrnrb(nrnrb), nil
+}
+
+// createServedPlmnsItem creates a served PLMN item with the TAI slice support list of its slices
+func createServedPlmnsItem(servedPlmn ServedPlmnInfo) (*f1apiesv1.ServedPlmnsItem, error) {
+	plmnID, err := pdubuilder.CreatePlmnIdentity(servedPlmn.PlmnIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	plmnItem, err := pdubuilder.CreateServedPlmnsItem(plmnID)
+	if err != nil {
+		return nil, err
+	}
+	if len(servedPlmn.Slices) == 0 {
+		return plmnItem, nil
+	}
+	sliceList := make([]*f1apiesv1.SliceSupportItem, 0, len(servedPlmn.Slices))
+	for _, slice := range servedPlmn.Slices {
+		snssai := &f1apiesv1.Snssai{
+			SSt: slice.Sst,
+		}
+		if len(slice.Sd) > 0 {
+			snssai.SD = slice.Sd
+		}
+		sliceItem, err := pdubuilder.CreateSliceSupportItem(snssai)
+		if err != nil {
+			return nil, err
+		}
+		sliceList = append(sliceList, sliceItem)
+	}
+	sliceSupportList, err := pdubuilder.CreateSliceSupportList(sliceList)
+	if err != nil {
+		return nil, err
+	}
+	extension, err := pdubuilder.CreateServedPlmnsItemExtIesExtensionSliceSupportList(sliceSupportList)
+	if err != nil {
+		return nil, err
+	}
+	extIes, err := pdubuilder.CreateServedPlmnsItemExtIes(&f1apcommondatatypesv1.ProtocolExtensionId{
+		Value: int32(f1apv1.ProtocolIeIDTAISliceSupportList),
+	}, f1apcommondatatypesv1.Criticality_CRITICALITY_IGNORE, extension)
+	if err != nil {
+		return nil, err
+	}
+	plmnItem.IEExtensions = []*f1apiesv1.ServedPlmnsItemExtIes{extIes}
+	return plmnItem, nil
 }
 
 func CreateF1SetupRequest(gnbDUID int64, rrcVerBytes []byte, rrcVerLen uint32, f1apSCellItemInfo []SCellItemInfo) ([]byte, error) {
@@ -110,13 +202,19 @@ func CreateF1SetupRequest(gnbDUID int64, rrcVerBytes []byte, rrcVerLen uint32, f
 			continue
 		}
 
+		servedPlmnInfos := sCell.ServedPlmns
+		if len(servedPlmnInfos) == 0 {
+			servedPlmnInfos = []ServedPlmnInfo{{PlmnIDBytes: sCell.PlmnIDBytes}}
+		}
 		plmnList := make([]*f1apiesv1.ServedPlmnsItem, 0)
-		plmnItem, err := pdubuilder.CreateServedPlmnsItem(plmnID)
-		if err != nil {
-			log.Warnf("%+v plmnID is not valid, err: %+v", plmnID, err)
-			continue
+		for _, servedPlmn := range servedPlmnInfos {
+			plmnItem, err := createServedPlmnsItem(servedPlmn)
+			if err != nil {
+				log.Warnf("%+v served plmn is not valid, err: %+v", servedPlmn, err)
+				continue
+			}
+			plmnList = append(plmnList, plmnItem)
 		}
-		plmnList = append(plmnList, plmnItem)
 		servedPlmns, err := pdubuilder.CreateServedPlmnsList(plmnList)
 		if err != nil {
 			log.Warnf("%+v plmnIDList is not valid, err: %+v", plmnList, err)
@@ -141,11 +239,9 @@ func CreateF1SetupRequest(gnbDUID int64, rrcVerBytes []byte, rrcVerLen uint32, f
 			NRarfcn:        sCell.NrArfcn,
 			FreqBandListNr: fbnrlist,
 		}
-		transmissionBW, err := pdubuilder.CreateTransmissionBandwIDth(pdubuilder.CreateNrscsScs120(),
-			pdubuilder.CreateNrnrbNrb11())
+		transmissionBW, err := pdubuilder.CreateTransmissionBandwIDth(sCell.Nrscs, sCell.Nrnrb)
 		if err != nil {
-			log.Warnf("%+v nrSCS and/or %+v nrNRB is not valid, err: %+v", pdubuilder.CreateNrscsScs120(),
-				pdubuilder.CreateNrnrbNrb11(), err)
+			log.Warnf("%+v nrSCS and/or %+v nrNRB is not valid, err: %+v", sCell.Nrscs, sCell.Nrnrb, err)
 			continue
 		}
 		fddInfo, err := pdubuilder.CreateFddInfo(nrFreqInfo, nrFreqInfo, transmissionBW, transmissionBW)
@@ -167,6 +263,11 @@ func CreateF1SetupRequest(gnbDUID int64, rrcVerBytes []byte, rrcVerLen uint32, f
 				MeasurementTimingConfiguration: sCell.MeasureTimingConfigurationBytes,
 			},
 		}
+		if len(sCell.FiveGsTacBytes) > 0 {
+			servedCellItem.ServedCellInformation.FiveGsTac = &f1apiesv1.FiveGsTAc{
+				Value: sCell.FiveGsTacBytes,
+			}
+		}
 		servedCellItemValue, err := pdubuilder.CreateGnbDUServedCellsItemIesValueGnbDUServedCellsItem(servedCellItem)
 		if err != nil {
 			log.Warnf("%+v servedCellItem is not valid, err: %+v", servedCellItem, err)
@@ -212,3 +313,78 @@ func CreateF1SetupRequest(gnbDUID int64, rrcVerBytes []byte, rrcVerLen uint32, f
 	}
 	return encoder.PerEncodeF1ApPdu(newF1apPdu)
 }
+
+// CreateF1SetupResponse creates the F1 setup response of the gNB-CU to the F1 setup request of the specified transaction
+func CreateF1SetupResponse(transactionID int32, gnbCUName string, rrcVerBytes []byte, rrcVerLen uint32) ([]byte, error) {
+	list := make([]*f1appducontentsv1.F1SetupResponseIes, 0)
+
+	// transaction ID
+	trID, err := pdubuilder.CreateTransactionID(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	ie1Value, err := pdubuilder.CreateF1SetupResponseIesValueTransactionID(trID)
+	if err != nil {
+		return nil, err
+	}
+	ie1, err := pdubuilder.CreateF1SetupResponseIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDTransactionID)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT, ie1Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie1)
+
+	// GnbCuName
+	cuName, err := pdubuilder.CreateGnbCUName(gnbCUName)
+	if err != nil {
+		return nil, err
+	}
+	ie2Value, err := pdubuilder.CreateF1SetupResponseIesValueGnbCuName(cuName)
+	if err != nil {
+		return nil, err
+	}
+	ie2, err := pdubuilder.CreateF1SetupResponseIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDgNBCUName)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_IGNORE, ie2Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie2)
+
+	// GnbCuRRC version
+	rrcVersion, err := pdubuilder.CreateRrcVersion(&asn1.BitString{
+		Value: rrcVerBytes,
+		Len:   rrcVerLen,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ie3Value, err := pdubuilder.CreateF1SetupResponseIesValueRrcVersion(rrcVersion)
+	if err != nil {
+		return nil, err
+	}
+	ie3, err := pdubuilder.CreateF1SetupResponseIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDGNBCURRCVersion)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT, ie3Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie3)
+
+	f1SetupResponse, err := pdubuilder.CreateF1SetupResponse(list)
+	if err != nil {
+		return nil, err
+	}
+	newF1apPdu := &f1appdudescriptionsv1.F1ApPDu{
+		F1ApPdu: &f1appdudescriptionsv1.F1ApPDu_SuccessfulOutcome{
+			SuccessfulOutcome: &f1appdudescriptionsv1.SuccessfulOutcome{
+				ProcedureCode: int32(f1apv1.ProcedureCodeIDF1Setup),
+				Criticality:   f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+				Value: &f1appdudescriptionsv1.SuccessfulOutcomeF1ApElementaryProcedures{
+					SoValues: &f1appdudescriptionsv1.SuccessfulOutcomeF1ApElementaryProcedures_F1SetupResponse{
+						F1SetupResponse: f1SetupResponse,
+					},
+				},
+			},
+		},
+	}
+	return encoder.PerEncodeF1ApPdu(newF1apPdu)
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f1ap
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2t/pkg/southbound/f1ap/encoder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestF1Setup(t *testing.T) {
+	nrscs, nrnrb, err := TransmissionBandwidth(30, 100)
+	assert.NoError(t, err)
+	_, _, err = TransmissionBandwidth(30, 400)
+	assert.Error(t, err)
+
+	request, err := CreateF1SetupRequest(42, []byte{0xE0}, 3, []SCellItemInfo{
+		{
+			PlmnIDBytes:                     []byte{0x13, 0xF1, 0x84},
+			NrCellIDBytes:                   []byte{0x00, 0x00, 0x00, 0x00, 0x10},
+			NrCellIDLen:                     36,
+			NrPCI:                           11,
+			SulFreqBandIndicationNr:         80,
+			FreqBandIndicatorNr:             78,
+			NrArfcn:                         630000,
+			Nrscs:                           nrscs,
+			Nrnrb:                           nrnrb,
+			MeasureTimingConfigurationBytes: []byte{0xF1, 0xF1, 0xF1},
+			FiveGsTacBytes:                  []byte{0x00, 0x00, 0x07},
+			ServedPlmns: []ServedPlmnInfo{
+				{
+					PlmnIDBytes: []byte{0x13, 0xF1, 0x84},
+					Slices:      []SliceInfo{{Sst: []byte{0x01}, Sd: []byte{0x00, 0x00, 0x01}}, {Sst: []byte{0x02}}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	pdu, err := encoder.PerDecodeF1ApPdu(request)
+	assert.NoError(t, err)
+	ies := pdu.GetInitiatingMessage().GetValue().GetF1SetupRequest().GetProtocolIes()
+	assert.Equal(t, int64(42), ies[1].GetValue().GetGnbDuId().GetValue())
+	cell := ies[3].GetValue().GetGnbDuServedCellsList().GetValue()[0].GetValue().GetGnbDUServedCellsItem().GetServedCellInformation()
+	assert.Equal(t, []byte{0x00, 0x00, 0x07}, cell.GetFiveGsTac().GetValue())
+	assert.Equal(t, nrnrb, cell.GetNRModeInfo().GetFDd().GetDLTransmissionBandwidth().GetNRnrb())
+	slices := cell.GetServedPlmns().GetValue()[0].GetIEExtensions()[0].GetExtension().GetSliceSupportList().GetValue()
+	assert.Len(t, slices, 2)
+
+	response, err := CreateF1SetupResponse(1, "gnb-cu", []byte{0xE0}, 3)
+	assert.NoError(t, err)
+	pdu, err = encoder.PerDecodeF1ApPdu(response)
+	assert.NoError(t, err)
+	assert.NotNil(t, pdu.GetSuccessfulOutcome().GetValue().GetF1SetupResponse())
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f1ap
+
+import (
+	f1apv1 "github.com/onosproject/onos-e2t/api/f1ap/v1"
+	f1apcommondatatypesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_commondatatypes"
+	f1apiesv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_ies"
+	f1appducontentsv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_pdu_contents"
+	f1appdudescriptionsv1 "github.com/onosproject/onos-e2t/api/f1ap/v1/f1ap_pdu_descriptions"
+	"github.com/onosproject/onos-e2t/pkg/southbound/f1ap/encoder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/f1ap/pdubuilder"
+)
+
+// CreateUEContextReleaseCommand creates the F1AP UE Context Release Command the gNB-CU sends to the gNB-DU
+// to release the context of a UE, e.g. once the UE is handed over to another node
+func CreateUEContextReleaseCommand(gnbCuUeF1apID int64, gnbDuUeF1apID int64, cause f1apiesv1.CauseRadioNetwork) ([]byte, error) {
+	list := make([]*f1appducontentsv1.UecontextReleaseCommandIes, 0)
+
+	cuUeF1apID, err := pdubuilder.CreateGnbCUUEF1ApID(gnbCuUeF1apID)
+	if err != nil {
+		return nil, err
+	}
+	ie1Value, err := pdubuilder.CreateUecontextReleaseCommandIesValueGnbCuUeF1ApID(cuUeF1apID)
+	if err != nil {
+		return nil, err
+	}
+	ie1, err := pdubuilder.CreateUecontextReleaseCommandIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDgNBCUUEF1APID)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT, ie1Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie1)
+
+	duUeF1apID, err := pdubuilder.CreateGnbDUUEF1ApID(gnbDuUeF1apID)
+	if err != nil {
+		return nil, err
+	}
+	ie2Value, err := pdubuilder.CreateUecontextReleaseCommandIesValueGnbDuUeF1ApID(duUeF1apID)
+	if err != nil {
+		return nil, err
+	}
+	ie2, err := pdubuilder.CreateUecontextReleaseCommandIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDgNBDUUEF1APID)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT, ie2Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie2)
+
+	f1apCause, err := pdubuilder.CreateCauseRadioNetwork(cause)
+	if err != nil {
+		return nil, err
+	}
+	ie3Value, err := pdubuilder.CreateUecontextReleaseCommandIesValueCause(f1apCause)
+	if err != nil {
+		return nil, err
+	}
+	ie3, err := pdubuilder.CreateUecontextReleaseCommandIes(&f1apcommondatatypesv1.ProtocolIeID{Value: int32(f1apv1.ProtocolIeIDCause)},
+		f1apcommondatatypesv1.Criticality_CRITICALITY_IGNORE, ie3Value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, ie3)
+
+	releaseCommand, err := pdubuilder.CreateUecontextReleaseCommand(list)
+	if err != nil {
+		return nil, err
+	}
+	newF1apPdu := &f1appdudescriptionsv1.F1ApPDu{
+		F1ApPdu: &f1appdudescriptionsv1.F1ApPDu_InitiatingMessage{
+			InitiatingMessage: &f1appdudescriptionsv1.InitiatingMessage{
+				ProcedureCode: int32(f1apv1.ProcedureCodeIDUEContextRelease),
+				Criticality:   f1apcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+				Value: &f1appdudescriptionsv1.InitiatingMessageF1ApElementaryProcedures{
+					ImValues: &f1appdudescriptionsv1.InitiatingMessageF1ApElementaryProcedures_UEcontextReleaseCommand{
+						UEcontextReleaseCommand: releaseCommand,
+					},
+				},
+			},
+		},
+	}
+	return encoder.PerEncodeF1ApPdu(newF1apPdu)
+}
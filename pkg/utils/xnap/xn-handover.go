@@ -0,0 +1,423 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package xnap
+
+import (
+	v1 "github.com/onosproject/onos-e2t/api/xnap/v1"
+	"github.com/onosproject/onos-e2t/api/xnap/v1/choiceOptions"
+	xnapcommondatatypesv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-commondatatypes"
+	xnapiesv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-ies"
+	xnappducontentsv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-pdu-contents"
+	"github.com/onosproject/onos-e2t/pkg/southbound/xnap/pdubuilder"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/onos-lib-go/pkg/asn1/aper"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// XnItemHandoverRequest is the information of a UE handed over to a cell of another node
+type XnItemHandoverRequest struct {
+	PlmnIDBytes          []byte
+	SourceUeXnApID       int64
+	SourceNrCellIDBytes  []byte
+	TargetNrCellIDBytes  []byte
+	NrCellIDLen          uint32
+	AmfRegion            XnItemAMFRegion
+	AmfUeNgapID          int64
+	Slice                XnItemSlice
+	FiveQI               int32
+	TransportAddressIPv4 []byte
+}
+
+var (
+	defaultAmfSetID          = &asn1.BitString{Value: []byte{0x00, 0x40}, Len: 10}
+	defaultAmfPointer        = &asn1.BitString{Value: []byte{0x04}, Len: 6}
+	defaultSecurityAlgorithm = &asn1.BitString{Value: []byte{0xe0, 0x00}, Len: 16}
+	defaultKeyNgRanStar      = &asn1.BitString{Value: make([]byte, 32), Len: 256}
+	defaultGtpTeID           = []byte{0x00, 0x00, 0x00, 0x01}
+	defaultUeAmbr            = int64(1000000000)
+	defaultPduSessionID      = int32(1)
+	defaultQoSFlowID         = int32(1)
+	defaultPriorityLevel     = int32(1)
+	// defaultHandoverCommand is the RRC handover command of the target node carried to the UE by the source node
+	defaultHandoverCommand = string([]byte{0x00})
+)
+
+// XnItemDrbStatus is the PDCP status of a DRB of a UE handed over to another node
+type XnItemDrbStatus struct {
+	DrbID int32
+	// UlPdcpSn and UlHfn are the COUNT of the first missing uplink PDCP SDU
+	UlPdcpSn int32
+	UlHfn    int32
+	// DlPdcpSn and DlHfn are the COUNT the target node assigns to the next downlink PDCP SDU
+	DlPdcpSn int32
+	DlHfn    int32
+}
+
+// CreateHandoverRequest creates the XnAP Handover Request the source node sends to the target node of a handover
+func CreateHandoverRequest(request XnItemHandoverRequest) ([]byte, error) {
+	plmnID, err := pdubuilder.CreatePlmnIdentity(request.PlmnIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	targetNrCellIdentity, err := pdubuilder.CreateNrCellIdentity(&asn1.BitString{
+		Value: request.TargetNrCellIDBytes,
+		Len:   request.NrCellIDLen,
+	})
+	if err != nil {
+		return nil, err
+	}
+	targetNcgi, err := pdubuilder.CreateNrCGi(plmnID, targetNrCellIdentity)
+	if err != nil {
+		return nil, err
+	}
+	guami, err := pdubuilder.CreateGuami(plmnID, &asn1.BitString{
+		Value: request.AmfRegion.AmfRegionID,
+		Len:   request.AmfRegion.AmfRegionIDLen,
+	}, defaultAmfSetID, defaultAmfPointer)
+	if err != nil {
+		return nil, err
+	}
+	tnlAddress := &xnapiesv1.TransportLayerAddress{
+		Value: &asn1.BitString{
+			Value: request.TransportAddressIPv4,
+			Len:   uint32(len(request.TransportAddressIPv4) * 8),
+		},
+	}
+
+	ueContextInfo := &xnappducontentsv1.UecontextInfoHorequest{
+		NgCUeReference: &xnapiesv1.AmfUENGapID{
+			Value: request.AmfUeNgapID,
+		},
+		CpTnlInfoSource: &xnapiesv1.CptransportLayerInformation{
+			CptransportLayerInformation: &xnapiesv1.CptransportLayerInformation_EndpointIpaddress{
+				EndpointIpaddress: tnlAddress,
+			},
+		},
+		UeSecurityCapabilities: &xnapiesv1.UesecurityCapabilities{
+			NrEncyptionAlgorithms:              defaultSecurityAlgorithm,
+			NrIntegrityProtectionAlgorithms:    defaultSecurityAlgorithm,
+			EUtraEncyptionAlgorithms:           defaultSecurityAlgorithm,
+			EUtraIntegrityProtectionAlgorithms: defaultSecurityAlgorithm,
+		},
+		SecurityInformation: &xnapiesv1.AsSecurityInformation{
+			KeyNgRanStar: defaultKeyNgRanStar,
+			Ncc:          0,
+		},
+		UeAmbr: &xnapiesv1.UeaggregateMaximumBitRate{
+			DlUeAmbr: &xnapiesv1.BitRate{Value: defaultUeAmbr},
+			UlUeAmbr: &xnapiesv1.BitRate{Value: defaultUeAmbr},
+		},
+		PduSessionResourcesToBeSetupList: &xnapiesv1.PdusessionResourcesToBeSetupList{
+			Value: []*xnapiesv1.PdusessionResourcesToBeSetupItem{
+				{
+					PduSessionId: &xnapiesv1.PdusessionID{Value: defaultPduSessionID},
+					SNssai: &xnapiesv1.SNSsai{
+						Sst: request.Slice.Sst,
+						Sd:  request.Slice.Sd,
+					},
+					ULNgUTnlatUpf: &xnapiesv1.UptransportLayerInformation{
+						UptransportLayerInformation: &xnapiesv1.UptransportLayerInformation_GtpTunnel{
+							GtpTunnel: &xnapiesv1.GtptunnelTransportLayerInformation{
+								TnlAddress: tnlAddress,
+								GtpTeid:    &xnapiesv1.GtpTEid{Value: defaultGtpTeID},
+							},
+						},
+					},
+					PduSessionType: pdubuilder.CreatePdusessionTypeIpv4(),
+					QosFlowsToBeSetupList: &xnapiesv1.QoSflowsToBeSetupList{
+						Value: []*xnapiesv1.QoSflowsToBeSetupItem{
+							{
+								Qfi: &xnapiesv1.QoSflowIdentifier{Value: defaultQoSFlowID},
+								QosFlowLevelQoSparameters: &xnapiesv1.QoSflowLevelQoSparameters{
+									QosCharacteristics: &xnapiesv1.QoScharacteristics{
+										QoScharacteristics: &xnapiesv1.QoScharacteristics_NonDynamic{
+											NonDynamic: &xnapiesv1.NonDynamic5Qidescriptor{
+												FiveQi: &xnapiesv1.FiveQi{Value: request.FiveQI},
+											},
+										},
+									},
+									AllocationAndRetentionPrio: &xnapiesv1.AllocationandRetentionPriority{
+										PriorityLevel:           defaultPriorityLevel,
+										PreEmptionCapability:    xnapiesv1.PreemptioncapabilityAllocationandRetentionPriority_PREEMPTIONCAPABILITY_ALLOCATIONAND_RETENTION_PRIORITY_SHALL_NOT_TRIGGER_PREEMPTDAT_DION,
+										PreEmptionVulnerability: xnapiesv1.PreemptionvulnerabilityAllocationandRetentionPriority_PREEMPTIONVULNERABILITY_ALLOCATIONAND_RETENTION_PRIORITY_NOT_PREEMPTABLE,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		RrcContext: []byte{0x00},
+	}
+
+	list := []*xnappducontentsv1.HandoverRequestIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDsourceNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdSourceNgRannodeUexnApid{
+					IdSourceNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: request.SourceUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDCause)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdCause{
+					IdCause: &xnapiesv1.Cause{
+						Cause: &xnapiesv1.Cause_RadioNetwork{
+							RadioNetwork: pdubuilder.CreateCauseRadioNetworkLayerHandoverDesirableForRadioReasons(),
+						},
+					},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDtargetCellGlobalID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdTargetCellGlobalId{
+					IdTargetCellGlobalId: &xnapiesv1.TargetCGi{
+						TargetCgi: &xnapiesv1.TargetCGi_Nr{
+							Nr: targetNcgi,
+						},
+					},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDGUAMI)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdGuami{
+					IdGuami: guami,
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDUEContextInfoHORequest)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdUecontextInfoHorequest{
+					IdUecontextInfoHorequest: ueContextInfo,
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDUEHistoryInformation)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+			Value: &xnappducontentsv1.HandoverRequestIEsValue{
+				HandoverRequestIes: &xnappducontentsv1.HandoverRequestIEsValue_IdUehistoryInformation{
+					IdUehistoryInformation: &xnapiesv1.UehistoryInformation{
+						Value: []*xnapiesv1.LastVisitedCellItem{
+							{
+								LastVisitedCellItem: &xnapiesv1.LastVisitedCellItem_NGRanCell{
+									NGRanCell: &xnapiesv1.LastVisitedNgrancellInformation{
+										Value: append(append([]byte{}, request.PlmnIDBytes...), request.SourceNrCellIDBytes...),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	handoverRequest, err := pdubuilder.CreateHandoverRequest(list)
+	if err != nil {
+		return nil, err
+	}
+	return encodeInitiatingMessage(v1.ProcedureCodeIDhandoverPreparation, xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT, handoverRequest)
+}
+
+// CreateHandoverRequestAcknowledge creates the XnAP Handover Request Acknowledge the target node returns to the
+// source node once it admitted the PDU session of the UE
+func CreateHandoverRequestAcknowledge(sourceUeXnApID int64, targetUeXnApID int64) ([]byte, error) {
+	list := []*xnappducontentsv1.HandoverRequestAcknowledgeIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDsourceNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+			Value: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue{
+				HandoverRequestAcknowledgeIes: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue_IdSourceNgRannodeUexnApid{
+					IdSourceNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: sourceUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDtargetNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+			Value: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue{
+				HandoverRequestAcknowledgeIes: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue_IdTargetNgRannodeUexnApid{
+					IdTargetNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: targetUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDPDUSessionResourcesAdmittedList)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+			Value: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue{
+				HandoverRequestAcknowledgeIes: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue_IdPdusessionResourcesAdmittedList{
+					IdPdusessionResourcesAdmittedList: &xnapiesv1.PdusessionResourcesAdmittedList{
+						Value: []*xnapiesv1.PdusessionResourcesAdmittedItem{
+							{
+								PduSessionId: &xnapiesv1.PdusessionID{Value: defaultPduSessionID},
+								PduSessionResourceAdmittedInfo: &xnapiesv1.PdusessionResourceAdmittedInfo{
+									QosFlowsAdmittedList: &xnapiesv1.QoSflowsAdmittedList{
+										Value: []*xnapiesv1.QoSflowsAdmittedItem{
+											{
+												Qfi: &xnapiesv1.QoSflowIdentifier{Value: defaultQoSFlowID},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDTarget2SourceNGRANnodeTranspContainer)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue{
+				HandoverRequestAcknowledgeIes: &xnappducontentsv1.HandoverRequestAcknowledgeIEsValue_IdTarget2SourceNgRannodeTranspContainer{
+					IdTarget2SourceNgRannodeTranspContainer: defaultHandoverCommand,
+				},
+			},
+		},
+	}
+	handoverRequestAcknowledge, err := pdubuilder.CreateHandoverRequestAcknowledge(list)
+	if err != nil {
+		return nil, err
+	}
+	return encodeSuccessfulOutcome(v1.ProcedureCodeIDhandoverPreparation, xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT, handoverRequestAcknowledge)
+}
+
+// CreateSNStatusTransfer creates the XnAP SN Status Transfer the source node sends to the target node of a
+// handover with the PDCP status of the DRBs of the UE
+func CreateSNStatusTransfer(sourceUeXnApID int64, targetUeXnApID int64, drbs []XnItemDrbStatus) ([]byte, error) {
+	drbList := make([]*xnapiesv1.DrbsSubjectToStatusTransferItem, 0, len(drbs))
+	for _, drb := range drbs {
+		drbList = append(drbList, &xnapiesv1.DrbsSubjectToStatusTransferItem{
+			DrbId:                &xnapiesv1.DrbID{Value: drb.DrbID},
+			PdcpStatusTransferUl: createDrbStatusTransfer(drb.UlPdcpSn, drb.UlHfn),
+			PdcpStatusTransferDl: createDrbStatusTransfer(drb.DlPdcpSn, drb.DlHfn),
+		})
+	}
+	list := []*xnappducontentsv1.SnstatusTransferIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDsourceNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.SnstatusTransferIEsValue{
+				SnstatusTransferIes: &xnappducontentsv1.SnstatusTransferIEsValue_IdSourceNgRannodeUexnApid{
+					IdSourceNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: sourceUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDtargetNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.SnstatusTransferIEsValue{
+				SnstatusTransferIes: &xnappducontentsv1.SnstatusTransferIEsValue_IdTargetNgRannodeUexnApid{
+					IdTargetNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: targetUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDDRBsSubjectToStatusTransferList)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.SnstatusTransferIEsValue{
+				SnstatusTransferIes: &xnappducontentsv1.SnstatusTransferIEsValue_IdDrbsSubjectToStatusTransferList{
+					IdDrbsSubjectToStatusTransferList: &xnapiesv1.DrbsSubjectToStatusTransferList{
+						Value: drbList,
+					},
+				},
+			},
+		},
+	}
+	snStatusTransfer, err := pdubuilder.CreateSnstatusTransfer(list)
+	if err != nil {
+		return nil, err
+	}
+	return encodeInitiatingMessage(v1.ProcedureCodeIDsNStatusTransfer, xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE, snStatusTransfer)
+}
+
+// createDrbStatusTransfer creates the status of a DRB with 12 bit PDCP sequence numbers
+func createDrbStatusTransfer(pdcpSn int32, hfn int32) *xnapiesv1.DrbbstatusTransferChoice {
+	return &xnapiesv1.DrbbstatusTransferChoice{
+		DrbbstatusTransferChoice: &xnapiesv1.DrbbstatusTransferChoice_PdcpSn_12Bits{
+			PdcpSn_12Bits: &xnapiesv1.DrbbstatusTransfer12BitsSn{
+				COuntvalue: &xnapiesv1.CountPDcpSN12{
+					PdcpSn12:    pdcpSn,
+					HfnPdcpSn12: hfn,
+				},
+			},
+		},
+	}
+}
+
+// CreateUEContextRelease creates the XnAP UE Context Release the target node sends to the source node
+// once a handover is completed
+func CreateUEContextRelease(sourceUeXnApID int64, targetUeXnApID int64) ([]byte, error) {
+	list := []*xnappducontentsv1.UecontextReleaseIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDsourceNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.UecontextReleaseIEsValue{
+				UecontextReleaseIes: &xnappducontentsv1.UecontextReleaseIEsValue_IdSourceNgRannodeUexnApid{
+					IdSourceNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: sourceUeXnApID},
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDtargetNGRANnodeUEXnAPID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.UecontextReleaseIEsValue{
+				UecontextReleaseIes: &xnappducontentsv1.UecontextReleaseIEsValue_IdTargetNgRannodeUexnApid{
+					IdTargetNgRannodeUexnApid: &xnapiesv1.NgRAnnodeUexnApid{Value: targetUeXnApID},
+				},
+			},
+		},
+	}
+	return encodeInitiatingMessage(v1.ProcedureCodeIDuEContextRelease, xnapcommondatatypesv1.Criticality_CRITICALITY_IGNORE,
+		&xnappducontentsv1.UecontextRelease{ProtocolIes: list})
+}
+
+// encodeInitiatingMessage encodes an XnAP-PDU initiating message. The XnAP-PDU of onos-e2t only defines the
+// Xn Setup procedure, so the message is encoded on its own and wrapped into the PDU as an open type.
+func encodeInitiatingMessage(procedureCode v1.ProcedureCodeT, criticality xnapcommondatatypesv1.Criticality, message interface{}) ([]byte, error) {
+	return encodeMessage(initiatingMessage, procedureCode, criticality, message)
+}
+
+// encodeSuccessfulOutcome encodes an XnAP-PDU successful outcome the same way as an initiating message
+func encodeSuccessfulOutcome(procedureCode v1.ProcedureCodeT, criticality xnapcommondatatypesv1.Criticality, message interface{}) ([]byte, error) {
+	return encodeMessage(successfulOutcome, procedureCode, criticality, message)
+}
+
+// choice indexes of the XnAP-PDU, padded to an octet
+const (
+	initiatingMessage = byte(0x00)
+	successfulOutcome = byte(0x20)
+)
+
+func encodeMessage(choice byte, procedureCode v1.ProcedureCodeT, criticality xnapcommondatatypesv1.Criticality, message interface{}) ([]byte, error) {
+	value, err := aper.MarshalWithParams(message, "valueExt", choiceOptions.XnapChoicemap, choiceOptions.XnapCanonicalChoicemap)
+	if err != nil {
+		return nil, err
+	}
+	pdu := []byte{choice, byte(procedureCode), byte(criticality) << 6}
+	switch {
+	case len(value) < 128:
+		pdu = append(pdu, byte(len(value)))
+	case len(value) < 16384:
+		pdu = append(pdu, 0x80|byte(len(value)>>8), byte(len(value)))
+	default:
+		return nil, errors.NewInvalid("XnAP message of %d bytes needs to be fragmented", len(value))
+	}
+	return append(pdu, value...), nil
+}
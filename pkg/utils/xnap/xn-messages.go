@@ -41,11 +41,17 @@ type XnItemCellInfo struct {
 	FreqBand                        int32
 	MeasureTimingConfigurationBytes []byte
 	RanAC                           int32
+	// TacBytes is the tracking area code of the cell; the one of the node is used if not set
+	TacBytes []byte
+	// Nrscs and Nrnrb are the transmission bandwidth of a served cell
+	Nrscs xnapiesv1.Nrscs
+	Nrnrb xnapiesv1.Nrnrb
 }
 
 var log = logging.GetLogger()
 
-// amfregionid 0xdd
+// CreateXnSetupRequest creates the Xn Setup Request of a node from its tracking area, slices, AMF region, served
+// cells and the neighbours of each served cell
 func CreateXnSetupRequest(plmnIDByte []byte, gnbIDByte []byte, tacBytes []byte, xnItemSliceList []XnItemSlice, xnAmfRegion XnItemAMFRegion, xnSCellItemInfo []XnItemCellInfo, xnNeighborCells map[types.NCGI][]XnItemCellInfo) ([]byte, error) {
 	list := make([]*xnappducontentsv1.XnSetupRequestIEs, 0)
 
@@ -54,39 +60,281 @@ func CreateXnSetupRequest(plmnIDByte []byte, gnbIDByte []byte, tacBytes []byte,
 		return nil, err
 	}
 
-	gnbID, err := pdubuilder.CreateGnbIDChoiceGnbID(&asn1.BitString{
-		Value: gnbIDByte,
-		Len:   22,
-	})
+	ranNodeID, err := createGlobalNgRanNodeID(plmnID, gnbIDByte)
 	if err != nil {
 		return nil, err
 	}
 
-	globalGnBID, err := pdubuilder.CreateGlobalgNbID(plmnID, gnbID)
+	val, err := pdubuilder.CreateXnSetupRequestIEsValueIDGlobalNgRanNodeID(ranNodeID)
 	if err != nil {
 		return nil, err
 	}
 
-	ranNodeID, err := pdubuilder.CreateGlobalNgRAnnodeIDGNb(globalGnBID)
+	item1 := &xnappducontentsv1.XnSetupRequestIEs{
+		Id:          int32(v1.ProtocolIeIDGlobalNGRANnodeID),
+		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+		Value:       val,
+	}
+	list = append(list, item1)
+
+	taiSupportList, err := createTaiSupportList(plmnIDByte, tacBytes, xnItemSliceList)
+	if err != nil {
+		return nil, err
+	}
+	val2, err := pdubuilder.CreateXnSetupRequestIEsValueIDTaisupportList(taiSupportList)
 	if err != nil {
 		return nil, err
 	}
 
-	val, err := pdubuilder.CreateXnSetupRequestIEsValueIDGlobalNgRanNodeID(ranNodeID)
+	item2 := &xnappducontentsv1.XnSetupRequestIEs{
+		Id:          int32(v1.ProtocolIeIDTAISupportlist),
+		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+		Value:       val2,
+	}
+	list = append(list, item2)
+
+	amfInfoList := make([]*xnapiesv1.GlobalAmfRegionInformation, 0)
+	amfInfoItem, err := pdubuilder.CreateGlobalAmfRegionInformation(plmnID, &asn1.BitString{
+		Value: xnAmfRegion.AmfRegionID,
+		Len:   xnAmfRegion.AmfRegionIDLen,
+	})
 	if err != nil {
 		return nil, err
 	}
+	amfInfoList = append(amfInfoList, amfInfoItem)
 
-	item1 := &xnappducontentsv1.XnSetupRequestIEs{
-		Id:          int32(v1.ProtocolIeIDGlobalNGRANnodeID),
+	val3, err := pdubuilder.CreateXnSetupRequestIEsValueIDAmfRegionInformation(&xnapiesv1.AmfRegionInformation{
+		Value: amfInfoList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	item3 := &xnappducontentsv1.XnSetupRequestIEs{
+		Id:          int32(v1.ProtocolIeIDAMFRegionInformation),
 		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
-		Value:       val,
+		Value:       val3,
 	}
-	list = append(list, item1)
+	list = append(list, item3)
+
+	servedCellsNR, err := createServedCellsNR(plmnID, tacBytes, xnSCellItemInfo, xnNeighborCells)
+	if err != nil {
+		return nil, err
+	}
+	val4, err := pdubuilder.CreateXnSetupRequestIEsValueIDListOfServedCellsNr(servedCellsNR)
+	if err != nil {
+		return nil, err
+	}
+	item4 := &xnappducontentsv1.XnSetupRequestIEs{
+		Id:          int32(v1.ProtocolIeIDListofservedcellsNR),
+		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+		Value:       val4,
+	}
+	list = append(list, item4)
+
+	xnSetupRequest, err := pdubuilder.CreateXnSetupRequest(list)
+	if err != nil {
+		return nil, err
+	}
+	newXnApPdu := &xnappdudescriptionsv1.XnApPDu{
+		XnApPdu: &xnappdudescriptionsv1.XnApPDu_InitiatingMessage{
+			InitiatingMessage: &xnappdudescriptionsv1.InitiatingMessage{
+				ProcedureCode: int32(v1.ProcedureCodeIDxnSetup),
+				Criticality:   xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+				Value: &xnappdudescriptionsv1.InitiatingMessageXnApElementaryProcedures{
+					ImValues: &xnappdudescriptionsv1.InitiatingMessageXnApElementaryProcedures_XnSetupRequest{
+						XnSetupRequest: xnSetupRequest,
+					},
+				},
+			},
+		},
+	}
+
+	return encoder.PerEncodeXnApPdu(newXnApPdu)
+}
+
+// CreateXnSetupResponse creates the Xn Setup Response of a node from its tracking area, slices, served cells and the
+// neighbours of each served cell
+func CreateXnSetupResponse(plmnIDByte []byte, gnbIDByte []byte, tacBytes []byte, xnItemSliceList []XnItemSlice, xnSCellItemInfo []XnItemCellInfo, xnNeighborCells map[types.NCGI][]XnItemCellInfo) ([]byte, error) {
+	plmnID, err := pdubuilder.CreatePlmnIdentity(plmnIDByte)
+	if err != nil {
+		return nil, err
+	}
+	ranNodeID, err := createGlobalNgRanNodeID(plmnID, gnbIDByte)
+	if err != nil {
+		return nil, err
+	}
+	taiSupportList, err := createTaiSupportList(plmnIDByte, tacBytes, xnItemSliceList)
+	if err != nil {
+		return nil, err
+	}
+	servedCellsNR, err := createServedCellsNR(plmnID, tacBytes, xnSCellItemInfo, xnNeighborCells)
+	if err != nil {
+		return nil, err
+	}
+
+	val1, err := pdubuilder.CreateXnSetupResponseIEsValueIDGlobalNgRanNodeID(ranNodeID)
+	if err != nil {
+		return nil, err
+	}
+	val2, err := pdubuilder.CreateXnSetupResponseIEsValueIDTaisupportList(taiSupportList)
+	if err != nil {
+		return nil, err
+	}
+	val3, err := pdubuilder.CreateXnSetupResponseIEsValueIDListOfServedCellsNr(servedCellsNR)
+	if err != nil {
+		return nil, err
+	}
+	list := []*xnappducontentsv1.XnSetupResponseIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDGlobalNGRANnodeID)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value:       val1,
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDTAISupportlist)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value:       val2,
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDListofservedcellsNR)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value:       val3,
+		},
+	}
+	xnSetupResponse, err := pdubuilder.CreateXnSetupResponse(list)
+	if err != nil {
+		return nil, err
+	}
+	newXnApPdu := &xnappdudescriptionsv1.XnApPDu{
+		XnApPdu: &xnappdudescriptionsv1.XnApPDu_SuccessfulOutcome{
+			SuccessfulOutcome: &xnappdudescriptionsv1.SuccessfulOutcome{
+				ProcedureCode: int32(v1.ProcedureCodeIDxnSetup),
+				Criticality:   xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+				Value: &xnappdudescriptionsv1.SuccessfulOutcomeXnApElementaryProcedures{
+					SoValues: &xnappdudescriptionsv1.SuccessfulOutcomeXnApElementaryProcedures_XnSetupResponse{
+						XnSetupResponse: xnSetupResponse,
+					},
+				},
+			},
+		},
+	}
+
+	return encoder.PerEncodeXnApPdu(newXnApPdu)
+}
+
+// CreateNgRanNodeConfigurationUpdate creates the NG-RAN Node Configuration Update a node sends to its Xn peers when
+// the served cells or their neighbours change; the modified cells are sent with their current neighbours
+func CreateNgRanNodeConfigurationUpdate(plmnIDByte []byte, tacBytes []byte, xnItemSliceList []XnItemSlice, xnModifiedCells []XnItemCellInfo, xnNeighborCells map[types.NCGI][]XnItemCellInfo) ([]byte, error) {
+	plmnID, err := pdubuilder.CreatePlmnIdentity(plmnIDByte)
+	if err != nil {
+		return nil, err
+	}
+	taiSupportList, err := createTaiSupportList(plmnIDByte, tacBytes, xnItemSliceList)
+	if err != nil {
+		return nil, err
+	}
+
+	servedCellsToModify := make([]*xnapiesv1.ServedCellsToModifyNRItem, 0)
+	for _, cell := range xnModifiedCells {
+		servedCellInfoNr, neighbourInfoNr, err := createServedCellInformationNR(plmnID, tacBytes, cell, xnNeighborCells[cell.NCGIKey])
+		if err != nil {
+			return nil, err
+		}
+		if servedCellInfoNr == nil {
+			continue
+		}
+		servedCellsToModify = append(servedCellsToModify, &xnapiesv1.ServedCellsToModifyNRItem{
+			OldNrCgi:         servedCellInfoNr.CellId,
+			ServedCellInfoNr: servedCellInfoNr,
+			NeighbourInfoNr:  neighbourInfoNr,
+		})
+	}
+	servedCellsToModifyNR, err := pdubuilder.CreateServedCellsToModifyNR(servedCellsToModify)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*xnappducontentsv1.NgrannodeConfigurationUpdateIEs{
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDTAISupportlist)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.NgrannodeConfigurationUpdateIEsValue{
+				NgrannodeConfigurationUpdateIes: &xnappducontentsv1.NgrannodeConfigurationUpdateIEsValue_IdTaisupportList{
+					IdTaisupportList: taiSupportList,
+				},
+			},
+		},
+		{
+			Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDConfigurationUpdateInitiatingNodeChoice)},
+			Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+			Value: &xnappducontentsv1.NgrannodeConfigurationUpdateIEsValue{
+				NgrannodeConfigurationUpdateIes: &xnappducontentsv1.NgrannodeConfigurationUpdateIEsValue_IdConfigurationUpdateInitiatingNodeChoice{
+					IdConfigurationUpdateInitiatingNodeChoice: &xnappducontentsv1.ConfigurationUpdateInitiatingNodeChoice{
+						ConfigurationUpdateInitiatingNodeChoice: &xnappducontentsv1.ConfigurationUpdateInitiatingNodeChoice_GNb{
+							GNb: &xnappducontentsv1.ConfigurationUpdategNb{
+								Id:          &xnapcommondatatypesv1.ProtocolIeID{Value: int32(v1.ProtocolIeIDservedCellsToUpdateNR)},
+								Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
+								Value: &xnappducontentsv1.ConfigurationUpdategNbValue{
+									ConfigurationUpdateGNb: &xnappducontentsv1.ConfigurationUpdategNbValue_IdServedCellsToUpdateNr{
+										IdServedCellsToUpdateNr: &xnapiesv1.ServedCellsToUpdateNR{
+											ServedCellsToModifyNr: servedCellsToModifyNR,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	configurationUpdate, err := pdubuilder.CreateNgrannodeConfigurationUpdate(list)
+	if err != nil {
+		return nil, err
+	}
+	return encodeInitiatingMessage(v1.ProcedureCodeIDnGRANnodeConfigurationUpdate, xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT, configurationUpdate)
+}
 
-	// creating TAIsupportList
-	taiSupportList := make([]*xnapiesv1.TaisupportItem, 0)
+// createGlobalNgRanNodeID creates the global ID of a gNB
+func createGlobalNgRanNodeID(plmnID *xnapiesv1.PlmnIdentity, gnbIDByte []byte) (*xnapiesv1.GlobalNgRAnnodeID, error) {
+	gnbID, err := pdubuilder.CreateGnbIDChoiceGnbID(&asn1.BitString{
+		Value: gnbIDByte,
+		Len:   22,
+	})
+	if err != nil {
+		return nil, err
+	}
+	globalGnBID, err := pdubuilder.CreateGlobalgNbID(plmnID, gnbID)
+	if err != nil {
+		return nil, err
+	}
+	return pdubuilder.CreateGlobalNgRAnnodeIDGNb(globalGnBID)
+}
+
+// createServedCellsNR creates the served cells of a node with the neighbours of each served cell
+func createServedCellsNR(plmnID *xnapiesv1.PlmnIdentity, tacBytes []byte, xnSCellItemInfo []XnItemCellInfo, xnNeighborCells map[types.NCGI][]XnItemCellInfo) (*xnapiesv1.ServedCellsNR, error) {
+	servedCellsNRList := make([]*xnapiesv1.ServedCellsNRItem, 0)
+	for _, servCell := range xnSCellItemInfo {
+		servedCellInfoNr, neighbourInfoNr, err := createServedCellInformationNR(plmnID, tacBytes, servCell, xnNeighborCells[servCell.NCGIKey])
+		if err != nil {
+			return nil, err
+		}
+		if servedCellInfoNr == nil {
+			continue
+		}
+		nrItem := &xnapiesv1.ServedCellsNRItem{
+			ServedCellInfoNr: servedCellInfoNr,
+			NeighbourInfoNr:  neighbourInfoNr,
+		}
+		servedCellsNRList = append(servedCellsNRList, nrItem)
+	}
+	return &xnapiesv1.ServedCellsNR{
+		Value: servedCellsNRList,
+	}, nil
+}
 
+// createTaiSupportList creates the tracking area supported by a node with the slices it supports
+func createTaiSupportList(plmnIDByte []byte, tacBytes []byte, xnItemSliceList []XnItemSlice) (*xnapiesv1.TaisupportList, error) {
 	tac, err := pdubuilder.CreateTac(tacBytes)
 	if err != nil {
 		return nil, err
@@ -105,7 +353,6 @@ func CreateXnSetupRequest(plmnIDByte []byte, gnbIDByte []byte, tacBytes []byte,
 		return nil, err
 	}
 
-	broadcastPlmns := make([]*xnapiesv1.BroadcastPlmninTaisupportItem, 0)
 	broadcastPlmn, err := pdubuilder.CreatePlmnIdentity(plmnIDByte)
 	if err != nil {
 		return nil, err
@@ -114,242 +361,154 @@ func CreateXnSetupRequest(plmnIDByte []byte, gnbIDByte []byte, tacBytes []byte,
 	if err != nil {
 		return nil, err
 	}
-	broadcastPlmns = append(broadcastPlmns, broadcastPlmnItem)
 
-	taiItem, err := pdubuilder.CreateTaisupportItem(tac, broadcastPlmns)
+	taiItem, err := pdubuilder.CreateTaisupportItem(tac, []*xnapiesv1.BroadcastPlmninTaisupportItem{broadcastPlmnItem})
 	if err != nil {
 		return nil, err
 	}
-	taiSupportList = append(taiSupportList, taiItem)
+	return &xnapiesv1.TaisupportList{
+		Value: []*xnapiesv1.TaisupportItem{taiItem},
+	}, nil
+}
 
-	val2, err := pdubuilder.CreateXnSetupRequestIEsValueIDTaisupportList(&xnapiesv1.TaisupportList{
-		Value: taiSupportList,
-	})
+// createNrFrequencyInfo creates the frequency information of a cell
+func createNrFrequencyInfo(cell XnItemCellInfo) (*xnapiesv1.NrfrequencyInfo, error) {
+	freqBand, err := pdubuilder.CreateNrfrequencyBand(cell.FreqBand)
 	if err != nil {
 		return nil, err
 	}
+	freqBandItem := &xnapiesv1.NrfrequencyBandItem{
+		NrFrequencyBand: freqBand,
+		SupportedSulBandList: &xnapiesv1.SupportedSulbandList{
+			Value: []*xnapiesv1.SupportedSulbandItem{
+				{
+					SulBandItem: &xnapiesv1.SulFrequencyBand{
+						Value: cell.SulFreqBand,
+					},
+				},
+			},
+		},
+	}
+	return &xnapiesv1.NrfrequencyInfo{
+		NrArfcn: &xnapiesv1.Nrarfcn{
+			Value: cell.NrArfcn,
+		},
+		FrequencyBandList: &xnapiesv1.NrfrequencyBandList{
+			Value: []*xnapiesv1.NrfrequencyBandItem{freqBandItem},
+		},
+	}, nil
+}
 
-	item2 := &xnappducontentsv1.XnSetupRequestIEs{
-		Id:          int32(v1.ProtocolIeIDTAISupportlist),
-		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
-		Value:       val2,
+// cellTac returns the tracking area code of a cell, defaulting to the one of its node
+func cellTac(cell XnItemCellInfo, tacBytes []byte) []byte {
+	if len(cell.TacBytes) > 0 {
+		return cell.TacBytes
 	}
-	list = append(list, item2)
+	return tacBytes
+}
 
-	amfInfoList := make([]*xnapiesv1.GlobalAmfRegionInformation, 0)
-	amfInfoItem, err := pdubuilder.CreateGlobalAmfRegionInformation(plmnID, &asn1.BitString{
-		Value: xnAmfRegion.AmfRegionID,
-		Len:   xnAmfRegion.AmfRegionIDLen,
+// createServedCellInformationNR creates the information of a served cell and of its neighbours; no information
+// is returned if the cell ID is not valid
+func createServedCellInformationNR(plmnID *xnapiesv1.PlmnIdentity, tacBytes []byte, servCell XnItemCellInfo, neighborCells []XnItemCellInfo) (*xnapiesv1.ServedCellInformationNR, *xnapiesv1.NeighbourInformationNR, error) {
+	nrCellidentity, err := pdubuilder.CreateNrCellIdentity(&asn1.BitString{
+		Value: servCell.NrCellIDBytes,
+		Len:   servCell.NrCellIDLen,
 	})
 	if err != nil {
-		return nil, err
+		log.Warnf("%+v NrCellIDBytes and/or %+v NrCellIDLen is not valid, err: %+v", servCell.NrCellIDBytes, servCell.NrCellIDLen, err)
+		return nil, nil, nil
+	}
+	ncgi, err := pdubuilder.CreateNrCGi(plmnID, nrCellidentity)
+	if err != nil {
+		return nil, nil, err
 	}
-	amfInfoList = append(amfInfoList, amfInfoItem)
 
-	val3, err := pdubuilder.CreateXnSetupRequestIEsValueIDAmfRegionInformation(&xnapiesv1.AmfRegionInformation{
-		Value: amfInfoList,
-	})
+	nrfreqInfo, err := createNrFrequencyInfo(servCell)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	item3 := &xnappducontentsv1.XnSetupRequestIEs{
-		Id:          int32(v1.ProtocolIeIDAMFRegionInformation),
-		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
-		Value:       val3,
+	transmBW, err := pdubuilder.CreateNrtransmissionBandwIDth(servCell.Nrscs, servCell.Nrnrb)
+	if err != nil {
+		return nil, nil, err
+	}
+	nrModeInfo, err := pdubuilder.CreateNrmodeInfoFdd(nrfreqInfo, nrfreqInfo, transmBW, transmBW)
+	if err != nil {
+		return nil, nil, err
+	}
+	nrModeInfoch, err := pdubuilder.CreateNrmodeInfoFddChoice(nrModeInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	connSupport, err := pdubuilder.CreateConnectivitySupport(pdubuilder.CreateENdcsupportConnectivitySupportNotSupported())
+	if err != nil {
+		return nil, nil, err
 	}
-	list = append(list, item3)
 
-	// creating list of served cells NR
-	servedCellsNRList := make([]*xnapiesv1.ServedCellsNRItem, 0)
-	for _, servCell := range xnSCellItemInfo {
-		nrCellidentity, err := pdubuilder.CreateNrCellIdentity(&asn1.BitString{
-			Value: servCell.NrCellIDBytes,
-			Len:   servCell.NrCellIDLen,
+	servedCellInfoNr := &xnapiesv1.ServedCellInformationNR{
+		NrPci: &xnapiesv1.Nrpci{
+			Value: servCell.NrPCI,
+		},
+		CellId: ncgi,
+		Tac: &xnapiesv1.Tac{
+			Value: cellTac(servCell, tacBytes),
+		},
+		Ranac: &xnapiesv1.Ranac{
+			Value: servCell.RanAC,
+		},
+		BroadcastPlmn: &xnapiesv1.BroadcastPlmns{
+			Value: []*xnapiesv1.PlmnIdentity{plmnID},
+		},
+		NrModeInfo:                     nrModeInfoch,
+		MeasurementTimingConfiguration: servCell.MeasureTimingConfigurationBytes,
+		ConnectivitySupport:            connSupport,
+	}
+
+	neighbourInfoNrList := make([]*xnapiesv1.NeighbourInformationNRItem, 0)
+	for _, nCell := range neighborCells {
+		neighborNrCellidentity, err := pdubuilder.CreateNrCellIdentity(&asn1.BitString{
+			Value: nCell.NrCellIDBytes,
+			Len:   nCell.NrCellIDLen,
 		})
 		if err != nil {
-			log.Warnf("%+v NrCellIDBytes and/or %+v NrCellIDLen is not valid, err: %+v", servCell.NrCellIDBytes, servCell.NrCellIDLen, err)
+			log.Warnf("%+v NrCellIDBytes and/or %+v NrCellIDLen is not valid, err: %+v", nCell.NrCellIDBytes, nCell.NrCellIDLen, err)
 			continue
 		}
-		ncgi, err := pdubuilder.CreateNrCGi(plmnID, nrCellidentity)
-		if err != nil {
-			return nil, err
-		}
-		broadcastPlmnsList := make([]*xnapiesv1.PlmnIdentity, 0)
-		broadcastPlmnsList = append(broadcastPlmnsList, plmnID)
-
-		freqBandList := make([]*xnapiesv1.NrfrequencyBandItem, 0)
-		freqBand, err := pdubuilder.CreateNrfrequencyBand(servCell.FreqBand)
-		if err != nil {
-			return nil, err
-		}
-		supportedSulBandList := make([]*xnapiesv1.SupportedSulbandItem, 0)
-		sulBandItem := &xnapiesv1.SupportedSulbandItem{
-			SulBandItem: &xnapiesv1.SulFrequencyBand{
-				Value: servCell.SulFreqBand,
-			},
-		}
-		supportedSulBandList = append(supportedSulBandList, sulBandItem)
-		freqBandItem := &xnapiesv1.NrfrequencyBandItem{
-			NrFrequencyBand: freqBand,
-			SupportedSulBandList: &xnapiesv1.SupportedSulbandList{
-				Value: supportedSulBandList,
-			},
-		}
-		freqBandList = append(freqBandList, freqBandItem)
-		nrfreqInfo := &xnapiesv1.NrfrequencyInfo{
-			NrArfcn: &xnapiesv1.Nrarfcn{
-				Value: servCell.NrArfcn,
-			},
-			FrequencyBandList: &xnapiesv1.NrfrequencyBandList{
-				Value: freqBandList,
-			},
-		}
-		transmBW, err := pdubuilder.CreateNrtransmissionBandwIDth(pdubuilder.CreateNrscsScs120(), pdubuilder.CreateNrnrbNrb24())
-		if err != nil {
-			return nil, err
-		}
-		nrModeInfo, err := pdubuilder.CreateNrmodeInfoFdd(nrfreqInfo, nrfreqInfo, transmBW, transmBW)
+		neighborNcgi, err := pdubuilder.CreateNrCGi(plmnID, neighborNrCellidentity)
 		if err != nil {
-			return nil, err
+			log.Warnf("failed to create nrcgi: %v", err)
+			return nil, nil, err
 		}
-		nrModeInfoch, err := pdubuilder.CreateNrmodeInfoFddChoice(nrModeInfo)
+		nrfreqInfo, err := createNrFrequencyInfo(nCell)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		connSupport, err := pdubuilder.CreateConnectivitySupport(pdubuilder.CreateENdcsupportConnectivitySupportNotSupported())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		servedCellInfoNr := &xnapiesv1.ServedCellInformationNR{
+		neighbourInfoNrList = append(neighbourInfoNrList, &xnapiesv1.NeighbourInformationNRItem{
 			NrPci: &xnapiesv1.Nrpci{
-				Value: servCell.NrPCI,
+				Value: nCell.NrPCI,
 			},
-			CellId: ncgi,
+			NrCgi: neighborNcgi,
 			Tac: &xnapiesv1.Tac{
-				Value: tacBytes,
+				Value: cellTac(nCell, tacBytes),
 			},
 			Ranac: &xnapiesv1.Ranac{
-				Value: servCell.RanAC,
+				Value: nCell.RanAC,
 			},
-			BroadcastPlmn: &xnapiesv1.BroadcastPlmns{
-				Value: broadcastPlmnsList,
-			},
-			NrModeInfo:                     nrModeInfoch,
-			MeasurementTimingConfiguration: servCell.MeasureTimingConfigurationBytes,
-			ConnectivitySupport:            connSupport,
-		}
-
-		neighbourInfoNrList := make([]*xnapiesv1.NeighbourInformationNRItem, 0)
-		for _, nCell := range xnNeighborCells[servCell.NCGIKey] {
-			neighborNrCellidentity, err := pdubuilder.CreateNrCellIdentity(&asn1.BitString{
-				Value: nCell.NrCellIDBytes,
-				Len:   nCell.NrCellIDLen,
-			})
-			if err != nil {
-				log.Warnf("%+v NrCellIDBytes and/or %+v NrCellIDLen is not valid, err: %+v", nCell.NrCellIDBytes, nCell.NrCellIDBytes, err)
-				continue
-			}
-			neighborNcgi, err := pdubuilder.CreateNrCGi(plmnID, neighborNrCellidentity)
-			if err != nil {
-				log.Warnf("failed to create nrcgi: %v", err)
-				return nil, err
-			}
-
-			freqBandList := make([]*xnapiesv1.NrfrequencyBandItem, 0)
-			freqBand, err := pdubuilder.CreateNrfrequencyBand(nCell.FreqBand)
-			if err != nil {
-				return nil, err
-			}
-			supportedSulBandList := make([]*xnapiesv1.SupportedSulbandItem, 0)
-			sulBandItem := &xnapiesv1.SupportedSulbandItem{
-				SulBandItem: &xnapiesv1.SulFrequencyBand{
-					Value: nCell.SulFreqBand,
-				},
-			}
-			supportedSulBandList = append(supportedSulBandList, sulBandItem)
-			freqBandItem := &xnapiesv1.NrfrequencyBandItem{
-				NrFrequencyBand: freqBand,
-				SupportedSulBandList: &xnapiesv1.SupportedSulbandList{
-					Value: supportedSulBandList,
-				},
-			}
-			freqBandList = append(freqBandList, freqBandItem)
-			nrfreqInfo := &xnapiesv1.NrfrequencyInfo{
-				NrArfcn: &xnapiesv1.Nrarfcn{
-					Value: nCell.NrArfcn,
-				},
-				FrequencyBandList: &xnapiesv1.NrfrequencyBandList{
-					Value: freqBandList,
-				},
-			}
-			connSupport, err := pdubuilder.CreateConnectivitySupport(pdubuilder.CreateENdcsupportConnectivitySupportNotSupported())
-			if err != nil {
-				return nil, err
-			}
-
-			neighbourInfoNrList = append(neighbourInfoNrList, &xnapiesv1.NeighbourInformationNRItem{
-				NrPci: &xnapiesv1.Nrpci{
-					Value: nCell.NrPCI,
-				},
-				NrCgi: neighborNcgi,
-				Tac: &xnapiesv1.Tac{
-					Value: tacBytes,
-				},
-				Ranac: &xnapiesv1.Ranac{
-					Value: nCell.RanAC,
-				},
-				NrModeInfo: &xnapiesv1.NeighbourInformationNRModeInfo{
-					NeighbourInformationNrModeInfo: &xnapiesv1.NeighbourInformationNRModeInfo_FddInfo{
-						FddInfo: &xnapiesv1.NeighbourInformationNRModeFddinfo{
-							UlNrFreqInfo: nrfreqInfo,
-							DlNrFequInfo: nrfreqInfo,
-						},
+			NrModeInfo: &xnapiesv1.NeighbourInformationNRModeInfo{
+				NeighbourInformationNrModeInfo: &xnapiesv1.NeighbourInformationNRModeInfo_FddInfo{
+					FddInfo: &xnapiesv1.NeighbourInformationNRModeFddinfo{
+						UlNrFreqInfo: nrfreqInfo,
+						DlNrFequInfo: nrfreqInfo,
 					},
 				},
-				MeasurementTimingConfiguration: nCell.MeasureTimingConfigurationBytes,
-				ConnectivitySupport:            connSupport,
-			})
-		}
-		nrItem := &xnapiesv1.ServedCellsNRItem{
-			ServedCellInfoNr: servedCellInfoNr,
-			NeighbourInfoNr: &xnapiesv1.NeighbourInformationNR{
-				Value: neighbourInfoNrList,
 			},
-		}
-		servedCellsNRList = append(servedCellsNRList, nrItem)
-	}
-
-	val4, err := pdubuilder.CreateXnSetupRequestIEsValueIDListOfServedCellsNr(&xnapiesv1.ServedCellsNR{
-		Value: servedCellsNRList,
-	})
-	if err != nil {
-		return nil, err
-	}
-	item4 := &xnappducontentsv1.XnSetupRequestIEs{
-		Id:          int32(v1.ProtocolIeIDListofservedcellsNR),
-		Criticality: xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
-		Value:       val4,
-	}
-	list = append(list, item4)
-
-	xnSetupRequest, err := pdubuilder.CreateXnSetupRequest(list)
-	if err != nil {
-		return nil, err
-	}
-	newXnApPdu := &xnappdudescriptionsv1.XnApPDu{
-		XnApPdu: &xnappdudescriptionsv1.XnApPDu_InitiatingMessage{
-			InitiatingMessage: &xnappdudescriptionsv1.InitiatingMessage{
-				ProcedureCode: int32(v1.ProcedureCodeIDxnSetup),
-				Criticality:   xnapcommondatatypesv1.Criticality_CRITICALITY_REJECT,
-				Value: &xnappdudescriptionsv1.InitiatingMessageXnApElementaryProcedures{
-					ImValues: &xnappdudescriptionsv1.InitiatingMessageXnApElementaryProcedures_XnSetupRequest{
-						XnSetupRequest: xnSetupRequest,
-					},
-				},
-			},
-		},
+			MeasurementTimingConfiguration: nCell.MeasureTimingConfigurationBytes,
+			ConnectivitySupport:            connSupport,
+		})
 	}
-
-	return encoder.PerEncodeXnApPdu(newXnApPdu)
+	return servedCellInfoNr, &xnapiesv1.NeighbourInformationNR{Value: neighbourInfoNrList}, nil
 }
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package xnap
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-e2t/api/xnap/v1/choiceOptions"
+	xnapiesv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-ies"
+	xnappducontentsv1 "github.com/onosproject/onos-e2t/api/xnap/v1/xnap-pdu-contents"
+	"github.com/onosproject/onos-e2t/pkg/southbound/xnap/encoder"
+	"github.com/onosproject/onos-lib-go/pkg/asn1/aper"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPlmnID = []byte{0x13, 0xF1, 0x84}
+	testTac    = []byte{0x00, 0x00, 0x07}
+	testSlices = []XnItemSlice{{Sst: []byte{0x01}, Sd: []byte{0x00, 0x00, 0x01}}}
+	testCell   = XnItemCellInfo{
+		NCGIKey:                         1,
+		NrCellIDBytes:                   []byte{0x00, 0x00, 0x00, 0x00, 0x10},
+		NrCellIDLen:                     36,
+		NrPCI:                           11,
+		NrArfcn:                         630000,
+		SulFreqBand:                     80,
+		FreqBand:                        78,
+		MeasureTimingConfigurationBytes: []byte{0xF1},
+		RanAC:                           255,
+		Nrscs:                           xnapiesv1.Nrscs_NRSCS_SCS30,
+		Nrnrb:                           xnapiesv1.Nrnrb_NRNRB_NRB273,
+	}
+	testNeighbor = XnItemCellInfo{
+		NCGIKey:                         2,
+		NrCellIDBytes:                   []byte{0x00, 0x00, 0x01, 0x00, 0x10},
+		NrCellIDLen:                     36,
+		NrPCI:                           12,
+		NrArfcn:                         630000,
+		SulFreqBand:                     80,
+		FreqBand:                        78,
+		MeasureTimingConfigurationBytes: []byte{0xF1},
+		RanAC:                           255,
+		TacBytes:                        []byte{0x00, 0x00, 0x08},
+	}
+)
+
+// decodeMessage decodes the message wrapped in an XnAP-PDU by encodeMessage
+func decodeMessage(t *testing.T, pdu []byte, choice byte, message interface{}) {
+	assert.Equal(t, choice, pdu[0])
+	value := pdu[4:]
+	if pdu[3]&0x80 != 0 {
+		value = pdu[5:]
+	}
+	assert.NoError(t, aper.UnmarshalWithParams(value, message, "valueExt", choiceOptions.XnapChoicemap, choiceOptions.XnapCanonicalChoicemap))
+}
+
+func TestXnSetup(t *testing.T) {
+	request, err := CreateXnSetupRequest(testPlmnID, []byte{0x00, 0x00, 0x04}, testTac, testSlices,
+		XnItemAMFRegion{AmfRegionID: []byte{0x01}, AmfRegionIDLen: 8}, []XnItemCellInfo{testCell},
+		map[types.NCGI][]XnItemCellInfo{testCell.NCGIKey: {testNeighbor}})
+	assert.NoError(t, err)
+	pdu, err := encoder.PerDecodeXnApPdu(request)
+	assert.NoError(t, err)
+	ies := pdu.GetInitiatingMessage().GetValue().GetXnSetupRequest().GetProtocolIes()
+	assert.Equal(t, []byte{0x01}, ies[2].GetValue().GetIdAmfRegionInformation().GetValue()[0].GetAmfRegionId().GetValue())
+	cell := ies[3].GetValue().GetIdListOfServedCellsNr().GetValue()[0]
+	assert.Equal(t, testTac, cell.GetServedCellInfoNr().GetTac().GetValue())
+	assert.Equal(t, xnapiesv1.Nrnrb_NRNRB_NRB273, cell.GetServedCellInfoNr().GetNrModeInfo().GetFdd().GetUlNrtransmissonBandwidth().GetNRnrb())
+	assert.Equal(t, testNeighbor.TacBytes, cell.GetNeighbourInfoNr().GetValue()[0].GetTac().GetValue())
+}
+
+func TestXnSetupResponse(t *testing.T) {
+	response, err := CreateXnSetupResponse(testPlmnID, []byte{0x00, 0x00, 0x04}, testTac, testSlices, []XnItemCellInfo{testCell},
+		map[types.NCGI][]XnItemCellInfo{testCell.NCGIKey: {testNeighbor}})
+	assert.NoError(t, err)
+	pdu, err := encoder.PerDecodeXnApPdu(response)
+	assert.NoError(t, err)
+	ies := pdu.GetSuccessfulOutcome().GetValue().GetXnSetupResponse().GetProtocolIes()
+	assert.Len(t, ies, 3)
+	cell := ies[2].GetValue().GetIdListOfServedCellsNr().GetValue()[0]
+	assert.Equal(t, int32(11), cell.GetServedCellInfoNr().GetNrPci().GetValue())
+	assert.Len(t, cell.GetNeighbourInfoNr().GetValue(), 1)
+}
+
+func TestNgRanNodeConfigurationUpdate(t *testing.T) {
+	update, err := CreateNgRanNodeConfigurationUpdate(testPlmnID, testTac, testSlices, []XnItemCellInfo{testCell},
+		map[types.NCGI][]XnItemCellInfo{testCell.NCGIKey: {testNeighbor}})
+	assert.NoError(t, err)
+	message := &xnappducontentsv1.NgrannodeConfigurationUpdate{}
+	decodeMessage(t, update, initiatingMessage, message)
+	ies := message.GetProtocolIes()
+	assert.Len(t, ies, 2)
+	cells := ies[1].GetValue().GetIdConfigurationUpdateInitiatingNodeChoice().GetGNb().GetValue().GetIdServedCellsToUpdateNr().GetServedCellsToModifyNr().GetValue()
+	assert.Len(t, cells, 1)
+	assert.Equal(t, int32(11), cells[0].GetServedCellInfoNr().GetNrPci().GetValue())
+	assert.Len(t, cells[0].GetNeighbourInfoNr().GetValue(), 1)
+}
+
+func TestHandover(t *testing.T) {
+	acknowledge, err := CreateHandoverRequestAcknowledge(1, 2)
+	assert.NoError(t, err)
+	ack := &xnappducontentsv1.HandoverRequestAcknowledge{}
+	decodeMessage(t, acknowledge, successfulOutcome, ack)
+	assert.Len(t, ack.GetProtocolIes(), 4)
+	assert.Equal(t, int64(2), ack.GetProtocolIes()[1].GetValue().GetIdTargetNgRannodeUexnApid().GetValue())
+
+	transfer, err := CreateSNStatusTransfer(1, 2, []XnItemDrbStatus{{DrbID: 1, UlPdcpSn: 10, DlPdcpSn: 20, DlHfn: 1}})
+	assert.NoError(t, err)
+	status := &xnappducontentsv1.SnstatusTransfer{}
+	decodeMessage(t, transfer, initiatingMessage, status)
+	drb := status.GetProtocolIes()[2].GetValue().GetIdDrbsSubjectToStatusTransferList().GetValue()[0]
+	assert.Equal(t, int32(20), drb.GetPdcpStatusTransferDl().GetPdcpSn_12Bits().GetCOuntvalue().GetPdcpSn12())
+}